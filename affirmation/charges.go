@@ -0,0 +1,56 @@
+package affirmation
+
+import (
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+// Fee is a MiscFees entry of a Confirmation
+type Fee struct {
+	Type enum.MiscFeeType
+	Amt  decimal.Decimal
+	Curr string
+}
+
+// Charges are the commission and fees of an allocation account
+type Charges struct {
+	// Commission is a rate for CommType 1 (per unit) and 2 (fraction of the gross amount,
+	// e.g. 0.0015), an amount for CommType 3 (absolute)
+	Commission decimal.Decimal
+	CommType   enum.CommType
+	Fees       []Fee
+}
+
+// Amounts are the money fields of a Confirmation
+type Amounts struct {
+	GrossTradeAmt decimal.Decimal
+	Commission    decimal.Decimal
+	Fees          decimal.Decimal
+	NetMoney      decimal.Decimal
+}
+
+// Compute returns the amounts of qty at avgPx with charges c.
+// NetMoney adds the charges to the gross amount of a buy and subtracts them from a sell.
+func Compute(side enum.Side, qty, avgPx decimal.Decimal, c Charges) Amounts {
+	var a Amounts
+	a.GrossTradeAmt = qty.Mul(avgPx)
+	switch c.CommType {
+	case enum.CommType_PER_UNIT:
+		a.Commission = c.Commission.Mul(qty)
+	case enum.CommType_PERCENT:
+		a.Commission = c.Commission.Mul(a.GrossTradeAmt)
+	default:
+		a.Commission = c.Commission
+	}
+	a.Fees = decimal.Zero
+	for _, f := range c.Fees {
+		a.Fees = a.Fees.Add(f.Amt)
+	}
+	charges := a.Commission.Add(a.Fees)
+	if side == enum.Side_SELL || side == enum.Side_SELL_SHORT || side == enum.Side_SELL_SHORT_EXEMPT {
+		a.NetMoney = a.GrossTradeAmt.Sub(charges)
+	} else {
+		a.NetMoney = a.GrossTradeAmt.Add(charges)
+	}
+	return a
+}
@@ -0,0 +1,37 @@
+package affirmation
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestCompute(t *testing.T) {
+	fees := []Fee{{Type: enum.MiscFeeType("1"), Amt: dec("2")}, {Type: enum.MiscFeeType("2"), Amt: dec("3")}}
+	tests := []struct {
+		name    string
+		side    enum.Side
+		charges Charges
+		want    Amounts
+	}{
+		{"no charges", enum.Side_BUY, Charges{}, Amounts{dec("1000"), dec("0"), dec("0"), dec("1000")}},
+		{"per unit buy", enum.Side_BUY, Charges{Commission: dec("0.1"), CommType: enum.CommType_PER_UNIT}, Amounts{dec("1000"), dec("10"), dec("0"), dec("1010")}},
+		{"percent sell", enum.Side_SELL, Charges{Commission: dec("0.0015"), CommType: enum.CommType_PERCENT}, Amounts{dec("1000"), dec("1.5"), dec("0"), dec("998.5")}},
+		{"absolute with fees", enum.Side_SELL_SHORT, Charges{Commission: dec("7"), CommType: enum.CommType_ABSOLUTE, Fees: fees},
+			Amounts{dec("1000"), dec("7"), dec("5"), dec("988")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compute(tt.side, dec("100"), dec("10"), tt.charges)
+			if !got.GrossTradeAmt.Equal(tt.want.GrossTradeAmt) || !got.Commission.Equal(tt.want.Commission) ||
+				!got.Fees.Equal(tt.want.Fees) || !got.NetMoney.Equal(tt.want.NetMoney) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
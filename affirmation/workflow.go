@@ -0,0 +1,371 @@
+package affirmation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/allocation"
+	"github.com/quickfixgo/fix44/confirmation"
+	"github.com/quickfixgo/fix44/confirmationack"
+	"github.com/quickfixgo/fix44/confirmationrequest"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrNotAccepted is returned when confirming an allocation that is not accepted
+	ErrNotAccepted = errors.New("affirmation: allocation is not accepted")
+	// ErrUnknownConfirmID is returned for a ConfirmationAck of a ConfirmID not sent by the Workflow
+	ErrUnknownConfirmID = errors.New("affirmation: unknown ConfirmID")
+	// ErrUnknownAllocID is returned for a ConfirmationRequest of an AllocID without Confirmation
+	ErrUnknownAllocID = errors.New("affirmation: unknown AllocID")
+)
+
+// State is the affirmation state of a Confirmation
+type State int
+
+const (
+	// Sent Confirmations have no ConfirmationAck yet
+	Sent State = iota
+	// Received Confirmations were acknowledged without being affirmed yet
+	Received
+	Affirmed
+	Rejected
+)
+
+func (s State) String() string {
+	switch s {
+	case Sent:
+		return "sent"
+	case Received:
+		return "received"
+	case Affirmed:
+		return "affirmed"
+	case Rejected:
+		return "rejected"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Confirm is a Confirmation sent for an allocation account
+type Confirm struct {
+	ConfirmID         string
+	AllocID           string
+	Account           string
+	IndividualAllocID string
+	Symbol            string
+	Side              enum.Side
+	TradeDate         string
+	Qty               decimal.Decimal
+	AvgPx             decimal.Decimal
+	Amounts
+
+	State        State
+	AffirmStatus enum.AffirmStatus
+	RejReason    enum.ConfirmRejReason
+	Text         string
+	SentAt       time.Time
+	UpdatedAt    time.Time
+}
+
+// Workflow sends a Confirmation per account of the accepted allocations
+// and tracks their affirmation from ConfirmationAcks.
+// A Workflow is safe for concurrent use.
+type Workflow struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// Currency is set on the Confirmations if not empty
+	Currency string
+	// OrderCapacity is the capacity of the Confirmations, New sets it to agency
+	OrderCapacity enum.OrderCapacity
+	// Charges, if set, returns the commission and fees of an account of an allocation
+	Charges func(a allocation.Allocation, s allocation.Split) Charges
+	// OnChange, if set, is called with a copy of a Confirm each time it changes.
+	// It is called once the Workflow is unlocked, so it can call the Workflow.
+	OnChange func(Confirm)
+
+	mu       sync.Mutex
+	nextID   int
+	idPrefix string
+	confirms map[string]*Confirm
+	// sent are the ConfirmIDs in sending order
+	sent    []string
+	charges map[string]Charges
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns a Workflow for the session
+func New(sessionID quickfix.SessionID) *Workflow {
+	return &Workflow{
+		SessionID:     sessionID,
+		Send:          quickfix.SendToTarget,
+		OrderCapacity: enum.OrderCapacity_AGENCY,
+		idPrefix:      time.Now().Format("150405") + "-",
+		confirms:      make(map[string]*Confirm),
+		charges:       make(map[string]Charges),
+	}
+}
+
+// Confirm sends a Confirmation for each account of an accepted allocation,
+// it returns the ConfirmIDs
+func (w *Workflow) Confirm(a allocation.Allocation) ([]string, error) {
+	if a.State != allocation.Accepted || a.TransType == enum.AllocTransType_CANCEL {
+		return nil, ErrNotAccepted
+	}
+	avgPx := a.Block.AvgPx()
+
+	w.mu.Lock()
+	defer w.unlock()
+	var ids []string
+	for _, s := range a.Splits {
+		var charges Charges
+		if w.Charges != nil {
+			charges = w.Charges(a, s)
+		}
+		w.nextID++
+		c := &Confirm{
+			ConfirmID:         fmt.Sprintf("%s%d", w.idPrefix, w.nextID),
+			AllocID:           a.AllocID,
+			Account:           s.Account,
+			IndividualAllocID: s.IndividualAllocID,
+			Symbol:            a.Block.Symbol,
+			Side:              a.Block.Side,
+			TradeDate:         a.Block.TradeDate,
+			Qty:               s.Qty,
+			AvgPx:             avgPx,
+			Amounts:           Compute(a.Block.Side, s.Qty, avgPx, charges),
+		}
+		c.SentAt = time.Now()
+		c.UpdatedAt = c.SentAt
+		if err := w.Send(w.build(c, charges, ""), w.SessionID); err != nil {
+			return ids, err
+		}
+		w.confirms[c.ConfirmID] = c
+		w.sent = append(w.sent, c.ConfirmID)
+		w.charges[c.ConfirmID] = charges
+		ids = append(ids, c.ConfirmID)
+		w.changed(c)
+	}
+	return ids, nil
+}
+
+// scale returns the number of decimal places of v without its trailing zeros,
+// so that a quantity or a price is sent unrounded
+func scale(v decimal.Decimal) int32 {
+	var s int32
+	for !v.Truncate(s).Equal(v) {
+		s++
+	}
+	return s
+}
+
+func (w *Workflow) build(c *Confirm, charges Charges, confirmReqID string) confirmation.Confirmation {
+	m := confirmation.New(field.NewConfirmID(c.ConfirmID), field.NewConfirmTransType(enum.ConfirmTransType_NEW),
+		field.NewConfirmType(enum.ConfirmType_CONFIRMATION), field.NewConfirmStatus(enum.ConfirmStatus_CONFIRMED),
+		field.NewTransactTime(c.SentAt), field.NewTradeDate(c.TradeDate), field.NewAllocQty(c.Qty, scale(c.Qty)),
+		field.NewSide(c.Side), field.NewAllocAccount(c.Account), field.NewAvgPx(c.AvgPx, scale(c.AvgPx)),
+		field.NewGrossTradeAmt(c.GrossTradeAmt, 2), field.NewNetMoney(c.NetMoney, 2))
+	m.SetAllocID(c.AllocID)
+	if c.IndividualAllocID != "" {
+		m.SetIndividualAllocID(c.IndividualAllocID)
+	}
+	if confirmReqID != "" {
+		m.SetConfirmReqID(confirmReqID)
+	}
+	m.SetSymbol(c.Symbol)
+	if w.Currency != "" {
+		m.SetCurrency(w.Currency)
+	}
+	if charges.CommType != "" {
+		m.SetCommission(charges.Commission, 4)
+		m.SetCommType(charges.CommType)
+	}
+	if len(charges.Fees) > 0 {
+		fees := confirmation.NewNoMiscFeesRepeatingGroup()
+		for _, f := range charges.Fees {
+			e := fees.Add()
+			e.SetMiscFeeAmt(f.Amt, 2)
+			if f.Curr != "" {
+				e.SetMiscFeeCurr(f.Curr)
+			}
+			e.SetMiscFeeType(f.Type)
+		}
+		m.SetNoMiscFees(fees)
+	}
+	capacities := confirmation.NewNoCapacitiesRepeatingGroup()
+	capacity := capacities.Add()
+	capacity.SetOrderCapacity(w.OrderCapacity)
+	capacity.SetOrderCapacityQty(c.Qty, scale(c.Qty))
+	m.SetNoCapacities(capacities)
+	return m
+}
+
+// Process handles a ConfirmationAck or a ConfirmationRequest,
+// other messages are ignored. It is meant to be called from FromApp.
+func (w *Workflow) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "AU":
+		return w.OnConfirmationAck(confirmationack.FromMessage(msg))
+	case "BH":
+		return w.OnConfirmationRequest(confirmationrequest.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnConfirmationAck applies the AffirmStatus of an ack to its Confirm
+func (w *Workflow) OnConfirmationAck(ack confirmationack.ConfirmationAck) error {
+	confirmID, err := ack.GetConfirmID()
+	if err != nil {
+		return err
+	}
+	status, err := ack.GetAffirmStatus()
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.unlock()
+	c, ok := w.confirms[confirmID]
+	if !ok {
+		return ErrUnknownConfirmID
+	}
+	c.AffirmStatus = status
+	c.RejReason, _ = ack.GetConfirmRejReason()
+	c.Text, _ = ack.GetText()
+	c.UpdatedAt = time.Now()
+	switch status {
+	case enum.AffirmStatus_RECEIVED:
+		if c.State == Sent {
+			c.State = Received
+		}
+	case enum.AffirmStatus_CONFIRM_REJECTED_IE_NOT_AFFIRMED:
+		c.State = Rejected
+	case enum.AffirmStatus_AFFIRMED:
+		c.State = Affirmed
+	}
+	w.changed(c)
+	return nil
+}
+
+// OnConfirmationRequest sends again the Confirmations of the requested AllocID,
+// restricted to the requested AllocAccount if any
+func (w *Workflow) OnConfirmationRequest(req confirmationrequest.ConfirmationRequest) error {
+	confirmReqID, err := req.GetConfirmReqID()
+	if err != nil {
+		return err
+	}
+	allocID, _ := req.GetAllocID()
+	account, _ := req.GetAllocAccount()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	found := false
+	for _, c := range w.inSendOrder() {
+		if c.AllocID != allocID || (account != "" && c.Account != account) {
+			continue
+		}
+		found = true
+		if err := w.Send(w.build(c, w.charges[c.ConfirmID], confirmReqID), w.SessionID); err != nil {
+			return err
+		}
+	}
+	if !found {
+		return ErrUnknownAllocID
+	}
+	return nil
+}
+
+// inSendOrder returns the Confirms in sending order, it must be called with mu held
+func (w *Workflow) inSendOrder() []*Confirm {
+	confirms := make([]*Confirm, len(w.sent))
+	for i, id := range w.sent {
+		confirms[i] = w.confirms[id]
+	}
+	return confirms
+}
+
+// changed queues the call of OnChange with a copy of c, it must be called with mu held
+func (w *Workflow) changed(c *Confirm) {
+	if w.OnChange != nil {
+		cp := *c
+		w.callbacks = append(w.callbacks, func() { w.OnChange(cp) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (w *Workflow) unlock() {
+	callbacks := w.callbacks
+	w.callbacks = nil
+	w.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// Get returns a copy of the Confirm with the ConfirmID
+func (w *Workflow) Get(confirmID string) (Confirm, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	c, ok := w.confirms[confirmID]
+	if !ok {
+		return Confirm{}, false
+	}
+	return *c, true
+}
+
+// Report is the end of day state of the Confirmations of a trade date
+type Report struct {
+	TradeDate string
+	Total     int
+	Affirmed  int
+	// Unconfirmed are the Confirms not affirmed, in sending order
+	Unconfirmed []Confirm
+}
+
+// String renders the report one unconfirmed item per line, after a summary line
+func (r Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "trade date %s: %d confirmations, %d affirmed, %d unconfirmed\n",
+		r.TradeDate, r.Total, r.Affirmed, len(r.Unconfirmed))
+	for _, c := range r.Unconfirmed {
+		fmt.Fprintf(&b, "%s ConfirmID=%s AllocID=%s Account=%s %s %s@%s NetMoney=%s",
+			c.State, c.ConfirmID, c.AllocID, c.Account, c.Symbol, c.Qty, c.AvgPx, c.NetMoney)
+		if c.Text != "" {
+			fmt.Fprintf(&b, " Text=%s", c.Text)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// EndOfDay reports the Confirmations of a trade date that were not affirmed,
+// all trade dates if tradeDate is empty
+func (w *Workflow) EndOfDay(tradeDate string) Report {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	r := Report{TradeDate: tradeDate}
+	for _, c := range w.inSendOrder() {
+		if tradeDate != "" && c.TradeDate != tradeDate {
+			continue
+		}
+		r.Total++
+		if c.State == Affirmed {
+			r.Affirmed++
+		} else {
+			r.Unconfirmed = append(r.Unconfirmed, *c)
+		}
+	}
+	return r
+}
@@ -0,0 +1,150 @@
+package affirmation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/allocation"
+	"github.com/quickfixgo/fix44/confirmation"
+	"github.com/quickfixgo/fix44/confirmationack"
+	"github.com/quickfixgo/fix44/confirmationrequest"
+	"github.com/quickfixgo/fix44/ordertracker"
+	"github.com/quickfixgo/quickfix"
+)
+
+// accepted returns an accepted allocation of 200.5 VND split evenly between the accounts A and B
+func accepted(allocID string) allocation.Allocation {
+	return allocation.Allocation{
+		AllocID:   allocID,
+		TransType: enum.AllocTransType_NEW,
+		State:     allocation.Accepted,
+		Block: allocation.Block{Symbol: "VND", Side: enum.Side_BUY, TradeDate: "20261019", Fills: []ordertracker.Fill{
+			{ExecID: "E1", LastQty: dec("200.5"), LastPx: dec("10.25")},
+		}},
+		Splits: []allocation.Split{{Account: "A", Qty: dec("100.25")}, {Account: "B", Qty: dec("100.25")}},
+	}
+}
+
+func ack(confirmID string, status enum.AffirmStatus) *quickfix.Message {
+	return confirmationack.New(field.NewConfirmID(confirmID), field.NewTradeDate("20261019"),
+		field.NewTransactTime(time.Now()), field.NewAffirmStatus(status)).ToMessage()
+}
+
+func TestConfirm(t *testing.T) {
+	w := New(quickfix.SessionID{})
+	var sent []confirmation.Confirmation
+	w.Send = func(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+		sent = append(sent, confirmation.FromMessage(m.ToMessage()))
+		return nil
+	}
+	pending := accepted("A1")
+	pending.State = allocation.Pending
+	if _, err := w.Confirm(pending); !errors.Is(err, ErrNotAccepted) {
+		t.Errorf("got %v for a pending allocation, want %v", err, ErrNotAccepted)
+	}
+	ids, err := w.Confirm(accepted("A1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || len(sent) != 2 {
+		t.Fatalf("got ConfirmIDs %v and %d sent", ids, len(sent))
+	}
+	// the quantities are sent unrounded
+	body := sent[0].ToMessage().String()
+	for _, want := range []string{"\x0180=100.25\x01", "\x01863=100.25\x01", "\x016=10.25\x01", "\x0179=A\x01"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("got %q, want it to contain %q", body, want)
+		}
+	}
+}
+
+func TestConfirmationAck(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []enum.AffirmStatus
+		want     State
+	}{
+		{"sent", nil, Sent},
+		{"received", []enum.AffirmStatus{enum.AffirmStatus_RECEIVED}, Received},
+		{"affirmed", []enum.AffirmStatus{enum.AffirmStatus_RECEIVED, enum.AffirmStatus_AFFIRMED}, Affirmed},
+		{"received after affirmed", []enum.AffirmStatus{enum.AffirmStatus_AFFIRMED, enum.AffirmStatus_RECEIVED}, Affirmed},
+		{"rejected", []enum.AffirmStatus{enum.AffirmStatus_RECEIVED, enum.AffirmStatus_CONFIRM_REJECTED_IE_NOT_AFFIRMED}, Rejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := New(quickfix.SessionID{})
+			w.Send = func(quickfix.Messagable, quickfix.SessionID) error { return nil }
+			ids, _ := w.Confirm(accepted("A1"))
+			var states []State
+			w.OnChange = func(c Confirm) { states = append(states, c.State) }
+			for _, status := range tt.statuses {
+				if err := w.Process(ack(ids[0], status)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if c, _ := w.Get(ids[0]); c.State != tt.want || len(states) != len(tt.statuses) {
+				t.Errorf("got %s after %d changes, want %s", c.State, len(states), tt.want)
+			}
+		})
+	}
+	w := New(quickfix.SessionID{})
+	if err := w.Process(ack("X", enum.AffirmStatus_AFFIRMED)); !errors.Is(err, ErrUnknownConfirmID) {
+		t.Errorf("got %v, want %v", err, ErrUnknownConfirmID)
+	}
+}
+
+func TestSendOrder(t *testing.T) {
+	w := New(quickfix.SessionID{})
+	var resent []string
+	w.Send = func(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+		c := confirmation.FromMessage(m.ToMessage())
+		if reqID, err := c.GetConfirmReqID(); err == nil && reqID == "R1" {
+			id, _ := c.GetConfirmID()
+			resent = append(resent, id)
+		}
+		return nil
+	}
+	// confirmations sent within the same clock tick come back in sending order
+	var want []string
+	for _, allocID := range []string{"A1", "A2", "A1", "A3", "A1"} {
+		ids, err := w.Confirm(accepted(allocID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allocID == "A1" {
+			want = append(want, ids...)
+		}
+	}
+	req := confirmationrequest.New(field.NewConfirmReqID("R1"), field.NewConfirmType(enum.ConfirmType_CONFIRMATION), field.NewTransactTime(time.Now()))
+	req.SetAllocID("A1")
+	if err := w.Process(req.ToMessage()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resent, want) {
+		t.Errorf("got %v resent, want %v", resent, want)
+	}
+	var unconfirmed []string
+	for _, c := range w.EndOfDay("20261019").Unconfirmed {
+		if c.AllocID == "A1" {
+			unconfirmed = append(unconfirmed, c.ConfirmID)
+		}
+	}
+	if !reflect.DeepEqual(unconfirmed, want) {
+		t.Errorf("got %v unconfirmed, want %v", unconfirmed, want)
+	}
+
+	req.SetAllocAccount("B")
+	resent = nil
+	if err := w.Process(req.ToMessage()); err != nil || len(resent) != 3 {
+		t.Errorf("got %v and %v resent for account B", err, resent)
+	}
+	req.SetAllocID("A9")
+	if err := w.Process(req.ToMessage()); !errors.Is(err, ErrUnknownAllocID) {
+		t.Errorf("got %v, want %v", err, ErrUnknownAllocID)
+	}
+}
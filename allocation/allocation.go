@@ -0,0 +1,117 @@
+package allocation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/ordertracker"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrNoFills is returned for a block without fills
+	ErrNoFills = errors.New("allocation: block without fills")
+	// ErrNoSplits is returned for an allocation without account
+	ErrNoSplits = errors.New("allocation: no account splits")
+)
+
+// QtyError is returned when the allocated quantities do not sum to the block quantity
+type QtyError struct {
+	Block     decimal.Decimal
+	Allocated decimal.Decimal
+}
+
+func (e *QtyError) Error() string {
+	return fmt.Sprintf("allocation: allocated quantity %s does not match block quantity %s", e.Allocated, e.Block)
+}
+
+// SplitError is returned for an invalid account split
+type SplitError struct {
+	Account string
+	Reason  string
+}
+
+func (e *SplitError) Error() string {
+	return fmt.Sprintf("allocation: account %q: %s", e.Account, e.Reason)
+}
+
+// Block is the set of fills of an instrument to allocate
+type Block struct {
+	Symbol    string
+	Side      enum.Side
+	TradeDate string
+	Fills     []ordertracker.Fill
+}
+
+// Qty returns the sum of the fill quantities
+func (b Block) Qty() decimal.Decimal {
+	qty := decimal.Zero
+	for _, f := range b.Fills {
+		qty = qty.Add(f.LastQty)
+	}
+	return qty
+}
+
+// AvgPx returns the average fill price weighted by quantity
+func (b Block) AvgPx() decimal.Decimal {
+	qty := b.Qty()
+	if qty.IsZero() {
+		return decimal.Zero
+	}
+	notional := decimal.Zero
+	for _, f := range b.Fills {
+		notional = notional.Add(f.LastQty.Mul(f.LastPx))
+	}
+	return notional.Div(qty)
+}
+
+// ClOrdIDs returns the ClOrdIDs of the fills, in order of first appearance
+func (b Block) ClOrdIDs() []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, f := range b.Fills {
+		if f.ClOrdID != "" && !seen[f.ClOrdID] {
+			seen[f.ClOrdID] = true
+			ids = append(ids, f.ClOrdID)
+		}
+	}
+	return ids
+}
+
+// Split is the quantity of a block given to an account
+type Split struct {
+	Account string
+	Qty     decimal.Decimal
+	// IndividualAllocID is optional, it identifies the split in account level rejects
+	IndividualAllocID string
+}
+
+// Validate checks that the splits are positive, on distinct accounts,
+// and sum to the block quantity
+func Validate(b Block, splits []Split) error {
+	if len(b.Fills) == 0 {
+		return ErrNoFills
+	}
+	if len(splits) == 0 {
+		return ErrNoSplits
+	}
+	allocated := decimal.Zero
+	seen := make(map[string]bool, len(splits))
+	for _, s := range splits {
+		switch {
+		case s.Account == "":
+			return &SplitError{Account: s.Account, Reason: "empty account"}
+		case seen[s.Account]:
+			return &SplitError{Account: s.Account, Reason: "duplicate account"}
+		case !s.Qty.IsPositive():
+			return &SplitError{Account: s.Account, Reason: "quantity must be positive"}
+		}
+		seen[s.Account] = true
+		allocated = allocated.Add(s.Qty)
+	}
+	if qty := b.Qty(); !allocated.Equal(qty) {
+		return &QtyError{Block: qty, Allocated: allocated}
+	}
+	return nil
+}
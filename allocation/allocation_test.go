@@ -0,0 +1,129 @@
+package allocation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/ordertracker"
+	"github.com/quickfixgo/quickfix"
+	"github.com/shopspring/decimal"
+)
+
+func qty(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+var block = Block{
+	Symbol: "VND",
+	Side:   enum.Side_BUY,
+	Fills: []ordertracker.Fill{
+		{ExecID: "E1", ClOrdID: "A1", LastQty: qty("600"), LastPx: qty("10")},
+		{ExecID: "E2", ClOrdID: "A1", LastQty: qty("400.5"), LastPx: qty("11")},
+	},
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		block  Block
+		splits []Split
+		want   error
+	}{
+		{"valid", block, []Split{{Account: "A", Qty: qty("500")}, {Account: "B", Qty: qty("500.5")}}, nil},
+		{"no fills", Block{Symbol: "VND"}, []Split{{Account: "A", Qty: qty("1")}}, ErrNoFills},
+		{"no splits", block, nil, ErrNoSplits},
+		{"empty account", block, []Split{{Qty: qty("1000.5")}}, &SplitError{}},
+		{"duplicate account", block, []Split{{Account: "A", Qty: qty("500")}, {Account: "A", Qty: qty("500.5")}}, &SplitError{}},
+		{"zero quantity", block, []Split{{Account: "A", Qty: qty("1000.5")}, {Account: "B"}}, &SplitError{}},
+		{"short", block, []Split{{Account: "A", Qty: qty("1000")}}, &QtyError{}},
+		{"over", block, []Split{{Account: "A", Qty: qty("1001")}}, &QtyError{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.block, tt.splits)
+			var splitErr *SplitError
+			var qtyErr *QtyError
+			switch {
+			case errors.As(tt.want, &splitErr):
+				if !errors.As(err, &splitErr) {
+					t.Errorf("got %v, want a SplitError", err)
+				}
+			case errors.As(tt.want, &qtyErr):
+				if !errors.As(err, &qtyErr) {
+					t.Errorf("got %v, want a QtyError", err)
+				}
+			case !errors.Is(err, tt.want):
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		v    string
+		want int32
+	}{
+		{"100", 0},
+		{"100.5", 1},
+		{"0.125", 3},
+		{"1e3", 0},
+		{"100.50", 1},
+		{"10.2500000000000000", 2},
+	}
+	for _, tt := range tests {
+		if got := scale(qty(tt.v)); got != tt.want {
+			t.Errorf("scale(%s) = %d, want %d", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestAllocationsInSendOrder(t *testing.T) {
+	w := New(quickfix.SessionID{})
+	w.Send = func(quickfix.Messagable, quickfix.SessionID) error { return nil }
+	var ids []string
+	for i := 0; i < 12; i++ {
+		id, err := w.Allocate(block, []Split{{Account: "A", Qty: qty("1000.5")}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	allocs := w.Allocations()
+	if len(allocs) != len(ids) {
+		t.Fatalf("got %d allocations, want %d", len(allocs), len(ids))
+	}
+	for i, a := range allocs {
+		if a.AllocID != ids[i] {
+			t.Errorf("allocation %d is %s, want %s", i, a.AllocID, ids[i])
+		}
+	}
+}
+
+func TestInstructionScales(t *testing.T) {
+	b := Block{Symbol: "VND", Side: enum.Side_BUY, Fills: []ordertracker.Fill{
+		{ExecID: "E1", ClOrdID: "A1", LastQty: qty("100"), LastPx: qty("10.5")},
+		{ExecID: "E2", ClOrdID: "A1", LastQty: qty("100.25"), LastPx: qty("10.25")},
+	}}
+	w := New(quickfix.SessionID{})
+	var sent *quickfix.Message
+	w.Send = func(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+		sent = m.ToMessage()
+		return nil
+	}
+	if _, err := w.Allocate(b, []Split{{Account: "A", Qty: qty("200.25")}}); err != nil {
+		t.Fatal(err)
+	}
+	body := sent.String()
+	for _, want := range []string{"\x0153=200.25\x01", "\x0132=100.25\x01", "\x0131=10.25\x01", "\x0131=10.5\x01", "\x0180=200.25\x01"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("got %q, want it to contain %q", body, want)
+		}
+	}
+	avgPx := b.AvgPx()
+	if !strings.Contains(body, "\x016="+avgPx.String()+"\x01") {
+		t.Errorf("got %q, want the AvgPx %s unrounded", body, avgPx)
+	}
+}
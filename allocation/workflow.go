@@ -0,0 +1,402 @@
+package allocation
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/allocationinstruction"
+	"github.com/quickfixgo/fix44/allocationinstructionack"
+	"github.com/quickfixgo/fix44/allocationreport"
+	"github.com/quickfixgo/fix44/allocationreportack"
+	"github.com/quickfixgo/fix44/ordertracker"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrUnknownAllocID is returned for an AllocID not sent by the Workflow
+	ErrUnknownAllocID = errors.New("allocation: unknown AllocID")
+	// ErrNotActive is returned when replacing or canceling an allocation that was
+	// rejected, canceled or replaced
+	ErrNotActive = errors.New("allocation: allocation is not active")
+)
+
+// State is the state of an allocation instruction in the Workflow
+type State int
+
+const (
+	// Pending instructions were sent without answer yet
+	Pending State = iota
+	// Received instructions were acknowledged with AllocStatus received, not yet accepted
+	Received
+	Accepted
+	Rejected
+	// Replaced and Canceled instructions were superseded by an accepted replace or cancel
+	Replaced
+	Canceled
+)
+
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Received:
+		return "received"
+	case Accepted:
+		return "accepted"
+	case Rejected:
+		return "rejected"
+	case Replaced:
+		return "replaced"
+	case Canceled:
+		return "canceled"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Allocation is a version of an allocation instruction sent by the Workflow
+type Allocation struct {
+	AllocID string
+	// RefAllocID is the AllocID replaced or canceled by this version
+	RefAllocID string
+	TransType  enum.AllocTransType
+	Block      Block
+	Splits     []Split
+
+	State       State
+	AllocStatus enum.AllocStatus
+	RejCode     enum.AllocRejCode
+	// AccountRejects maps the rejected accounts to their IndividualAllocRejCode
+	AccountRejects map[string]int
+	Text           string
+	UpdatedAt      time.Time
+}
+
+// Workflow sends AllocationInstructions and tracks their acknowledgements and reports.
+// A Workflow is safe for concurrent use.
+type Workflow struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// AckReports answers each AllocationReport with an AllocationReportAck
+	AckReports bool
+	// OnChange, if set, is called with a copy of an allocation each time it changes.
+	// It is called once the Workflow is unlocked, so it can call the Workflow.
+	OnChange func(Allocation)
+
+	mu       sync.Mutex
+	nextID   int
+	idPrefix string
+	allocs   map[string]*Allocation
+	// sent are the AllocIDs in the order they were sent
+	sent []string
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns a Workflow for the session
+func New(sessionID quickfix.SessionID) *Workflow {
+	return &Workflow{
+		SessionID: sessionID,
+		Send:      quickfix.SendToTarget,
+		idPrefix:  time.Now().Format("150405") + "-",
+		allocs:    make(map[string]*Allocation),
+	}
+}
+
+// Allocate validates the splits and sends a new AllocationInstruction for the block,
+// it returns the AllocID
+func (w *Workflow) Allocate(b Block, splits []Split) (string, error) {
+	if err := Validate(b, splits); err != nil {
+		return "", err
+	}
+	w.mu.Lock()
+	defer w.unlock()
+	return w.send(&Allocation{TransType: enum.AllocTransType_NEW, Block: b, Splits: splits})
+}
+
+// Replace sends a new version of an allocation with other splits, it returns the new AllocID
+func (w *Workflow) Replace(allocID string, splits []Split) (string, error) {
+	w.mu.Lock()
+	defer w.unlock()
+	old, err := w.active(allocID)
+	if err != nil {
+		return "", err
+	}
+	if err := Validate(old.Block, splits); err != nil {
+		return "", err
+	}
+	return w.send(&Allocation{RefAllocID: allocID, TransType: enum.AllocTransType_REPLACE, Block: old.Block, Splits: splits})
+}
+
+// Cancel asks to cancel an allocation, it returns the AllocID of the cancel
+func (w *Workflow) Cancel(allocID string) (string, error) {
+	w.mu.Lock()
+	defer w.unlock()
+	old, err := w.active(allocID)
+	if err != nil {
+		return "", err
+	}
+	return w.send(&Allocation{RefAllocID: allocID, TransType: enum.AllocTransType_CANCEL, Block: old.Block, Splits: old.Splits})
+}
+
+func (w *Workflow) active(allocID string) (*Allocation, error) {
+	a, ok := w.allocs[allocID]
+	if !ok {
+		return nil, ErrUnknownAllocID
+	}
+	if a.TransType == enum.AllocTransType_CANCEL {
+		return nil, ErrNotActive
+	}
+	switch a.State {
+	case Rejected, Replaced, Canceled:
+		return nil, ErrNotActive
+	}
+	return a, nil
+}
+
+// send must be called with mu held
+func (w *Workflow) send(a *Allocation) (string, error) {
+	w.nextID++
+	a.AllocID = fmt.Sprintf("%s%d", w.idPrefix, w.nextID)
+	a.UpdatedAt = time.Now()
+	if err := w.Send(buildInstruction(a), w.SessionID); err != nil {
+		return "", err
+	}
+	w.allocs[a.AllocID] = a
+	w.sent = append(w.sent, a.AllocID)
+	w.changed(a)
+	return a.AllocID, nil
+}
+
+// scale returns the number of decimal places of v without its trailing zeros,
+// so that a quantity or a price is sent unrounded
+func scale(v decimal.Decimal) int32 {
+	var s int32
+	for !v.Truncate(s).Equal(v) {
+		s++
+	}
+	return s
+}
+
+func buildInstruction(a *Allocation) allocationinstruction.AllocationInstruction {
+	b := a.Block
+	qty, avgPx := b.Qty(), b.AvgPx()
+	m := allocationinstruction.New(field.NewAllocID(a.AllocID), field.NewAllocTransType(a.TransType),
+		field.NewAllocType(enum.AllocType_PRELIMINARY), field.NewAllocNoOrdersType(enum.AllocNoOrdersType_EXPLICIT_LIST_PROVIDED),
+		field.NewSide(b.Side), field.NewQuantity(qty, scale(qty)), field.NewAvgPx(avgPx, scale(avgPx)), field.NewTradeDate(b.TradeDate))
+	if a.RefAllocID != "" {
+		m.SetRefAllocID(a.RefAllocID)
+	}
+	m.SetSymbol(b.Symbol)
+	m.SetTransactTime(a.UpdatedAt)
+
+	orders := allocationinstruction.NewNoOrdersRepeatingGroup()
+	for _, id := range b.ClOrdIDs() {
+		orders.Add().SetClOrdID(id)
+	}
+	m.SetNoOrders(orders)
+	execs := allocationinstruction.NewNoExecsRepeatingGroup()
+	for _, f := range b.Fills {
+		e := execs.Add()
+		e.SetLastQty(f.LastQty, scale(f.LastQty))
+		e.SetExecID(f.ExecID)
+		e.SetLastPx(f.LastPx, scale(f.LastPx))
+	}
+	m.SetNoExecs(execs)
+	allocs := allocationinstruction.NewNoAllocsRepeatingGroup()
+	for _, s := range a.Splits {
+		e := allocs.Add()
+		e.SetAllocAccount(s.Account)
+		if s.IndividualAllocID != "" {
+			e.SetIndividualAllocID(s.IndividualAllocID)
+		}
+		e.SetAllocQty(s.Qty, scale(s.Qty))
+	}
+	m.SetNoAllocs(allocs)
+	return m
+}
+
+// Process handles an AllocationInstructionAck or an AllocationReport,
+// other messages are ignored. It is meant to be called from FromApp.
+func (w *Workflow) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "P":
+		return w.OnAllocationInstructionAck(allocationinstructionack.FromMessage(msg))
+	case "AS":
+		return w.OnAllocationReport(allocationreport.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnAllocationInstructionAck applies the AllocStatus of an ack to its allocation
+func (w *Workflow) OnAllocationInstructionAck(ack allocationinstructionack.AllocationInstructionAck) error {
+	allocID, err := ack.GetAllocID()
+	if err != nil {
+		return err
+	}
+	status, err := ack.GetAllocStatus()
+	if err != nil {
+		return err
+	}
+	rejCode, _ := ack.GetAllocRejCode()
+	text, _ := ack.GetText()
+	var accountRejects map[string]int
+	if g, err := ack.GetNoAllocs(); err == nil {
+		for _, e := range g.All() {
+			code, err := e.GetIndividualAllocRejCode()
+			if err != nil {
+				continue
+			}
+			if accountRejects == nil {
+				accountRejects = make(map[string]int)
+			}
+			account, _ := e.GetAllocAccount()
+			accountRejects[account] = code
+		}
+	}
+
+	w.mu.Lock()
+	defer w.unlock()
+	a, ok := w.allocs[allocID]
+	if !ok {
+		return ErrUnknownAllocID
+	}
+	a.RejCode = rejCode
+	a.Text = text
+	a.AccountRejects = accountRejects
+	w.setStatus(a, status)
+	return nil
+}
+
+// OnAllocationReport applies the AllocStatus of a report to the allocation of its AllocID,
+// and acknowledges the report if AckReports is set
+func (w *Workflow) OnAllocationReport(r allocationreport.AllocationReport) error {
+	reportID, err := r.GetAllocReportID()
+	if err != nil {
+		return err
+	}
+	allocID, err := r.GetAllocID()
+	if err != nil {
+		return err
+	}
+	status, err := r.GetAllocStatus()
+	if err != nil {
+		return err
+	}
+	rejCode, _ := r.GetAllocRejCode()
+	text, _ := r.GetText()
+
+	w.mu.Lock()
+	a, ok := w.allocs[allocID]
+	if ok {
+		a.RejCode = rejCode
+		a.Text = text
+		w.setStatus(a, status)
+	}
+	w.unlock()
+	if w.AckReports {
+		ackStatus := enum.AllocStatus_ACCEPTED
+		if !ok {
+			ackStatus = enum.AllocStatus_BLOCK_LEVEL_REJECT
+		}
+		ack := allocationreportack.New(field.NewAllocReportID(reportID), field.NewAllocID(allocID),
+			field.NewTransactTime(time.Now()), field.NewAllocStatus(ackStatus))
+		if err := w.Send(ack, w.SessionID); err != nil {
+			return err
+		}
+	}
+	if !ok {
+		return ErrUnknownAllocID
+	}
+	return nil
+}
+
+// setStatus must be called with mu held
+func (w *Workflow) setStatus(a *Allocation, status enum.AllocStatus) {
+	a.AllocStatus = status
+	a.UpdatedAt = time.Now()
+	switch status {
+	case enum.AllocStatus_ACCEPTED:
+		a.State = Accepted
+		if ref, ok := w.allocs[a.RefAllocID]; ok {
+			if a.TransType == enum.AllocTransType_CANCEL {
+				ref.State = Canceled
+			} else {
+				ref.State = Replaced
+			}
+			ref.UpdatedAt = a.UpdatedAt
+			w.changed(ref)
+		}
+	case enum.AllocStatus_RECEIVED:
+		if a.State == Pending {
+			a.State = Received
+		}
+	default:
+		// block level, account level and intermediary rejects, incomplete allocations
+		a.State = Rejected
+	}
+	w.changed(a)
+}
+
+// changed queues the call of OnChange with a copy of a, it must be called with mu held
+func (w *Workflow) changed(a *Allocation) {
+	if w.OnChange != nil {
+		c := a.clone()
+		w.callbacks = append(w.callbacks, func() { w.OnChange(c) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (w *Workflow) unlock() {
+	callbacks := w.callbacks
+	w.callbacks = nil
+	w.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+func (a *Allocation) clone() Allocation {
+	c := *a
+	c.Splits = append([]Split(nil), a.Splits...)
+	c.Block.Fills = append([]ordertracker.Fill(nil), a.Block.Fills...)
+	if a.AccountRejects != nil {
+		c.AccountRejects = make(map[string]int, len(a.AccountRejects))
+		for k, v := range a.AccountRejects {
+			c.AccountRejects[k] = v
+		}
+	}
+	return c
+}
+
+// Allocation returns a copy of the allocation with the AllocID
+func (w *Workflow) Allocation(allocID string) (Allocation, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	a, ok := w.allocs[allocID]
+	if !ok {
+		return Allocation{}, false
+	}
+	return a.clone(), true
+}
+
+// Allocations returns copies of all the allocations, in the order they were sent
+func (w *Workflow) Allocations() []Allocation {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	allocs := make([]Allocation, 0, len(w.sent))
+	for _, id := range w.sent {
+		allocs = append(allocs, w.allocs[id].clone())
+	}
+	return allocs
+}
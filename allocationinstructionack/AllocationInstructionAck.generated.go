@@ -2,6 +2,7 @@ package allocationinstructionack
 
 import (
 	"github.com/shopspring/decimal"
+	"iter"
 	"time"
 
 	"github.com/quickfixgo/enum"
@@ -540,6 +541,68 @@ func (m NoAllocsRepeatingGroup) Get(i int) NoAllocs {
 	return NoAllocs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoAllocs of each entry in the NoAllocsRepeatingGroup
+func (m NoAllocsRepeatingGroup) All() iter.Seq2[int, NoAllocs] {
+	return func(yield func(int, NoAllocs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoAllocs entries of the NoAllocsRepeatingGroup as a slice
+func (m NoAllocsRepeatingGroup) Slice() []NoAllocs {
+	s := make([]NoAllocs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoAllocsRow holds the values of a NoAllocs, nil fields are left unset by AddRow
+type NoAllocsRow struct {
+	AllocAccount           *string
+	AllocAcctIDSource      *int
+	AllocPrice             *quickfix.FIXDecimal
+	IndividualAllocID      *string
+	IndividualAllocRejCode *int
+	AllocText              *string
+	EncodedAllocTextLen    *int
+	EncodedAllocText       *string
+}
+
+//AddRow creates and appends a new NoAllocs to this group, setting the fields present in row
+func (m NoAllocsRepeatingGroup) AddRow(row NoAllocsRow) NoAllocs {
+	g := m.Add()
+	if row.AllocAccount != nil {
+		g.SetAllocAccount(*row.AllocAccount)
+	}
+	if row.AllocAcctIDSource != nil {
+		g.SetAllocAcctIDSource(*row.AllocAcctIDSource)
+	}
+	if row.AllocPrice != nil {
+		g.SetAllocPrice(row.AllocPrice.Decimal, row.AllocPrice.Scale)
+	}
+	if row.IndividualAllocID != nil {
+		g.SetIndividualAllocID(*row.IndividualAllocID)
+	}
+	if row.IndividualAllocRejCode != nil {
+		g.SetIndividualAllocRejCode(*row.IndividualAllocRejCode)
+	}
+	if row.AllocText != nil {
+		g.SetAllocText(*row.AllocText)
+	}
+	if row.EncodedAllocTextLen != nil {
+		g.SetEncodedAllocTextLen(*row.EncodedAllocTextLen)
+	}
+	if row.EncodedAllocText != nil {
+		g.SetEncodedAllocText(*row.EncodedAllocText)
+	}
+	return g
+}
+
 //NoPartyIDs is a repeating group element, Tag 453
 type NoPartyIDs struct {
 	*quickfix.Group
@@ -685,6 +748,44 @@ func (m NoPartySubIDsRepeatingGroup) Get(i int) NoPartySubIDs {
 	return NoPartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPartySubIDs of each entry in the NoPartySubIDsRepeatingGroup
+func (m NoPartySubIDsRepeatingGroup) All() iter.Seq2[int, NoPartySubIDs] {
+	return func(yield func(int, NoPartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPartySubIDs entries of the NoPartySubIDsRepeatingGroup as a slice
+func (m NoPartySubIDsRepeatingGroup) Slice() []NoPartySubIDs {
+	s := make([]NoPartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPartySubIDsRow holds the values of a NoPartySubIDs, nil fields are left unset by AddRow
+type NoPartySubIDsRow struct {
+	PartySubID     *string
+	PartySubIDType *enum.PartySubIDType
+}
+
+//AddRow creates and appends a new NoPartySubIDs to this group, setting the fields present in row
+func (m NoPartySubIDsRepeatingGroup) AddRow(row NoPartySubIDsRow) NoPartySubIDs {
+	g := m.Add()
+	if row.PartySubID != nil {
+		g.SetPartySubID(*row.PartySubID)
+	}
+	if row.PartySubIDType != nil {
+		g.SetPartySubIDType(*row.PartySubIDType)
+	}
+	return g
+}
+
 //NoPartyIDsRepeatingGroup is a repeating group, Tag 453
 type NoPartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -707,3 +808,53 @@ func (m NoPartyIDsRepeatingGroup) Add() NoPartyIDs {
 func (m NoPartyIDsRepeatingGroup) Get(i int) NoPartyIDs {
 	return NoPartyIDs{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoPartyIDs of each entry in the NoPartyIDsRepeatingGroup
+func (m NoPartyIDsRepeatingGroup) All() iter.Seq2[int, NoPartyIDs] {
+	return func(yield func(int, NoPartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPartyIDs entries of the NoPartyIDsRepeatingGroup as a slice
+func (m NoPartyIDsRepeatingGroup) Slice() []NoPartyIDs {
+	s := make([]NoPartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPartyIDsRow holds the values of a NoPartyIDs, nil fields are left unset by AddRow
+type NoPartyIDsRow struct {
+	PartyID       *string
+	PartyIDSource *enum.PartyIDSource
+	PartyRole     *enum.PartyRole
+	NoPartySubIDs []NoPartySubIDsRow
+}
+
+//AddRow creates and appends a new NoPartyIDs to this group, setting the fields present in row
+func (m NoPartyIDsRepeatingGroup) AddRow(row NoPartyIDsRow) NoPartyIDs {
+	g := m.Add()
+	if row.PartyID != nil {
+		g.SetPartyID(*row.PartyID)
+	}
+	if row.PartyIDSource != nil {
+		g.SetPartyIDSource(*row.PartyIDSource)
+	}
+	if row.PartyRole != nil {
+		g.SetPartyRole(*row.PartyRole)
+	}
+	if len(row.NoPartySubIDs) > 0 {
+		f := NewNoPartySubIDsRepeatingGroup()
+		for _, r := range row.NoPartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoPartySubIDs(f)
+	}
+	return g
+}
@@ -2,6 +2,7 @@ package allocationreport
 
 import (
 	"github.com/shopspring/decimal"
+	"iter"
 	"time"
 
 	"github.com/quickfixgo/enum"
@@ -2835,6 +2836,44 @@ func (m NoNested2PartySubIDsRepeatingGroup) Get(i int) NoNested2PartySubIDs {
 	return NoNested2PartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoNested2PartySubIDs of each entry in the NoNested2PartySubIDsRepeatingGroup
+func (m NoNested2PartySubIDsRepeatingGroup) All() iter.Seq2[int, NoNested2PartySubIDs] {
+	return func(yield func(int, NoNested2PartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoNested2PartySubIDs entries of the NoNested2PartySubIDsRepeatingGroup as a slice
+func (m NoNested2PartySubIDsRepeatingGroup) Slice() []NoNested2PartySubIDs {
+	s := make([]NoNested2PartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoNested2PartySubIDsRow holds the values of a NoNested2PartySubIDs, nil fields are left unset by AddRow
+type NoNested2PartySubIDsRow struct {
+	Nested2PartySubID     *string
+	Nested2PartySubIDType *int
+}
+
+//AddRow creates and appends a new NoNested2PartySubIDs to this group, setting the fields present in row
+func (m NoNested2PartySubIDsRepeatingGroup) AddRow(row NoNested2PartySubIDsRow) NoNested2PartySubIDs {
+	g := m.Add()
+	if row.Nested2PartySubID != nil {
+		g.SetNested2PartySubID(*row.Nested2PartySubID)
+	}
+	if row.Nested2PartySubIDType != nil {
+		g.SetNested2PartySubIDType(*row.Nested2PartySubIDType)
+	}
+	return g
+}
+
 //NoNested2PartyIDsRepeatingGroup is a repeating group, Tag 756
 type NoNested2PartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -2858,6 +2897,56 @@ func (m NoNested2PartyIDsRepeatingGroup) Get(i int) NoNested2PartyIDs {
 	return NoNested2PartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoNested2PartyIDs of each entry in the NoNested2PartyIDsRepeatingGroup
+func (m NoNested2PartyIDsRepeatingGroup) All() iter.Seq2[int, NoNested2PartyIDs] {
+	return func(yield func(int, NoNested2PartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoNested2PartyIDs entries of the NoNested2PartyIDsRepeatingGroup as a slice
+func (m NoNested2PartyIDsRepeatingGroup) Slice() []NoNested2PartyIDs {
+	s := make([]NoNested2PartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoNested2PartyIDsRow holds the values of a NoNested2PartyIDs, nil fields are left unset by AddRow
+type NoNested2PartyIDsRow struct {
+	Nested2PartyID       *string
+	Nested2PartyIDSource *string
+	Nested2PartyRole     *int
+	NoNested2PartySubIDs []NoNested2PartySubIDsRow
+}
+
+//AddRow creates and appends a new NoNested2PartyIDs to this group, setting the fields present in row
+func (m NoNested2PartyIDsRepeatingGroup) AddRow(row NoNested2PartyIDsRow) NoNested2PartyIDs {
+	g := m.Add()
+	if row.Nested2PartyID != nil {
+		g.SetNested2PartyID(*row.Nested2PartyID)
+	}
+	if row.Nested2PartyIDSource != nil {
+		g.SetNested2PartyIDSource(*row.Nested2PartyIDSource)
+	}
+	if row.Nested2PartyRole != nil {
+		g.SetNested2PartyRole(*row.Nested2PartyRole)
+	}
+	if len(row.NoNested2PartySubIDs) > 0 {
+		f := NewNoNested2PartySubIDsRepeatingGroup()
+		for _, r := range row.NoNested2PartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoNested2PartySubIDs(f)
+	}
+	return g
+}
+
 //NoOrdersRepeatingGroup is a repeating group, Tag 73
 type NoOrdersRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -2881,6 +2970,76 @@ func (m NoOrdersRepeatingGroup) Get(i int) NoOrders {
 	return NoOrders{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoOrders of each entry in the NoOrdersRepeatingGroup
+func (m NoOrdersRepeatingGroup) All() iter.Seq2[int, NoOrders] {
+	return func(yield func(int, NoOrders) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoOrders entries of the NoOrdersRepeatingGroup as a slice
+func (m NoOrdersRepeatingGroup) Slice() []NoOrders {
+	s := make([]NoOrders, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoOrdersRow holds the values of a NoOrders, nil fields are left unset by AddRow
+type NoOrdersRow struct {
+	ClOrdID           *string
+	OrderID           *string
+	SecondaryOrderID  *string
+	SecondaryClOrdID  *string
+	ListID            *string
+	NoNested2PartyIDs []NoNested2PartyIDsRow
+	OrderQty          *quickfix.FIXDecimal
+	OrderAvgPx        *quickfix.FIXDecimal
+	OrderBookingQty   *quickfix.FIXDecimal
+}
+
+//AddRow creates and appends a new NoOrders to this group, setting the fields present in row
+func (m NoOrdersRepeatingGroup) AddRow(row NoOrdersRow) NoOrders {
+	g := m.Add()
+	if row.ClOrdID != nil {
+		g.SetClOrdID(*row.ClOrdID)
+	}
+	if row.OrderID != nil {
+		g.SetOrderID(*row.OrderID)
+	}
+	if row.SecondaryOrderID != nil {
+		g.SetSecondaryOrderID(*row.SecondaryOrderID)
+	}
+	if row.SecondaryClOrdID != nil {
+		g.SetSecondaryClOrdID(*row.SecondaryClOrdID)
+	}
+	if row.ListID != nil {
+		g.SetListID(*row.ListID)
+	}
+	if len(row.NoNested2PartyIDs) > 0 {
+		f := NewNoNested2PartyIDsRepeatingGroup()
+		for _, r := range row.NoNested2PartyIDs {
+			f.AddRow(r)
+		}
+		g.SetNoNested2PartyIDs(f)
+	}
+	if row.OrderQty != nil {
+		g.SetOrderQty(row.OrderQty.Decimal, row.OrderQty.Scale)
+	}
+	if row.OrderAvgPx != nil {
+		g.SetOrderAvgPx(row.OrderAvgPx.Decimal, row.OrderAvgPx.Scale)
+	}
+	if row.OrderBookingQty != nil {
+		g.SetOrderBookingQty(row.OrderBookingQty.Decimal, row.OrderBookingQty.Scale)
+	}
+	return g
+}
+
 //NoAllocs is a repeating group element, Tag 78
 type NoAllocs struct {
 	*quickfix.Group
@@ -3707,6 +3866,44 @@ func (m NoNestedPartySubIDsRepeatingGroup) Get(i int) NoNestedPartySubIDs {
 	return NoNestedPartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoNestedPartySubIDs of each entry in the NoNestedPartySubIDsRepeatingGroup
+func (m NoNestedPartySubIDsRepeatingGroup) All() iter.Seq2[int, NoNestedPartySubIDs] {
+	return func(yield func(int, NoNestedPartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoNestedPartySubIDs entries of the NoNestedPartySubIDsRepeatingGroup as a slice
+func (m NoNestedPartySubIDsRepeatingGroup) Slice() []NoNestedPartySubIDs {
+	s := make([]NoNestedPartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoNestedPartySubIDsRow holds the values of a NoNestedPartySubIDs, nil fields are left unset by AddRow
+type NoNestedPartySubIDsRow struct {
+	NestedPartySubID     *string
+	NestedPartySubIDType *int
+}
+
+//AddRow creates and appends a new NoNestedPartySubIDs to this group, setting the fields present in row
+func (m NoNestedPartySubIDsRepeatingGroup) AddRow(row NoNestedPartySubIDsRow) NoNestedPartySubIDs {
+	g := m.Add()
+	if row.NestedPartySubID != nil {
+		g.SetNestedPartySubID(*row.NestedPartySubID)
+	}
+	if row.NestedPartySubIDType != nil {
+		g.SetNestedPartySubIDType(*row.NestedPartySubIDType)
+	}
+	return g
+}
+
 //NoNestedPartyIDsRepeatingGroup is a repeating group, Tag 539
 type NoNestedPartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -3730,6 +3927,56 @@ func (m NoNestedPartyIDsRepeatingGroup) Get(i int) NoNestedPartyIDs {
 	return NoNestedPartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoNestedPartyIDs of each entry in the NoNestedPartyIDsRepeatingGroup
+func (m NoNestedPartyIDsRepeatingGroup) All() iter.Seq2[int, NoNestedPartyIDs] {
+	return func(yield func(int, NoNestedPartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoNestedPartyIDs entries of the NoNestedPartyIDsRepeatingGroup as a slice
+func (m NoNestedPartyIDsRepeatingGroup) Slice() []NoNestedPartyIDs {
+	s := make([]NoNestedPartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoNestedPartyIDsRow holds the values of a NoNestedPartyIDs, nil fields are left unset by AddRow
+type NoNestedPartyIDsRow struct {
+	NestedPartyID       *string
+	NestedPartyIDSource *string
+	NestedPartyRole     *int
+	NoNestedPartySubIDs []NoNestedPartySubIDsRow
+}
+
+//AddRow creates and appends a new NoNestedPartyIDs to this group, setting the fields present in row
+func (m NoNestedPartyIDsRepeatingGroup) AddRow(row NoNestedPartyIDsRow) NoNestedPartyIDs {
+	g := m.Add()
+	if row.NestedPartyID != nil {
+		g.SetNestedPartyID(*row.NestedPartyID)
+	}
+	if row.NestedPartyIDSource != nil {
+		g.SetNestedPartyIDSource(*row.NestedPartyIDSource)
+	}
+	if row.NestedPartyRole != nil {
+		g.SetNestedPartyRole(*row.NestedPartyRole)
+	}
+	if len(row.NoNestedPartySubIDs) > 0 {
+		f := NewNoNestedPartySubIDsRepeatingGroup()
+		for _, r := range row.NoNestedPartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoNestedPartySubIDs(f)
+	}
+	return g
+}
+
 //NoMiscFees is a repeating group element, Tag 136
 type NoMiscFees struct {
 	*quickfix.Group
@@ -3834,6 +4081,52 @@ func (m NoMiscFeesRepeatingGroup) Get(i int) NoMiscFees {
 	return NoMiscFees{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoMiscFees of each entry in the NoMiscFeesRepeatingGroup
+func (m NoMiscFeesRepeatingGroup) All() iter.Seq2[int, NoMiscFees] {
+	return func(yield func(int, NoMiscFees) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoMiscFees entries of the NoMiscFeesRepeatingGroup as a slice
+func (m NoMiscFeesRepeatingGroup) Slice() []NoMiscFees {
+	s := make([]NoMiscFees, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoMiscFeesRow holds the values of a NoMiscFees, nil fields are left unset by AddRow
+type NoMiscFeesRow struct {
+	MiscFeeAmt   *quickfix.FIXDecimal
+	MiscFeeCurr  *string
+	MiscFeeType  *enum.MiscFeeType
+	MiscFeeBasis *enum.MiscFeeBasis
+}
+
+//AddRow creates and appends a new NoMiscFees to this group, setting the fields present in row
+func (m NoMiscFeesRepeatingGroup) AddRow(row NoMiscFeesRow) NoMiscFees {
+	g := m.Add()
+	if row.MiscFeeAmt != nil {
+		g.SetMiscFeeAmt(row.MiscFeeAmt.Decimal, row.MiscFeeAmt.Scale)
+	}
+	if row.MiscFeeCurr != nil {
+		g.SetMiscFeeCurr(*row.MiscFeeCurr)
+	}
+	if row.MiscFeeType != nil {
+		g.SetMiscFeeType(*row.MiscFeeType)
+	}
+	if row.MiscFeeBasis != nil {
+		g.SetMiscFeeBasis(*row.MiscFeeBasis)
+	}
+	return g
+}
+
 //NoClearingInstructions is a repeating group element, Tag 576
 type NoClearingInstructions struct {
 	*quickfix.Group
@@ -3881,6 +4174,40 @@ func (m NoClearingInstructionsRepeatingGroup) Get(i int) NoClearingInstructions
 	return NoClearingInstructions{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoClearingInstructions of each entry in the NoClearingInstructionsRepeatingGroup
+func (m NoClearingInstructionsRepeatingGroup) All() iter.Seq2[int, NoClearingInstructions] {
+	return func(yield func(int, NoClearingInstructions) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoClearingInstructions entries of the NoClearingInstructionsRepeatingGroup as a slice
+func (m NoClearingInstructionsRepeatingGroup) Slice() []NoClearingInstructions {
+	s := make([]NoClearingInstructions, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoClearingInstructionsRow holds the values of a NoClearingInstructions, nil fields are left unset by AddRow
+type NoClearingInstructionsRow struct {
+	ClearingInstruction *enum.ClearingInstruction
+}
+
+//AddRow creates and appends a new NoClearingInstructions to this group, setting the fields present in row
+func (m NoClearingInstructionsRepeatingGroup) AddRow(row NoClearingInstructionsRow) NoClearingInstructions {
+	g := m.Add()
+	if row.ClearingInstruction != nil {
+		g.SetClearingInstruction(*row.ClearingInstruction)
+	}
+	return g
+}
+
 //NoDlvyInst is a repeating group element, Tag 85
 type NoDlvyInst struct {
 	*quickfix.Group
@@ -4086,6 +4413,44 @@ func (m NoSettlPartySubIDsRepeatingGroup) Get(i int) NoSettlPartySubIDs {
 	return NoSettlPartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoSettlPartySubIDs of each entry in the NoSettlPartySubIDsRepeatingGroup
+func (m NoSettlPartySubIDsRepeatingGroup) All() iter.Seq2[int, NoSettlPartySubIDs] {
+	return func(yield func(int, NoSettlPartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoSettlPartySubIDs entries of the NoSettlPartySubIDsRepeatingGroup as a slice
+func (m NoSettlPartySubIDsRepeatingGroup) Slice() []NoSettlPartySubIDs {
+	s := make([]NoSettlPartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoSettlPartySubIDsRow holds the values of a NoSettlPartySubIDs, nil fields are left unset by AddRow
+type NoSettlPartySubIDsRow struct {
+	SettlPartySubID     *string
+	SettlPartySubIDType *int
+}
+
+//AddRow creates and appends a new NoSettlPartySubIDs to this group, setting the fields present in row
+func (m NoSettlPartySubIDsRepeatingGroup) AddRow(row NoSettlPartySubIDsRow) NoSettlPartySubIDs {
+	g := m.Add()
+	if row.SettlPartySubID != nil {
+		g.SetSettlPartySubID(*row.SettlPartySubID)
+	}
+	if row.SettlPartySubIDType != nil {
+		g.SetSettlPartySubIDType(*row.SettlPartySubIDType)
+	}
+	return g
+}
+
 //NoSettlPartyIDsRepeatingGroup is a repeating group, Tag 781
 type NoSettlPartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -4109,6 +4474,56 @@ func (m NoSettlPartyIDsRepeatingGroup) Get(i int) NoSettlPartyIDs {
 	return NoSettlPartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoSettlPartyIDs of each entry in the NoSettlPartyIDsRepeatingGroup
+func (m NoSettlPartyIDsRepeatingGroup) All() iter.Seq2[int, NoSettlPartyIDs] {
+	return func(yield func(int, NoSettlPartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoSettlPartyIDs entries of the NoSettlPartyIDsRepeatingGroup as a slice
+func (m NoSettlPartyIDsRepeatingGroup) Slice() []NoSettlPartyIDs {
+	s := make([]NoSettlPartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoSettlPartyIDsRow holds the values of a NoSettlPartyIDs, nil fields are left unset by AddRow
+type NoSettlPartyIDsRow struct {
+	SettlPartyID       *string
+	SettlPartyIDSource *string
+	SettlPartyRole     *int
+	NoSettlPartySubIDs []NoSettlPartySubIDsRow
+}
+
+//AddRow creates and appends a new NoSettlPartyIDs to this group, setting the fields present in row
+func (m NoSettlPartyIDsRepeatingGroup) AddRow(row NoSettlPartyIDsRow) NoSettlPartyIDs {
+	g := m.Add()
+	if row.SettlPartyID != nil {
+		g.SetSettlPartyID(*row.SettlPartyID)
+	}
+	if row.SettlPartyIDSource != nil {
+		g.SetSettlPartyIDSource(*row.SettlPartyIDSource)
+	}
+	if row.SettlPartyRole != nil {
+		g.SetSettlPartyRole(*row.SettlPartyRole)
+	}
+	if len(row.NoSettlPartySubIDs) > 0 {
+		f := NewNoSettlPartySubIDsRepeatingGroup()
+		for _, r := range row.NoSettlPartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoSettlPartySubIDs(f)
+	}
+	return g
+}
+
 //NoDlvyInstRepeatingGroup is a repeating group, Tag 85
 type NoDlvyInstRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -4132,6 +4547,52 @@ func (m NoDlvyInstRepeatingGroup) Get(i int) NoDlvyInst {
 	return NoDlvyInst{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoDlvyInst of each entry in the NoDlvyInstRepeatingGroup
+func (m NoDlvyInstRepeatingGroup) All() iter.Seq2[int, NoDlvyInst] {
+	return func(yield func(int, NoDlvyInst) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoDlvyInst entries of the NoDlvyInstRepeatingGroup as a slice
+func (m NoDlvyInstRepeatingGroup) Slice() []NoDlvyInst {
+	s := make([]NoDlvyInst, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoDlvyInstRow holds the values of a NoDlvyInst, nil fields are left unset by AddRow
+type NoDlvyInstRow struct {
+	SettlInstSource *enum.SettlInstSource
+	DlvyInstType    *enum.DlvyInstType
+	NoSettlPartyIDs []NoSettlPartyIDsRow
+}
+
+//AddRow creates and appends a new NoDlvyInst to this group, setting the fields present in row
+func (m NoDlvyInstRepeatingGroup) AddRow(row NoDlvyInstRow) NoDlvyInst {
+	g := m.Add()
+	if row.SettlInstSource != nil {
+		g.SetSettlInstSource(*row.SettlInstSource)
+	}
+	if row.DlvyInstType != nil {
+		g.SetDlvyInstType(*row.DlvyInstType)
+	}
+	if len(row.NoSettlPartyIDs) > 0 {
+		f := NewNoSettlPartyIDsRepeatingGroup()
+		for _, r := range row.NoSettlPartyIDs {
+			f.AddRow(r)
+		}
+		g.SetNoSettlPartyIDs(f)
+	}
+	return g
+}
+
 //NoAllocsRepeatingGroup is a repeating group, Tag 78
 type NoAllocsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -4155,6 +4616,196 @@ func (m NoAllocsRepeatingGroup) Get(i int) NoAllocs {
 	return NoAllocs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoAllocs of each entry in the NoAllocsRepeatingGroup
+func (m NoAllocsRepeatingGroup) All() iter.Seq2[int, NoAllocs] {
+	return func(yield func(int, NoAllocs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoAllocs entries of the NoAllocsRepeatingGroup as a slice
+func (m NoAllocsRepeatingGroup) Slice() []NoAllocs {
+	s := make([]NoAllocs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoAllocsRow holds the values of a NoAllocs, nil fields are left unset by AddRow
+type NoAllocsRow struct {
+	AllocAccount            *string
+	AllocAcctIDSource       *int
+	MatchStatus             *enum.MatchStatus
+	AllocPrice              *quickfix.FIXDecimal
+	AllocQty                *quickfix.FIXDecimal
+	IndividualAllocID       *string
+	ProcessCode             *enum.ProcessCode
+	NoNestedPartyIDs        []NoNestedPartyIDsRow
+	NotifyBrokerOfCredit    *bool
+	AllocHandlInst          *enum.AllocHandlInst
+	AllocText               *string
+	EncodedAllocTextLen     *int
+	EncodedAllocText        *string
+	Commission              *quickfix.FIXDecimal
+	CommType                *enum.CommType
+	CommCurrency            *string
+	FundRenewWaiv           *enum.FundRenewWaiv
+	AllocAvgPx              *quickfix.FIXDecimal
+	AllocNetMoney           *quickfix.FIXDecimal
+	SettlCurrAmt            *quickfix.FIXDecimal
+	AllocSettlCurrAmt       *quickfix.FIXDecimal
+	SettlCurrency           *string
+	AllocSettlCurrency      *string
+	SettlCurrFxRate         *quickfix.FIXDecimal
+	SettlCurrFxRateCalc     *enum.SettlCurrFxRateCalc
+	AllocAccruedInterestAmt *quickfix.FIXDecimal
+	AllocInterestAtMaturity *quickfix.FIXDecimal
+	NoMiscFees              []NoMiscFeesRow
+	NoClearingInstructions  []NoClearingInstructionsRow
+	ClearingFeeIndicator    *enum.ClearingFeeIndicator
+	AllocSettlInstType      *enum.AllocSettlInstType
+	SettlDeliveryType       *enum.SettlDeliveryType
+	StandInstDbType         *enum.StandInstDbType
+	StandInstDbName         *string
+	StandInstDbID           *string
+	NoDlvyInst              []NoDlvyInstRow
+}
+
+//AddRow creates and appends a new NoAllocs to this group, setting the fields present in row
+func (m NoAllocsRepeatingGroup) AddRow(row NoAllocsRow) NoAllocs {
+	g := m.Add()
+	if row.AllocAccount != nil {
+		g.SetAllocAccount(*row.AllocAccount)
+	}
+	if row.AllocAcctIDSource != nil {
+		g.SetAllocAcctIDSource(*row.AllocAcctIDSource)
+	}
+	if row.MatchStatus != nil {
+		g.SetMatchStatus(*row.MatchStatus)
+	}
+	if row.AllocPrice != nil {
+		g.SetAllocPrice(row.AllocPrice.Decimal, row.AllocPrice.Scale)
+	}
+	if row.AllocQty != nil {
+		g.SetAllocQty(row.AllocQty.Decimal, row.AllocQty.Scale)
+	}
+	if row.IndividualAllocID != nil {
+		g.SetIndividualAllocID(*row.IndividualAllocID)
+	}
+	if row.ProcessCode != nil {
+		g.SetProcessCode(*row.ProcessCode)
+	}
+	if len(row.NoNestedPartyIDs) > 0 {
+		f := NewNoNestedPartyIDsRepeatingGroup()
+		for _, r := range row.NoNestedPartyIDs {
+			f.AddRow(r)
+		}
+		g.SetNoNestedPartyIDs(f)
+	}
+	if row.NotifyBrokerOfCredit != nil {
+		g.SetNotifyBrokerOfCredit(*row.NotifyBrokerOfCredit)
+	}
+	if row.AllocHandlInst != nil {
+		g.SetAllocHandlInst(*row.AllocHandlInst)
+	}
+	if row.AllocText != nil {
+		g.SetAllocText(*row.AllocText)
+	}
+	if row.EncodedAllocTextLen != nil {
+		g.SetEncodedAllocTextLen(*row.EncodedAllocTextLen)
+	}
+	if row.EncodedAllocText != nil {
+		g.SetEncodedAllocText(*row.EncodedAllocText)
+	}
+	if row.Commission != nil {
+		g.SetCommission(row.Commission.Decimal, row.Commission.Scale)
+	}
+	if row.CommType != nil {
+		g.SetCommType(*row.CommType)
+	}
+	if row.CommCurrency != nil {
+		g.SetCommCurrency(*row.CommCurrency)
+	}
+	if row.FundRenewWaiv != nil {
+		g.SetFundRenewWaiv(*row.FundRenewWaiv)
+	}
+	if row.AllocAvgPx != nil {
+		g.SetAllocAvgPx(row.AllocAvgPx.Decimal, row.AllocAvgPx.Scale)
+	}
+	if row.AllocNetMoney != nil {
+		g.SetAllocNetMoney(row.AllocNetMoney.Decimal, row.AllocNetMoney.Scale)
+	}
+	if row.SettlCurrAmt != nil {
+		g.SetSettlCurrAmt(row.SettlCurrAmt.Decimal, row.SettlCurrAmt.Scale)
+	}
+	if row.AllocSettlCurrAmt != nil {
+		g.SetAllocSettlCurrAmt(row.AllocSettlCurrAmt.Decimal, row.AllocSettlCurrAmt.Scale)
+	}
+	if row.SettlCurrency != nil {
+		g.SetSettlCurrency(*row.SettlCurrency)
+	}
+	if row.AllocSettlCurrency != nil {
+		g.SetAllocSettlCurrency(*row.AllocSettlCurrency)
+	}
+	if row.SettlCurrFxRate != nil {
+		g.SetSettlCurrFxRate(row.SettlCurrFxRate.Decimal, row.SettlCurrFxRate.Scale)
+	}
+	if row.SettlCurrFxRateCalc != nil {
+		g.SetSettlCurrFxRateCalc(*row.SettlCurrFxRateCalc)
+	}
+	if row.AllocAccruedInterestAmt != nil {
+		g.SetAllocAccruedInterestAmt(row.AllocAccruedInterestAmt.Decimal, row.AllocAccruedInterestAmt.Scale)
+	}
+	if row.AllocInterestAtMaturity != nil {
+		g.SetAllocInterestAtMaturity(row.AllocInterestAtMaturity.Decimal, row.AllocInterestAtMaturity.Scale)
+	}
+	if len(row.NoMiscFees) > 0 {
+		f := NewNoMiscFeesRepeatingGroup()
+		for _, r := range row.NoMiscFees {
+			f.AddRow(r)
+		}
+		g.SetNoMiscFees(f)
+	}
+	if len(row.NoClearingInstructions) > 0 {
+		f := NewNoClearingInstructionsRepeatingGroup()
+		for _, r := range row.NoClearingInstructions {
+			f.AddRow(r)
+		}
+		g.SetNoClearingInstructions(f)
+	}
+	if row.ClearingFeeIndicator != nil {
+		g.SetClearingFeeIndicator(*row.ClearingFeeIndicator)
+	}
+	if row.AllocSettlInstType != nil {
+		g.SetAllocSettlInstType(*row.AllocSettlInstType)
+	}
+	if row.SettlDeliveryType != nil {
+		g.SetSettlDeliveryType(*row.SettlDeliveryType)
+	}
+	if row.StandInstDbType != nil {
+		g.SetStandInstDbType(*row.StandInstDbType)
+	}
+	if row.StandInstDbName != nil {
+		g.SetStandInstDbName(*row.StandInstDbName)
+	}
+	if row.StandInstDbID != nil {
+		g.SetStandInstDbID(*row.StandInstDbID)
+	}
+	if len(row.NoDlvyInst) > 0 {
+		f := NewNoDlvyInstRepeatingGroup()
+		for _, r := range row.NoDlvyInst {
+			f.AddRow(r)
+		}
+		g.SetNoDlvyInst(f)
+	}
+	return g
+}
+
 //NoExecs is a repeating group element, Tag 124
 type NoExecs struct {
 	*quickfix.Group
@@ -4297,6 +4948,60 @@ func (m NoExecsRepeatingGroup) Get(i int) NoExecs {
 	return NoExecs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoExecs of each entry in the NoExecsRepeatingGroup
+func (m NoExecsRepeatingGroup) All() iter.Seq2[int, NoExecs] {
+	return func(yield func(int, NoExecs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoExecs entries of the NoExecsRepeatingGroup as a slice
+func (m NoExecsRepeatingGroup) Slice() []NoExecs {
+	s := make([]NoExecs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoExecsRow holds the values of a NoExecs, nil fields are left unset by AddRow
+type NoExecsRow struct {
+	LastQty         *quickfix.FIXDecimal
+	ExecID          *string
+	SecondaryExecID *string
+	LastPx          *quickfix.FIXDecimal
+	LastParPx       *quickfix.FIXDecimal
+	LastCapacity    *enum.LastCapacity
+}
+
+//AddRow creates and appends a new NoExecs to this group, setting the fields present in row
+func (m NoExecsRepeatingGroup) AddRow(row NoExecsRow) NoExecs {
+	g := m.Add()
+	if row.LastQty != nil {
+		g.SetLastQty(row.LastQty.Decimal, row.LastQty.Scale)
+	}
+	if row.ExecID != nil {
+		g.SetExecID(*row.ExecID)
+	}
+	if row.SecondaryExecID != nil {
+		g.SetSecondaryExecID(*row.SecondaryExecID)
+	}
+	if row.LastPx != nil {
+		g.SetLastPx(row.LastPx.Decimal, row.LastPx.Scale)
+	}
+	if row.LastParPx != nil {
+		g.SetLastParPx(row.LastParPx.Decimal, row.LastParPx.Scale)
+	}
+	if row.LastCapacity != nil {
+		g.SetLastCapacity(*row.LastCapacity)
+	}
+	return g
+}
+
 //NoStipulations is a repeating group element, Tag 232
 type NoStipulations struct {
 	*quickfix.Group
@@ -4363,6 +5068,44 @@ func (m NoStipulationsRepeatingGroup) Get(i int) NoStipulations {
 	return NoStipulations{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoStipulations of each entry in the NoStipulationsRepeatingGroup
+func (m NoStipulationsRepeatingGroup) All() iter.Seq2[int, NoStipulations] {
+	return func(yield func(int, NoStipulations) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoStipulations entries of the NoStipulationsRepeatingGroup as a slice
+func (m NoStipulationsRepeatingGroup) Slice() []NoStipulations {
+	s := make([]NoStipulations, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoStipulationsRow holds the values of a NoStipulations, nil fields are left unset by AddRow
+type NoStipulationsRow struct {
+	StipulationType  *enum.StipulationType
+	StipulationValue *string
+}
+
+//AddRow creates and appends a new NoStipulations to this group, setting the fields present in row
+func (m NoStipulationsRepeatingGroup) AddRow(row NoStipulationsRow) NoStipulations {
+	g := m.Add()
+	if row.StipulationType != nil {
+		g.SetStipulationType(*row.StipulationType)
+	}
+	if row.StipulationValue != nil {
+		g.SetStipulationValue(*row.StipulationValue)
+	}
+	return g
+}
+
 //NoPartyIDs is a repeating group element, Tag 453
 type NoPartyIDs struct {
 	*quickfix.Group
@@ -4508,6 +5251,44 @@ func (m NoPartySubIDsRepeatingGroup) Get(i int) NoPartySubIDs {
 	return NoPartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPartySubIDs of each entry in the NoPartySubIDsRepeatingGroup
+func (m NoPartySubIDsRepeatingGroup) All() iter.Seq2[int, NoPartySubIDs] {
+	return func(yield func(int, NoPartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPartySubIDs entries of the NoPartySubIDsRepeatingGroup as a slice
+func (m NoPartySubIDsRepeatingGroup) Slice() []NoPartySubIDs {
+	s := make([]NoPartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPartySubIDsRow holds the values of a NoPartySubIDs, nil fields are left unset by AddRow
+type NoPartySubIDsRow struct {
+	PartySubID     *string
+	PartySubIDType *enum.PartySubIDType
+}
+
+//AddRow creates and appends a new NoPartySubIDs to this group, setting the fields present in row
+func (m NoPartySubIDsRepeatingGroup) AddRow(row NoPartySubIDsRow) NoPartySubIDs {
+	g := m.Add()
+	if row.PartySubID != nil {
+		g.SetPartySubID(*row.PartySubID)
+	}
+	if row.PartySubIDType != nil {
+		g.SetPartySubIDType(*row.PartySubIDType)
+	}
+	return g
+}
+
 //NoPartyIDsRepeatingGroup is a repeating group, Tag 453
 type NoPartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -4531,6 +5312,56 @@ func (m NoPartyIDsRepeatingGroup) Get(i int) NoPartyIDs {
 	return NoPartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPartyIDs of each entry in the NoPartyIDsRepeatingGroup
+func (m NoPartyIDsRepeatingGroup) All() iter.Seq2[int, NoPartyIDs] {
+	return func(yield func(int, NoPartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPartyIDs entries of the NoPartyIDsRepeatingGroup as a slice
+func (m NoPartyIDsRepeatingGroup) Slice() []NoPartyIDs {
+	s := make([]NoPartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPartyIDsRow holds the values of a NoPartyIDs, nil fields are left unset by AddRow
+type NoPartyIDsRow struct {
+	PartyID       *string
+	PartyIDSource *enum.PartyIDSource
+	PartyRole     *enum.PartyRole
+	NoPartySubIDs []NoPartySubIDsRow
+}
+
+//AddRow creates and appends a new NoPartyIDs to this group, setting the fields present in row
+func (m NoPartyIDsRepeatingGroup) AddRow(row NoPartyIDsRow) NoPartyIDs {
+	g := m.Add()
+	if row.PartyID != nil {
+		g.SetPartyID(*row.PartyID)
+	}
+	if row.PartyIDSource != nil {
+		g.SetPartyIDSource(*row.PartyIDSource)
+	}
+	if row.PartyRole != nil {
+		g.SetPartyRole(*row.PartyRole)
+	}
+	if len(row.NoPartySubIDs) > 0 {
+		f := NewNoPartySubIDsRepeatingGroup()
+		for _, r := range row.NoPartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoPartySubIDs(f)
+	}
+	return g
+}
+
 //NoSecurityAltID is a repeating group element, Tag 454
 type NoSecurityAltID struct {
 	*quickfix.Group
@@ -4597,6 +5428,44 @@ func (m NoSecurityAltIDRepeatingGroup) Get(i int) NoSecurityAltID {
 	return NoSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoSecurityAltID of each entry in the NoSecurityAltIDRepeatingGroup
+func (m NoSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoSecurityAltID] {
+	return func(yield func(int, NoSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoSecurityAltID entries of the NoSecurityAltIDRepeatingGroup as a slice
+func (m NoSecurityAltIDRepeatingGroup) Slice() []NoSecurityAltID {
+	s := make([]NoSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoSecurityAltIDRow holds the values of a NoSecurityAltID, nil fields are left unset by AddRow
+type NoSecurityAltIDRow struct {
+	SecurityAltID       *string
+	SecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoSecurityAltID to this group, setting the fields present in row
+func (m NoSecurityAltIDRepeatingGroup) AddRow(row NoSecurityAltIDRow) NoSecurityAltID {
+	g := m.Add()
+	if row.SecurityAltID != nil {
+		g.SetSecurityAltID(*row.SecurityAltID)
+	}
+	if row.SecurityAltIDSource != nil {
+		g.SetSecurityAltIDSource(*row.SecurityAltIDSource)
+	}
+	return g
+}
+
 //NoLegs is a repeating group element, Tag 555
 type NoLegs struct {
 	*quickfix.Group
@@ -5464,6 +6333,44 @@ func (m NoLegSecurityAltIDRepeatingGroup) Get(i int) NoLegSecurityAltID {
 	return NoLegSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoLegSecurityAltID of each entry in the NoLegSecurityAltIDRepeatingGroup
+func (m NoLegSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoLegSecurityAltID] {
+	return func(yield func(int, NoLegSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoLegSecurityAltID entries of the NoLegSecurityAltIDRepeatingGroup as a slice
+func (m NoLegSecurityAltIDRepeatingGroup) Slice() []NoLegSecurityAltID {
+	s := make([]NoLegSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoLegSecurityAltIDRow holds the values of a NoLegSecurityAltID, nil fields are left unset by AddRow
+type NoLegSecurityAltIDRow struct {
+	LegSecurityAltID       *string
+	LegSecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoLegSecurityAltID to this group, setting the fields present in row
+func (m NoLegSecurityAltIDRepeatingGroup) AddRow(row NoLegSecurityAltIDRow) NoLegSecurityAltID {
+	g := m.Add()
+	if row.LegSecurityAltID != nil {
+		g.SetLegSecurityAltID(*row.LegSecurityAltID)
+	}
+	if row.LegSecurityAltIDSource != nil {
+		g.SetLegSecurityAltIDSource(*row.LegSecurityAltIDSource)
+	}
+	return g
+}
+
 //NoLegsRepeatingGroup is a repeating group, Tag 555
 type NoLegsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -5487,6 +6394,208 @@ func (m NoLegsRepeatingGroup) Get(i int) NoLegs {
 	return NoLegs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoLegs of each entry in the NoLegsRepeatingGroup
+func (m NoLegsRepeatingGroup) All() iter.Seq2[int, NoLegs] {
+	return func(yield func(int, NoLegs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoLegs entries of the NoLegsRepeatingGroup as a slice
+func (m NoLegsRepeatingGroup) Slice() []NoLegs {
+	s := make([]NoLegs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoLegsRow holds the values of a NoLegs, nil fields are left unset by AddRow
+type NoLegsRow struct {
+	LegSymbol                     *string
+	LegSymbolSfx                  *string
+	LegSecurityID                 *string
+	LegSecurityIDSource           *string
+	NoLegSecurityAltID            []NoLegSecurityAltIDRow
+	LegProduct                    *int
+	LegCFICode                    *string
+	LegSecurityType               *string
+	LegSecuritySubType            *string
+	LegMaturityMonthYear          *string
+	LegMaturityDate               *string
+	LegCouponPaymentDate          *string
+	LegIssueDate                  *string
+	LegRepoCollateralSecurityType *int
+	LegRepurchaseTerm             *int
+	LegRepurchaseRate             *quickfix.FIXDecimal
+	LegFactor                     *quickfix.FIXDecimal
+	LegCreditRating               *string
+	LegInstrRegistry              *string
+	LegCountryOfIssue             *string
+	LegStateOrProvinceOfIssue     *string
+	LegLocaleOfIssue              *string
+	LegRedemptionDate             *string
+	LegStrikePrice                *quickfix.FIXDecimal
+	LegStrikeCurrency             *string
+	LegOptAttribute               *string
+	LegContractMultiplier         *quickfix.FIXDecimal
+	LegCouponRate                 *quickfix.FIXDecimal
+	LegSecurityExchange           *string
+	LegIssuer                     *string
+	EncodedLegIssuerLen           *int
+	EncodedLegIssuer              *string
+	LegSecurityDesc               *string
+	EncodedLegSecurityDescLen     *int
+	EncodedLegSecurityDesc        *string
+	LegRatioQty                   *quickfix.FIXDecimal
+	LegSide                       *string
+	LegCurrency                   *string
+	LegPool                       *string
+	LegDatedDate                  *string
+	LegContractSettlMonth         *string
+	LegInterestAccrualDate        *string
+}
+
+//AddRow creates and appends a new NoLegs to this group, setting the fields present in row
+func (m NoLegsRepeatingGroup) AddRow(row NoLegsRow) NoLegs {
+	g := m.Add()
+	if row.LegSymbol != nil {
+		g.SetLegSymbol(*row.LegSymbol)
+	}
+	if row.LegSymbolSfx != nil {
+		g.SetLegSymbolSfx(*row.LegSymbolSfx)
+	}
+	if row.LegSecurityID != nil {
+		g.SetLegSecurityID(*row.LegSecurityID)
+	}
+	if row.LegSecurityIDSource != nil {
+		g.SetLegSecurityIDSource(*row.LegSecurityIDSource)
+	}
+	if len(row.NoLegSecurityAltID) > 0 {
+		f := NewNoLegSecurityAltIDRepeatingGroup()
+		for _, r := range row.NoLegSecurityAltID {
+			f.AddRow(r)
+		}
+		g.SetNoLegSecurityAltID(f)
+	}
+	if row.LegProduct != nil {
+		g.SetLegProduct(*row.LegProduct)
+	}
+	if row.LegCFICode != nil {
+		g.SetLegCFICode(*row.LegCFICode)
+	}
+	if row.LegSecurityType != nil {
+		g.SetLegSecurityType(*row.LegSecurityType)
+	}
+	if row.LegSecuritySubType != nil {
+		g.SetLegSecuritySubType(*row.LegSecuritySubType)
+	}
+	if row.LegMaturityMonthYear != nil {
+		g.SetLegMaturityMonthYear(*row.LegMaturityMonthYear)
+	}
+	if row.LegMaturityDate != nil {
+		g.SetLegMaturityDate(*row.LegMaturityDate)
+	}
+	if row.LegCouponPaymentDate != nil {
+		g.SetLegCouponPaymentDate(*row.LegCouponPaymentDate)
+	}
+	if row.LegIssueDate != nil {
+		g.SetLegIssueDate(*row.LegIssueDate)
+	}
+	if row.LegRepoCollateralSecurityType != nil {
+		g.SetLegRepoCollateralSecurityType(*row.LegRepoCollateralSecurityType)
+	}
+	if row.LegRepurchaseTerm != nil {
+		g.SetLegRepurchaseTerm(*row.LegRepurchaseTerm)
+	}
+	if row.LegRepurchaseRate != nil {
+		g.SetLegRepurchaseRate(row.LegRepurchaseRate.Decimal, row.LegRepurchaseRate.Scale)
+	}
+	if row.LegFactor != nil {
+		g.SetLegFactor(row.LegFactor.Decimal, row.LegFactor.Scale)
+	}
+	if row.LegCreditRating != nil {
+		g.SetLegCreditRating(*row.LegCreditRating)
+	}
+	if row.LegInstrRegistry != nil {
+		g.SetLegInstrRegistry(*row.LegInstrRegistry)
+	}
+	if row.LegCountryOfIssue != nil {
+		g.SetLegCountryOfIssue(*row.LegCountryOfIssue)
+	}
+	if row.LegStateOrProvinceOfIssue != nil {
+		g.SetLegStateOrProvinceOfIssue(*row.LegStateOrProvinceOfIssue)
+	}
+	if row.LegLocaleOfIssue != nil {
+		g.SetLegLocaleOfIssue(*row.LegLocaleOfIssue)
+	}
+	if row.LegRedemptionDate != nil {
+		g.SetLegRedemptionDate(*row.LegRedemptionDate)
+	}
+	if row.LegStrikePrice != nil {
+		g.SetLegStrikePrice(row.LegStrikePrice.Decimal, row.LegStrikePrice.Scale)
+	}
+	if row.LegStrikeCurrency != nil {
+		g.SetLegStrikeCurrency(*row.LegStrikeCurrency)
+	}
+	if row.LegOptAttribute != nil {
+		g.SetLegOptAttribute(*row.LegOptAttribute)
+	}
+	if row.LegContractMultiplier != nil {
+		g.SetLegContractMultiplier(row.LegContractMultiplier.Decimal, row.LegContractMultiplier.Scale)
+	}
+	if row.LegCouponRate != nil {
+		g.SetLegCouponRate(row.LegCouponRate.Decimal, row.LegCouponRate.Scale)
+	}
+	if row.LegSecurityExchange != nil {
+		g.SetLegSecurityExchange(*row.LegSecurityExchange)
+	}
+	if row.LegIssuer != nil {
+		g.SetLegIssuer(*row.LegIssuer)
+	}
+	if row.EncodedLegIssuerLen != nil {
+		g.SetEncodedLegIssuerLen(*row.EncodedLegIssuerLen)
+	}
+	if row.EncodedLegIssuer != nil {
+		g.SetEncodedLegIssuer(*row.EncodedLegIssuer)
+	}
+	if row.LegSecurityDesc != nil {
+		g.SetLegSecurityDesc(*row.LegSecurityDesc)
+	}
+	if row.EncodedLegSecurityDescLen != nil {
+		g.SetEncodedLegSecurityDescLen(*row.EncodedLegSecurityDescLen)
+	}
+	if row.EncodedLegSecurityDesc != nil {
+		g.SetEncodedLegSecurityDesc(*row.EncodedLegSecurityDesc)
+	}
+	if row.LegRatioQty != nil {
+		g.SetLegRatioQty(row.LegRatioQty.Decimal, row.LegRatioQty.Scale)
+	}
+	if row.LegSide != nil {
+		g.SetLegSide(*row.LegSide)
+	}
+	if row.LegCurrency != nil {
+		g.SetLegCurrency(*row.LegCurrency)
+	}
+	if row.LegPool != nil {
+		g.SetLegPool(*row.LegPool)
+	}
+	if row.LegDatedDate != nil {
+		g.SetLegDatedDate(*row.LegDatedDate)
+	}
+	if row.LegContractSettlMonth != nil {
+		g.SetLegContractSettlMonth(*row.LegContractSettlMonth)
+	}
+	if row.LegInterestAccrualDate != nil {
+		g.SetLegInterestAccrualDate(*row.LegInterestAccrualDate)
+	}
+	return g
+}
+
 //NoUnderlyings is a repeating group element, Tag 711
 type NoUnderlyings struct {
 	*quickfix.Group
@@ -6428,6 +7537,44 @@ func (m NoUnderlyingSecurityAltIDRepeatingGroup) Get(i int) NoUnderlyingSecurity
 	return NoUnderlyingSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoUnderlyingSecurityAltID of each entry in the NoUnderlyingSecurityAltIDRepeatingGroup
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoUnderlyingSecurityAltID] {
+	return func(yield func(int, NoUnderlyingSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyingSecurityAltID entries of the NoUnderlyingSecurityAltIDRepeatingGroup as a slice
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) Slice() []NoUnderlyingSecurityAltID {
+	s := make([]NoUnderlyingSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingSecurityAltIDRow holds the values of a NoUnderlyingSecurityAltID, nil fields are left unset by AddRow
+type NoUnderlyingSecurityAltIDRow struct {
+	UnderlyingSecurityAltID       *string
+	UnderlyingSecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoUnderlyingSecurityAltID to this group, setting the fields present in row
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) AddRow(row NoUnderlyingSecurityAltIDRow) NoUnderlyingSecurityAltID {
+	g := m.Add()
+	if row.UnderlyingSecurityAltID != nil {
+		g.SetUnderlyingSecurityAltID(*row.UnderlyingSecurityAltID)
+	}
+	if row.UnderlyingSecurityAltIDSource != nil {
+		g.SetUnderlyingSecurityAltIDSource(*row.UnderlyingSecurityAltIDSource)
+	}
+	return g
+}
+
 //NoUnderlyingStips is a repeating group element, Tag 887
 type NoUnderlyingStips struct {
 	*quickfix.Group
@@ -6494,6 +7641,44 @@ func (m NoUnderlyingStipsRepeatingGroup) Get(i int) NoUnderlyingStips {
 	return NoUnderlyingStips{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoUnderlyingStips of each entry in the NoUnderlyingStipsRepeatingGroup
+func (m NoUnderlyingStipsRepeatingGroup) All() iter.Seq2[int, NoUnderlyingStips] {
+	return func(yield func(int, NoUnderlyingStips) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyingStips entries of the NoUnderlyingStipsRepeatingGroup as a slice
+func (m NoUnderlyingStipsRepeatingGroup) Slice() []NoUnderlyingStips {
+	s := make([]NoUnderlyingStips, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingStipsRow holds the values of a NoUnderlyingStips, nil fields are left unset by AddRow
+type NoUnderlyingStipsRow struct {
+	UnderlyingStipType  *string
+	UnderlyingStipValue *string
+}
+
+//AddRow creates and appends a new NoUnderlyingStips to this group, setting the fields present in row
+func (m NoUnderlyingStipsRepeatingGroup) AddRow(row NoUnderlyingStipsRow) NoUnderlyingStips {
+	g := m.Add()
+	if row.UnderlyingStipType != nil {
+		g.SetUnderlyingStipType(*row.UnderlyingStipType)
+	}
+	if row.UnderlyingStipValue != nil {
+		g.SetUnderlyingStipValue(*row.UnderlyingStipValue)
+	}
+	return g
+}
+
 //NoUnderlyingsRepeatingGroup is a repeating group, Tag 711
 type NoUnderlyingsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -6517,6 +7702,228 @@ func (m NoUnderlyingsRepeatingGroup) Get(i int) NoUnderlyings {
 	return NoUnderlyings{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoUnderlyings of each entry in the NoUnderlyingsRepeatingGroup
+func (m NoUnderlyingsRepeatingGroup) All() iter.Seq2[int, NoUnderlyings] {
+	return func(yield func(int, NoUnderlyings) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyings entries of the NoUnderlyingsRepeatingGroup as a slice
+func (m NoUnderlyingsRepeatingGroup) Slice() []NoUnderlyings {
+	s := make([]NoUnderlyings, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingsRow holds the values of a NoUnderlyings, nil fields are left unset by AddRow
+type NoUnderlyingsRow struct {
+	UnderlyingSymbol                     *string
+	UnderlyingSymbolSfx                  *string
+	UnderlyingSecurityID                 *string
+	UnderlyingSecurityIDSource           *string
+	NoUnderlyingSecurityAltID            []NoUnderlyingSecurityAltIDRow
+	UnderlyingProduct                    *int
+	UnderlyingCFICode                    *string
+	UnderlyingSecurityType               *string
+	UnderlyingSecuritySubType            *string
+	UnderlyingMaturityMonthYear          *string
+	UnderlyingMaturityDate               *string
+	UnderlyingCouponPaymentDate          *string
+	UnderlyingIssueDate                  *string
+	UnderlyingRepoCollateralSecurityType *int
+	UnderlyingRepurchaseTerm             *int
+	UnderlyingRepurchaseRate             *quickfix.FIXDecimal
+	UnderlyingFactor                     *quickfix.FIXDecimal
+	UnderlyingCreditRating               *string
+	UnderlyingInstrRegistry              *string
+	UnderlyingCountryOfIssue             *string
+	UnderlyingStateOrProvinceOfIssue     *string
+	UnderlyingLocaleOfIssue              *string
+	UnderlyingRedemptionDate             *string
+	UnderlyingStrikePrice                *quickfix.FIXDecimal
+	UnderlyingStrikeCurrency             *string
+	UnderlyingOptAttribute               *string
+	UnderlyingContractMultiplier         *quickfix.FIXDecimal
+	UnderlyingCouponRate                 *quickfix.FIXDecimal
+	UnderlyingSecurityExchange           *string
+	UnderlyingIssuer                     *string
+	EncodedUnderlyingIssuerLen           *int
+	EncodedUnderlyingIssuer              *string
+	UnderlyingSecurityDesc               *string
+	EncodedUnderlyingSecurityDescLen     *int
+	EncodedUnderlyingSecurityDesc        *string
+	UnderlyingCPProgram                  *string
+	UnderlyingCPRegType                  *string
+	UnderlyingCurrency                   *string
+	UnderlyingQty                        *quickfix.FIXDecimal
+	UnderlyingPx                         *quickfix.FIXDecimal
+	UnderlyingDirtyPrice                 *quickfix.FIXDecimal
+	UnderlyingEndPrice                   *quickfix.FIXDecimal
+	UnderlyingStartValue                 *quickfix.FIXDecimal
+	UnderlyingCurrentValue               *quickfix.FIXDecimal
+	UnderlyingEndValue                   *quickfix.FIXDecimal
+	NoUnderlyingStips                    []NoUnderlyingStipsRow
+}
+
+//AddRow creates and appends a new NoUnderlyings to this group, setting the fields present in row
+func (m NoUnderlyingsRepeatingGroup) AddRow(row NoUnderlyingsRow) NoUnderlyings {
+	g := m.Add()
+	if row.UnderlyingSymbol != nil {
+		g.SetUnderlyingSymbol(*row.UnderlyingSymbol)
+	}
+	if row.UnderlyingSymbolSfx != nil {
+		g.SetUnderlyingSymbolSfx(*row.UnderlyingSymbolSfx)
+	}
+	if row.UnderlyingSecurityID != nil {
+		g.SetUnderlyingSecurityID(*row.UnderlyingSecurityID)
+	}
+	if row.UnderlyingSecurityIDSource != nil {
+		g.SetUnderlyingSecurityIDSource(*row.UnderlyingSecurityIDSource)
+	}
+	if len(row.NoUnderlyingSecurityAltID) > 0 {
+		f := NewNoUnderlyingSecurityAltIDRepeatingGroup()
+		for _, r := range row.NoUnderlyingSecurityAltID {
+			f.AddRow(r)
+		}
+		g.SetNoUnderlyingSecurityAltID(f)
+	}
+	if row.UnderlyingProduct != nil {
+		g.SetUnderlyingProduct(*row.UnderlyingProduct)
+	}
+	if row.UnderlyingCFICode != nil {
+		g.SetUnderlyingCFICode(*row.UnderlyingCFICode)
+	}
+	if row.UnderlyingSecurityType != nil {
+		g.SetUnderlyingSecurityType(*row.UnderlyingSecurityType)
+	}
+	if row.UnderlyingSecuritySubType != nil {
+		g.SetUnderlyingSecuritySubType(*row.UnderlyingSecuritySubType)
+	}
+	if row.UnderlyingMaturityMonthYear != nil {
+		g.SetUnderlyingMaturityMonthYear(*row.UnderlyingMaturityMonthYear)
+	}
+	if row.UnderlyingMaturityDate != nil {
+		g.SetUnderlyingMaturityDate(*row.UnderlyingMaturityDate)
+	}
+	if row.UnderlyingCouponPaymentDate != nil {
+		g.SetUnderlyingCouponPaymentDate(*row.UnderlyingCouponPaymentDate)
+	}
+	if row.UnderlyingIssueDate != nil {
+		g.SetUnderlyingIssueDate(*row.UnderlyingIssueDate)
+	}
+	if row.UnderlyingRepoCollateralSecurityType != nil {
+		g.SetUnderlyingRepoCollateralSecurityType(*row.UnderlyingRepoCollateralSecurityType)
+	}
+	if row.UnderlyingRepurchaseTerm != nil {
+		g.SetUnderlyingRepurchaseTerm(*row.UnderlyingRepurchaseTerm)
+	}
+	if row.UnderlyingRepurchaseRate != nil {
+		g.SetUnderlyingRepurchaseRate(row.UnderlyingRepurchaseRate.Decimal, row.UnderlyingRepurchaseRate.Scale)
+	}
+	if row.UnderlyingFactor != nil {
+		g.SetUnderlyingFactor(row.UnderlyingFactor.Decimal, row.UnderlyingFactor.Scale)
+	}
+	if row.UnderlyingCreditRating != nil {
+		g.SetUnderlyingCreditRating(*row.UnderlyingCreditRating)
+	}
+	if row.UnderlyingInstrRegistry != nil {
+		g.SetUnderlyingInstrRegistry(*row.UnderlyingInstrRegistry)
+	}
+	if row.UnderlyingCountryOfIssue != nil {
+		g.SetUnderlyingCountryOfIssue(*row.UnderlyingCountryOfIssue)
+	}
+	if row.UnderlyingStateOrProvinceOfIssue != nil {
+		g.SetUnderlyingStateOrProvinceOfIssue(*row.UnderlyingStateOrProvinceOfIssue)
+	}
+	if row.UnderlyingLocaleOfIssue != nil {
+		g.SetUnderlyingLocaleOfIssue(*row.UnderlyingLocaleOfIssue)
+	}
+	if row.UnderlyingRedemptionDate != nil {
+		g.SetUnderlyingRedemptionDate(*row.UnderlyingRedemptionDate)
+	}
+	if row.UnderlyingStrikePrice != nil {
+		g.SetUnderlyingStrikePrice(row.UnderlyingStrikePrice.Decimal, row.UnderlyingStrikePrice.Scale)
+	}
+	if row.UnderlyingStrikeCurrency != nil {
+		g.SetUnderlyingStrikeCurrency(*row.UnderlyingStrikeCurrency)
+	}
+	if row.UnderlyingOptAttribute != nil {
+		g.SetUnderlyingOptAttribute(*row.UnderlyingOptAttribute)
+	}
+	if row.UnderlyingContractMultiplier != nil {
+		g.SetUnderlyingContractMultiplier(row.UnderlyingContractMultiplier.Decimal, row.UnderlyingContractMultiplier.Scale)
+	}
+	if row.UnderlyingCouponRate != nil {
+		g.SetUnderlyingCouponRate(row.UnderlyingCouponRate.Decimal, row.UnderlyingCouponRate.Scale)
+	}
+	if row.UnderlyingSecurityExchange != nil {
+		g.SetUnderlyingSecurityExchange(*row.UnderlyingSecurityExchange)
+	}
+	if row.UnderlyingIssuer != nil {
+		g.SetUnderlyingIssuer(*row.UnderlyingIssuer)
+	}
+	if row.EncodedUnderlyingIssuerLen != nil {
+		g.SetEncodedUnderlyingIssuerLen(*row.EncodedUnderlyingIssuerLen)
+	}
+	if row.EncodedUnderlyingIssuer != nil {
+		g.SetEncodedUnderlyingIssuer(*row.EncodedUnderlyingIssuer)
+	}
+	if row.UnderlyingSecurityDesc != nil {
+		g.SetUnderlyingSecurityDesc(*row.UnderlyingSecurityDesc)
+	}
+	if row.EncodedUnderlyingSecurityDescLen != nil {
+		g.SetEncodedUnderlyingSecurityDescLen(*row.EncodedUnderlyingSecurityDescLen)
+	}
+	if row.EncodedUnderlyingSecurityDesc != nil {
+		g.SetEncodedUnderlyingSecurityDesc(*row.EncodedUnderlyingSecurityDesc)
+	}
+	if row.UnderlyingCPProgram != nil {
+		g.SetUnderlyingCPProgram(*row.UnderlyingCPProgram)
+	}
+	if row.UnderlyingCPRegType != nil {
+		g.SetUnderlyingCPRegType(*row.UnderlyingCPRegType)
+	}
+	if row.UnderlyingCurrency != nil {
+		g.SetUnderlyingCurrency(*row.UnderlyingCurrency)
+	}
+	if row.UnderlyingQty != nil {
+		g.SetUnderlyingQty(row.UnderlyingQty.Decimal, row.UnderlyingQty.Scale)
+	}
+	if row.UnderlyingPx != nil {
+		g.SetUnderlyingPx(row.UnderlyingPx.Decimal, row.UnderlyingPx.Scale)
+	}
+	if row.UnderlyingDirtyPrice != nil {
+		g.SetUnderlyingDirtyPrice(row.UnderlyingDirtyPrice.Decimal, row.UnderlyingDirtyPrice.Scale)
+	}
+	if row.UnderlyingEndPrice != nil {
+		g.SetUnderlyingEndPrice(row.UnderlyingEndPrice.Decimal, row.UnderlyingEndPrice.Scale)
+	}
+	if row.UnderlyingStartValue != nil {
+		g.SetUnderlyingStartValue(row.UnderlyingStartValue.Decimal, row.UnderlyingStartValue.Scale)
+	}
+	if row.UnderlyingCurrentValue != nil {
+		g.SetUnderlyingCurrentValue(row.UnderlyingCurrentValue.Decimal, row.UnderlyingCurrentValue.Scale)
+	}
+	if row.UnderlyingEndValue != nil {
+		g.SetUnderlyingEndValue(row.UnderlyingEndValue.Decimal, row.UnderlyingEndValue.Scale)
+	}
+	if len(row.NoUnderlyingStips) > 0 {
+		f := NewNoUnderlyingStipsRepeatingGroup()
+		for _, r := range row.NoUnderlyingStips {
+			f.AddRow(r)
+		}
+		g.SetNoUnderlyingStips(f)
+	}
+	return g
+}
+
 //NoEvents is a repeating group element, Tag 864
 type NoEvents struct {
 	*quickfix.Group
@@ -6621,6 +8028,52 @@ func (m NoEventsRepeatingGroup) Get(i int) NoEvents {
 	return NoEvents{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoEvents of each entry in the NoEventsRepeatingGroup
+func (m NoEventsRepeatingGroup) All() iter.Seq2[int, NoEvents] {
+	return func(yield func(int, NoEvents) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoEvents entries of the NoEventsRepeatingGroup as a slice
+func (m NoEventsRepeatingGroup) Slice() []NoEvents {
+	s := make([]NoEvents, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoEventsRow holds the values of a NoEvents, nil fields are left unset by AddRow
+type NoEventsRow struct {
+	EventType *enum.EventType
+	EventDate *string
+	EventPx   *quickfix.FIXDecimal
+	EventText *string
+}
+
+//AddRow creates and appends a new NoEvents to this group, setting the fields present in row
+func (m NoEventsRepeatingGroup) AddRow(row NoEventsRow) NoEvents {
+	g := m.Add()
+	if row.EventType != nil {
+		g.SetEventType(*row.EventType)
+	}
+	if row.EventDate != nil {
+		g.SetEventDate(*row.EventDate)
+	}
+	if row.EventPx != nil {
+		g.SetEventPx(row.EventPx.Decimal, row.EventPx.Scale)
+	}
+	if row.EventText != nil {
+		g.SetEventText(*row.EventText)
+	}
+	return g
+}
+
 //NoInstrAttrib is a repeating group element, Tag 870
 type NoInstrAttrib struct {
 	*quickfix.Group
@@ -6686,3 +8139,41 @@ func (m NoInstrAttribRepeatingGroup) Add() NoInstrAttrib {
 func (m NoInstrAttribRepeatingGroup) Get(i int) NoInstrAttrib {
 	return NoInstrAttrib{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoInstrAttrib of each entry in the NoInstrAttribRepeatingGroup
+func (m NoInstrAttribRepeatingGroup) All() iter.Seq2[int, NoInstrAttrib] {
+	return func(yield func(int, NoInstrAttrib) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoInstrAttrib entries of the NoInstrAttribRepeatingGroup as a slice
+func (m NoInstrAttribRepeatingGroup) Slice() []NoInstrAttrib {
+	s := make([]NoInstrAttrib, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoInstrAttribRow holds the values of a NoInstrAttrib, nil fields are left unset by AddRow
+type NoInstrAttribRow struct {
+	InstrAttribType  *enum.InstrAttribType
+	InstrAttribValue *string
+}
+
+//AddRow creates and appends a new NoInstrAttrib to this group, setting the fields present in row
+func (m NoInstrAttribRepeatingGroup) AddRow(row NoInstrAttribRow) NoInstrAttrib {
+	g := m.Add()
+	if row.InstrAttribType != nil {
+		g.SetInstrAttribType(*row.InstrAttribType)
+	}
+	if row.InstrAttribValue != nil {
+		g.SetInstrAttribValue(*row.InstrAttribValue)
+	}
+	return g
+}
@@ -2,6 +2,7 @@ package assignmentreport
 
 import (
 	"github.com/shopspring/decimal"
+	"iter"
 
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
@@ -1490,6 +1491,44 @@ func (m NoPartySubIDsRepeatingGroup) Get(i int) NoPartySubIDs {
 	return NoPartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPartySubIDs of each entry in the NoPartySubIDsRepeatingGroup
+func (m NoPartySubIDsRepeatingGroup) All() iter.Seq2[int, NoPartySubIDs] {
+	return func(yield func(int, NoPartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPartySubIDs entries of the NoPartySubIDsRepeatingGroup as a slice
+func (m NoPartySubIDsRepeatingGroup) Slice() []NoPartySubIDs {
+	s := make([]NoPartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPartySubIDsRow holds the values of a NoPartySubIDs, nil fields are left unset by AddRow
+type NoPartySubIDsRow struct {
+	PartySubID     *string
+	PartySubIDType *enum.PartySubIDType
+}
+
+//AddRow creates and appends a new NoPartySubIDs to this group, setting the fields present in row
+func (m NoPartySubIDsRepeatingGroup) AddRow(row NoPartySubIDsRow) NoPartySubIDs {
+	g := m.Add()
+	if row.PartySubID != nil {
+		g.SetPartySubID(*row.PartySubID)
+	}
+	if row.PartySubIDType != nil {
+		g.SetPartySubIDType(*row.PartySubIDType)
+	}
+	return g
+}
+
 //NoPartyIDsRepeatingGroup is a repeating group, Tag 453
 type NoPartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -1513,6 +1552,56 @@ func (m NoPartyIDsRepeatingGroup) Get(i int) NoPartyIDs {
 	return NoPartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPartyIDs of each entry in the NoPartyIDsRepeatingGroup
+func (m NoPartyIDsRepeatingGroup) All() iter.Seq2[int, NoPartyIDs] {
+	return func(yield func(int, NoPartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPartyIDs entries of the NoPartyIDsRepeatingGroup as a slice
+func (m NoPartyIDsRepeatingGroup) Slice() []NoPartyIDs {
+	s := make([]NoPartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPartyIDsRow holds the values of a NoPartyIDs, nil fields are left unset by AddRow
+type NoPartyIDsRow struct {
+	PartyID       *string
+	PartyIDSource *enum.PartyIDSource
+	PartyRole     *enum.PartyRole
+	NoPartySubIDs []NoPartySubIDsRow
+}
+
+//AddRow creates and appends a new NoPartyIDs to this group, setting the fields present in row
+func (m NoPartyIDsRepeatingGroup) AddRow(row NoPartyIDsRow) NoPartyIDs {
+	g := m.Add()
+	if row.PartyID != nil {
+		g.SetPartyID(*row.PartyID)
+	}
+	if row.PartyIDSource != nil {
+		g.SetPartyIDSource(*row.PartyIDSource)
+	}
+	if row.PartyRole != nil {
+		g.SetPartyRole(*row.PartyRole)
+	}
+	if len(row.NoPartySubIDs) > 0 {
+		f := NewNoPartySubIDsRepeatingGroup()
+		for _, r := range row.NoPartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoPartySubIDs(f)
+	}
+	return g
+}
+
 //NoSecurityAltID is a repeating group element, Tag 454
 type NoSecurityAltID struct {
 	*quickfix.Group
@@ -1579,6 +1668,44 @@ func (m NoSecurityAltIDRepeatingGroup) Get(i int) NoSecurityAltID {
 	return NoSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoSecurityAltID of each entry in the NoSecurityAltIDRepeatingGroup
+func (m NoSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoSecurityAltID] {
+	return func(yield func(int, NoSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoSecurityAltID entries of the NoSecurityAltIDRepeatingGroup as a slice
+func (m NoSecurityAltIDRepeatingGroup) Slice() []NoSecurityAltID {
+	s := make([]NoSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoSecurityAltIDRow holds the values of a NoSecurityAltID, nil fields are left unset by AddRow
+type NoSecurityAltIDRow struct {
+	SecurityAltID       *string
+	SecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoSecurityAltID to this group, setting the fields present in row
+func (m NoSecurityAltIDRepeatingGroup) AddRow(row NoSecurityAltIDRow) NoSecurityAltID {
+	g := m.Add()
+	if row.SecurityAltID != nil {
+		g.SetSecurityAltID(*row.SecurityAltID)
+	}
+	if row.SecurityAltIDSource != nil {
+		g.SetSecurityAltIDSource(*row.SecurityAltIDSource)
+	}
+	return g
+}
+
 //NoLegs is a repeating group element, Tag 555
 type NoLegs struct {
 	*quickfix.Group
@@ -2446,6 +2573,44 @@ func (m NoLegSecurityAltIDRepeatingGroup) Get(i int) NoLegSecurityAltID {
 	return NoLegSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoLegSecurityAltID of each entry in the NoLegSecurityAltIDRepeatingGroup
+func (m NoLegSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoLegSecurityAltID] {
+	return func(yield func(int, NoLegSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoLegSecurityAltID entries of the NoLegSecurityAltIDRepeatingGroup as a slice
+func (m NoLegSecurityAltIDRepeatingGroup) Slice() []NoLegSecurityAltID {
+	s := make([]NoLegSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoLegSecurityAltIDRow holds the values of a NoLegSecurityAltID, nil fields are left unset by AddRow
+type NoLegSecurityAltIDRow struct {
+	LegSecurityAltID       *string
+	LegSecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoLegSecurityAltID to this group, setting the fields present in row
+func (m NoLegSecurityAltIDRepeatingGroup) AddRow(row NoLegSecurityAltIDRow) NoLegSecurityAltID {
+	g := m.Add()
+	if row.LegSecurityAltID != nil {
+		g.SetLegSecurityAltID(*row.LegSecurityAltID)
+	}
+	if row.LegSecurityAltIDSource != nil {
+		g.SetLegSecurityAltIDSource(*row.LegSecurityAltIDSource)
+	}
+	return g
+}
+
 //NoLegsRepeatingGroup is a repeating group, Tag 555
 type NoLegsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -2469,6 +2634,208 @@ func (m NoLegsRepeatingGroup) Get(i int) NoLegs {
 	return NoLegs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoLegs of each entry in the NoLegsRepeatingGroup
+func (m NoLegsRepeatingGroup) All() iter.Seq2[int, NoLegs] {
+	return func(yield func(int, NoLegs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoLegs entries of the NoLegsRepeatingGroup as a slice
+func (m NoLegsRepeatingGroup) Slice() []NoLegs {
+	s := make([]NoLegs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoLegsRow holds the values of a NoLegs, nil fields are left unset by AddRow
+type NoLegsRow struct {
+	LegSymbol                     *string
+	LegSymbolSfx                  *string
+	LegSecurityID                 *string
+	LegSecurityIDSource           *string
+	NoLegSecurityAltID            []NoLegSecurityAltIDRow
+	LegProduct                    *int
+	LegCFICode                    *string
+	LegSecurityType               *string
+	LegSecuritySubType            *string
+	LegMaturityMonthYear          *string
+	LegMaturityDate               *string
+	LegCouponPaymentDate          *string
+	LegIssueDate                  *string
+	LegRepoCollateralSecurityType *int
+	LegRepurchaseTerm             *int
+	LegRepurchaseRate             *quickfix.FIXDecimal
+	LegFactor                     *quickfix.FIXDecimal
+	LegCreditRating               *string
+	LegInstrRegistry              *string
+	LegCountryOfIssue             *string
+	LegStateOrProvinceOfIssue     *string
+	LegLocaleOfIssue              *string
+	LegRedemptionDate             *string
+	LegStrikePrice                *quickfix.FIXDecimal
+	LegStrikeCurrency             *string
+	LegOptAttribute               *string
+	LegContractMultiplier         *quickfix.FIXDecimal
+	LegCouponRate                 *quickfix.FIXDecimal
+	LegSecurityExchange           *string
+	LegIssuer                     *string
+	EncodedLegIssuerLen           *int
+	EncodedLegIssuer              *string
+	LegSecurityDesc               *string
+	EncodedLegSecurityDescLen     *int
+	EncodedLegSecurityDesc        *string
+	LegRatioQty                   *quickfix.FIXDecimal
+	LegSide                       *string
+	LegCurrency                   *string
+	LegPool                       *string
+	LegDatedDate                  *string
+	LegContractSettlMonth         *string
+	LegInterestAccrualDate        *string
+}
+
+//AddRow creates and appends a new NoLegs to this group, setting the fields present in row
+func (m NoLegsRepeatingGroup) AddRow(row NoLegsRow) NoLegs {
+	g := m.Add()
+	if row.LegSymbol != nil {
+		g.SetLegSymbol(*row.LegSymbol)
+	}
+	if row.LegSymbolSfx != nil {
+		g.SetLegSymbolSfx(*row.LegSymbolSfx)
+	}
+	if row.LegSecurityID != nil {
+		g.SetLegSecurityID(*row.LegSecurityID)
+	}
+	if row.LegSecurityIDSource != nil {
+		g.SetLegSecurityIDSource(*row.LegSecurityIDSource)
+	}
+	if len(row.NoLegSecurityAltID) > 0 {
+		f := NewNoLegSecurityAltIDRepeatingGroup()
+		for _, r := range row.NoLegSecurityAltID {
+			f.AddRow(r)
+		}
+		g.SetNoLegSecurityAltID(f)
+	}
+	if row.LegProduct != nil {
+		g.SetLegProduct(*row.LegProduct)
+	}
+	if row.LegCFICode != nil {
+		g.SetLegCFICode(*row.LegCFICode)
+	}
+	if row.LegSecurityType != nil {
+		g.SetLegSecurityType(*row.LegSecurityType)
+	}
+	if row.LegSecuritySubType != nil {
+		g.SetLegSecuritySubType(*row.LegSecuritySubType)
+	}
+	if row.LegMaturityMonthYear != nil {
+		g.SetLegMaturityMonthYear(*row.LegMaturityMonthYear)
+	}
+	if row.LegMaturityDate != nil {
+		g.SetLegMaturityDate(*row.LegMaturityDate)
+	}
+	if row.LegCouponPaymentDate != nil {
+		g.SetLegCouponPaymentDate(*row.LegCouponPaymentDate)
+	}
+	if row.LegIssueDate != nil {
+		g.SetLegIssueDate(*row.LegIssueDate)
+	}
+	if row.LegRepoCollateralSecurityType != nil {
+		g.SetLegRepoCollateralSecurityType(*row.LegRepoCollateralSecurityType)
+	}
+	if row.LegRepurchaseTerm != nil {
+		g.SetLegRepurchaseTerm(*row.LegRepurchaseTerm)
+	}
+	if row.LegRepurchaseRate != nil {
+		g.SetLegRepurchaseRate(row.LegRepurchaseRate.Decimal, row.LegRepurchaseRate.Scale)
+	}
+	if row.LegFactor != nil {
+		g.SetLegFactor(row.LegFactor.Decimal, row.LegFactor.Scale)
+	}
+	if row.LegCreditRating != nil {
+		g.SetLegCreditRating(*row.LegCreditRating)
+	}
+	if row.LegInstrRegistry != nil {
+		g.SetLegInstrRegistry(*row.LegInstrRegistry)
+	}
+	if row.LegCountryOfIssue != nil {
+		g.SetLegCountryOfIssue(*row.LegCountryOfIssue)
+	}
+	if row.LegStateOrProvinceOfIssue != nil {
+		g.SetLegStateOrProvinceOfIssue(*row.LegStateOrProvinceOfIssue)
+	}
+	if row.LegLocaleOfIssue != nil {
+		g.SetLegLocaleOfIssue(*row.LegLocaleOfIssue)
+	}
+	if row.LegRedemptionDate != nil {
+		g.SetLegRedemptionDate(*row.LegRedemptionDate)
+	}
+	if row.LegStrikePrice != nil {
+		g.SetLegStrikePrice(row.LegStrikePrice.Decimal, row.LegStrikePrice.Scale)
+	}
+	if row.LegStrikeCurrency != nil {
+		g.SetLegStrikeCurrency(*row.LegStrikeCurrency)
+	}
+	if row.LegOptAttribute != nil {
+		g.SetLegOptAttribute(*row.LegOptAttribute)
+	}
+	if row.LegContractMultiplier != nil {
+		g.SetLegContractMultiplier(row.LegContractMultiplier.Decimal, row.LegContractMultiplier.Scale)
+	}
+	if row.LegCouponRate != nil {
+		g.SetLegCouponRate(row.LegCouponRate.Decimal, row.LegCouponRate.Scale)
+	}
+	if row.LegSecurityExchange != nil {
+		g.SetLegSecurityExchange(*row.LegSecurityExchange)
+	}
+	if row.LegIssuer != nil {
+		g.SetLegIssuer(*row.LegIssuer)
+	}
+	if row.EncodedLegIssuerLen != nil {
+		g.SetEncodedLegIssuerLen(*row.EncodedLegIssuerLen)
+	}
+	if row.EncodedLegIssuer != nil {
+		g.SetEncodedLegIssuer(*row.EncodedLegIssuer)
+	}
+	if row.LegSecurityDesc != nil {
+		g.SetLegSecurityDesc(*row.LegSecurityDesc)
+	}
+	if row.EncodedLegSecurityDescLen != nil {
+		g.SetEncodedLegSecurityDescLen(*row.EncodedLegSecurityDescLen)
+	}
+	if row.EncodedLegSecurityDesc != nil {
+		g.SetEncodedLegSecurityDesc(*row.EncodedLegSecurityDesc)
+	}
+	if row.LegRatioQty != nil {
+		g.SetLegRatioQty(row.LegRatioQty.Decimal, row.LegRatioQty.Scale)
+	}
+	if row.LegSide != nil {
+		g.SetLegSide(*row.LegSide)
+	}
+	if row.LegCurrency != nil {
+		g.SetLegCurrency(*row.LegCurrency)
+	}
+	if row.LegPool != nil {
+		g.SetLegPool(*row.LegPool)
+	}
+	if row.LegDatedDate != nil {
+		g.SetLegDatedDate(*row.LegDatedDate)
+	}
+	if row.LegContractSettlMonth != nil {
+		g.SetLegContractSettlMonth(*row.LegContractSettlMonth)
+	}
+	if row.LegInterestAccrualDate != nil {
+		g.SetLegInterestAccrualDate(*row.LegInterestAccrualDate)
+	}
+	return g
+}
+
 //NoPositions is a repeating group element, Tag 702
 type NoPositions struct {
 	*quickfix.Group
@@ -2712,6 +3079,44 @@ func (m NoNestedPartySubIDsRepeatingGroup) Get(i int) NoNestedPartySubIDs {
 	return NoNestedPartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoNestedPartySubIDs of each entry in the NoNestedPartySubIDsRepeatingGroup
+func (m NoNestedPartySubIDsRepeatingGroup) All() iter.Seq2[int, NoNestedPartySubIDs] {
+	return func(yield func(int, NoNestedPartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoNestedPartySubIDs entries of the NoNestedPartySubIDsRepeatingGroup as a slice
+func (m NoNestedPartySubIDsRepeatingGroup) Slice() []NoNestedPartySubIDs {
+	s := make([]NoNestedPartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoNestedPartySubIDsRow holds the values of a NoNestedPartySubIDs, nil fields are left unset by AddRow
+type NoNestedPartySubIDsRow struct {
+	NestedPartySubID     *string
+	NestedPartySubIDType *int
+}
+
+//AddRow creates and appends a new NoNestedPartySubIDs to this group, setting the fields present in row
+func (m NoNestedPartySubIDsRepeatingGroup) AddRow(row NoNestedPartySubIDsRow) NoNestedPartySubIDs {
+	g := m.Add()
+	if row.NestedPartySubID != nil {
+		g.SetNestedPartySubID(*row.NestedPartySubID)
+	}
+	if row.NestedPartySubIDType != nil {
+		g.SetNestedPartySubIDType(*row.NestedPartySubIDType)
+	}
+	return g
+}
+
 //NoNestedPartyIDsRepeatingGroup is a repeating group, Tag 539
 type NoNestedPartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -2735,6 +3140,56 @@ func (m NoNestedPartyIDsRepeatingGroup) Get(i int) NoNestedPartyIDs {
 	return NoNestedPartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoNestedPartyIDs of each entry in the NoNestedPartyIDsRepeatingGroup
+func (m NoNestedPartyIDsRepeatingGroup) All() iter.Seq2[int, NoNestedPartyIDs] {
+	return func(yield func(int, NoNestedPartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoNestedPartyIDs entries of the NoNestedPartyIDsRepeatingGroup as a slice
+func (m NoNestedPartyIDsRepeatingGroup) Slice() []NoNestedPartyIDs {
+	s := make([]NoNestedPartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoNestedPartyIDsRow holds the values of a NoNestedPartyIDs, nil fields are left unset by AddRow
+type NoNestedPartyIDsRow struct {
+	NestedPartyID       *string
+	NestedPartyIDSource *string
+	NestedPartyRole     *int
+	NoNestedPartySubIDs []NoNestedPartySubIDsRow
+}
+
+//AddRow creates and appends a new NoNestedPartyIDs to this group, setting the fields present in row
+func (m NoNestedPartyIDsRepeatingGroup) AddRow(row NoNestedPartyIDsRow) NoNestedPartyIDs {
+	g := m.Add()
+	if row.NestedPartyID != nil {
+		g.SetNestedPartyID(*row.NestedPartyID)
+	}
+	if row.NestedPartyIDSource != nil {
+		g.SetNestedPartyIDSource(*row.NestedPartyIDSource)
+	}
+	if row.NestedPartyRole != nil {
+		g.SetNestedPartyRole(*row.NestedPartyRole)
+	}
+	if len(row.NoNestedPartySubIDs) > 0 {
+		f := NewNoNestedPartySubIDsRepeatingGroup()
+		for _, r := range row.NoNestedPartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoNestedPartySubIDs(f)
+	}
+	return g
+}
+
 //NoPositionsRepeatingGroup is a repeating group, Tag 702
 type NoPositionsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -2758,6 +3213,60 @@ func (m NoPositionsRepeatingGroup) Get(i int) NoPositions {
 	return NoPositions{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPositions of each entry in the NoPositionsRepeatingGroup
+func (m NoPositionsRepeatingGroup) All() iter.Seq2[int, NoPositions] {
+	return func(yield func(int, NoPositions) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPositions entries of the NoPositionsRepeatingGroup as a slice
+func (m NoPositionsRepeatingGroup) Slice() []NoPositions {
+	s := make([]NoPositions, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPositionsRow holds the values of a NoPositions, nil fields are left unset by AddRow
+type NoPositionsRow struct {
+	PosType          *enum.PosType
+	LongQty          *quickfix.FIXDecimal
+	ShortQty         *quickfix.FIXDecimal
+	PosQtyStatus     *enum.PosQtyStatus
+	NoNestedPartyIDs []NoNestedPartyIDsRow
+}
+
+//AddRow creates and appends a new NoPositions to this group, setting the fields present in row
+func (m NoPositionsRepeatingGroup) AddRow(row NoPositionsRow) NoPositions {
+	g := m.Add()
+	if row.PosType != nil {
+		g.SetPosType(*row.PosType)
+	}
+	if row.LongQty != nil {
+		g.SetLongQty(row.LongQty.Decimal, row.LongQty.Scale)
+	}
+	if row.ShortQty != nil {
+		g.SetShortQty(row.ShortQty.Decimal, row.ShortQty.Scale)
+	}
+	if row.PosQtyStatus != nil {
+		g.SetPosQtyStatus(*row.PosQtyStatus)
+	}
+	if len(row.NoNestedPartyIDs) > 0 {
+		f := NewNoNestedPartyIDsRepeatingGroup()
+		for _, r := range row.NoNestedPartyIDs {
+			f.AddRow(r)
+		}
+		g.SetNoNestedPartyIDs(f)
+	}
+	return g
+}
+
 //NoUnderlyings is a repeating group element, Tag 711
 type NoUnderlyings struct {
 	*quickfix.Group
@@ -3699,6 +4208,44 @@ func (m NoUnderlyingSecurityAltIDRepeatingGroup) Get(i int) NoUnderlyingSecurity
 	return NoUnderlyingSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoUnderlyingSecurityAltID of each entry in the NoUnderlyingSecurityAltIDRepeatingGroup
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoUnderlyingSecurityAltID] {
+	return func(yield func(int, NoUnderlyingSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyingSecurityAltID entries of the NoUnderlyingSecurityAltIDRepeatingGroup as a slice
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) Slice() []NoUnderlyingSecurityAltID {
+	s := make([]NoUnderlyingSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingSecurityAltIDRow holds the values of a NoUnderlyingSecurityAltID, nil fields are left unset by AddRow
+type NoUnderlyingSecurityAltIDRow struct {
+	UnderlyingSecurityAltID       *string
+	UnderlyingSecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoUnderlyingSecurityAltID to this group, setting the fields present in row
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) AddRow(row NoUnderlyingSecurityAltIDRow) NoUnderlyingSecurityAltID {
+	g := m.Add()
+	if row.UnderlyingSecurityAltID != nil {
+		g.SetUnderlyingSecurityAltID(*row.UnderlyingSecurityAltID)
+	}
+	if row.UnderlyingSecurityAltIDSource != nil {
+		g.SetUnderlyingSecurityAltIDSource(*row.UnderlyingSecurityAltIDSource)
+	}
+	return g
+}
+
 //NoUnderlyingStips is a repeating group element, Tag 887
 type NoUnderlyingStips struct {
 	*quickfix.Group
@@ -3765,6 +4312,44 @@ func (m NoUnderlyingStipsRepeatingGroup) Get(i int) NoUnderlyingStips {
 	return NoUnderlyingStips{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoUnderlyingStips of each entry in the NoUnderlyingStipsRepeatingGroup
+func (m NoUnderlyingStipsRepeatingGroup) All() iter.Seq2[int, NoUnderlyingStips] {
+	return func(yield func(int, NoUnderlyingStips) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyingStips entries of the NoUnderlyingStipsRepeatingGroup as a slice
+func (m NoUnderlyingStipsRepeatingGroup) Slice() []NoUnderlyingStips {
+	s := make([]NoUnderlyingStips, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingStipsRow holds the values of a NoUnderlyingStips, nil fields are left unset by AddRow
+type NoUnderlyingStipsRow struct {
+	UnderlyingStipType  *string
+	UnderlyingStipValue *string
+}
+
+//AddRow creates and appends a new NoUnderlyingStips to this group, setting the fields present in row
+func (m NoUnderlyingStipsRepeatingGroup) AddRow(row NoUnderlyingStipsRow) NoUnderlyingStips {
+	g := m.Add()
+	if row.UnderlyingStipType != nil {
+		g.SetUnderlyingStipType(*row.UnderlyingStipType)
+	}
+	if row.UnderlyingStipValue != nil {
+		g.SetUnderlyingStipValue(*row.UnderlyingStipValue)
+	}
+	return g
+}
+
 //NoUnderlyingsRepeatingGroup is a repeating group, Tag 711
 type NoUnderlyingsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -3788,6 +4373,228 @@ func (m NoUnderlyingsRepeatingGroup) Get(i int) NoUnderlyings {
 	return NoUnderlyings{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoUnderlyings of each entry in the NoUnderlyingsRepeatingGroup
+func (m NoUnderlyingsRepeatingGroup) All() iter.Seq2[int, NoUnderlyings] {
+	return func(yield func(int, NoUnderlyings) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyings entries of the NoUnderlyingsRepeatingGroup as a slice
+func (m NoUnderlyingsRepeatingGroup) Slice() []NoUnderlyings {
+	s := make([]NoUnderlyings, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingsRow holds the values of a NoUnderlyings, nil fields are left unset by AddRow
+type NoUnderlyingsRow struct {
+	UnderlyingSymbol                     *string
+	UnderlyingSymbolSfx                  *string
+	UnderlyingSecurityID                 *string
+	UnderlyingSecurityIDSource           *string
+	NoUnderlyingSecurityAltID            []NoUnderlyingSecurityAltIDRow
+	UnderlyingProduct                    *int
+	UnderlyingCFICode                    *string
+	UnderlyingSecurityType               *string
+	UnderlyingSecuritySubType            *string
+	UnderlyingMaturityMonthYear          *string
+	UnderlyingMaturityDate               *string
+	UnderlyingCouponPaymentDate          *string
+	UnderlyingIssueDate                  *string
+	UnderlyingRepoCollateralSecurityType *int
+	UnderlyingRepurchaseTerm             *int
+	UnderlyingRepurchaseRate             *quickfix.FIXDecimal
+	UnderlyingFactor                     *quickfix.FIXDecimal
+	UnderlyingCreditRating               *string
+	UnderlyingInstrRegistry              *string
+	UnderlyingCountryOfIssue             *string
+	UnderlyingStateOrProvinceOfIssue     *string
+	UnderlyingLocaleOfIssue              *string
+	UnderlyingRedemptionDate             *string
+	UnderlyingStrikePrice                *quickfix.FIXDecimal
+	UnderlyingStrikeCurrency             *string
+	UnderlyingOptAttribute               *string
+	UnderlyingContractMultiplier         *quickfix.FIXDecimal
+	UnderlyingCouponRate                 *quickfix.FIXDecimal
+	UnderlyingSecurityExchange           *string
+	UnderlyingIssuer                     *string
+	EncodedUnderlyingIssuerLen           *int
+	EncodedUnderlyingIssuer              *string
+	UnderlyingSecurityDesc               *string
+	EncodedUnderlyingSecurityDescLen     *int
+	EncodedUnderlyingSecurityDesc        *string
+	UnderlyingCPProgram                  *string
+	UnderlyingCPRegType                  *string
+	UnderlyingCurrency                   *string
+	UnderlyingQty                        *quickfix.FIXDecimal
+	UnderlyingPx                         *quickfix.FIXDecimal
+	UnderlyingDirtyPrice                 *quickfix.FIXDecimal
+	UnderlyingEndPrice                   *quickfix.FIXDecimal
+	UnderlyingStartValue                 *quickfix.FIXDecimal
+	UnderlyingCurrentValue               *quickfix.FIXDecimal
+	UnderlyingEndValue                   *quickfix.FIXDecimal
+	NoUnderlyingStips                    []NoUnderlyingStipsRow
+}
+
+//AddRow creates and appends a new NoUnderlyings to this group, setting the fields present in row
+func (m NoUnderlyingsRepeatingGroup) AddRow(row NoUnderlyingsRow) NoUnderlyings {
+	g := m.Add()
+	if row.UnderlyingSymbol != nil {
+		g.SetUnderlyingSymbol(*row.UnderlyingSymbol)
+	}
+	if row.UnderlyingSymbolSfx != nil {
+		g.SetUnderlyingSymbolSfx(*row.UnderlyingSymbolSfx)
+	}
+	if row.UnderlyingSecurityID != nil {
+		g.SetUnderlyingSecurityID(*row.UnderlyingSecurityID)
+	}
+	if row.UnderlyingSecurityIDSource != nil {
+		g.SetUnderlyingSecurityIDSource(*row.UnderlyingSecurityIDSource)
+	}
+	if len(row.NoUnderlyingSecurityAltID) > 0 {
+		f := NewNoUnderlyingSecurityAltIDRepeatingGroup()
+		for _, r := range row.NoUnderlyingSecurityAltID {
+			f.AddRow(r)
+		}
+		g.SetNoUnderlyingSecurityAltID(f)
+	}
+	if row.UnderlyingProduct != nil {
+		g.SetUnderlyingProduct(*row.UnderlyingProduct)
+	}
+	if row.UnderlyingCFICode != nil {
+		g.SetUnderlyingCFICode(*row.UnderlyingCFICode)
+	}
+	if row.UnderlyingSecurityType != nil {
+		g.SetUnderlyingSecurityType(*row.UnderlyingSecurityType)
+	}
+	if row.UnderlyingSecuritySubType != nil {
+		g.SetUnderlyingSecuritySubType(*row.UnderlyingSecuritySubType)
+	}
+	if row.UnderlyingMaturityMonthYear != nil {
+		g.SetUnderlyingMaturityMonthYear(*row.UnderlyingMaturityMonthYear)
+	}
+	if row.UnderlyingMaturityDate != nil {
+		g.SetUnderlyingMaturityDate(*row.UnderlyingMaturityDate)
+	}
+	if row.UnderlyingCouponPaymentDate != nil {
+		g.SetUnderlyingCouponPaymentDate(*row.UnderlyingCouponPaymentDate)
+	}
+	if row.UnderlyingIssueDate != nil {
+		g.SetUnderlyingIssueDate(*row.UnderlyingIssueDate)
+	}
+	if row.UnderlyingRepoCollateralSecurityType != nil {
+		g.SetUnderlyingRepoCollateralSecurityType(*row.UnderlyingRepoCollateralSecurityType)
+	}
+	if row.UnderlyingRepurchaseTerm != nil {
+		g.SetUnderlyingRepurchaseTerm(*row.UnderlyingRepurchaseTerm)
+	}
+	if row.UnderlyingRepurchaseRate != nil {
+		g.SetUnderlyingRepurchaseRate(row.UnderlyingRepurchaseRate.Decimal, row.UnderlyingRepurchaseRate.Scale)
+	}
+	if row.UnderlyingFactor != nil {
+		g.SetUnderlyingFactor(row.UnderlyingFactor.Decimal, row.UnderlyingFactor.Scale)
+	}
+	if row.UnderlyingCreditRating != nil {
+		g.SetUnderlyingCreditRating(*row.UnderlyingCreditRating)
+	}
+	if row.UnderlyingInstrRegistry != nil {
+		g.SetUnderlyingInstrRegistry(*row.UnderlyingInstrRegistry)
+	}
+	if row.UnderlyingCountryOfIssue != nil {
+		g.SetUnderlyingCountryOfIssue(*row.UnderlyingCountryOfIssue)
+	}
+	if row.UnderlyingStateOrProvinceOfIssue != nil {
+		g.SetUnderlyingStateOrProvinceOfIssue(*row.UnderlyingStateOrProvinceOfIssue)
+	}
+	if row.UnderlyingLocaleOfIssue != nil {
+		g.SetUnderlyingLocaleOfIssue(*row.UnderlyingLocaleOfIssue)
+	}
+	if row.UnderlyingRedemptionDate != nil {
+		g.SetUnderlyingRedemptionDate(*row.UnderlyingRedemptionDate)
+	}
+	if row.UnderlyingStrikePrice != nil {
+		g.SetUnderlyingStrikePrice(row.UnderlyingStrikePrice.Decimal, row.UnderlyingStrikePrice.Scale)
+	}
+	if row.UnderlyingStrikeCurrency != nil {
+		g.SetUnderlyingStrikeCurrency(*row.UnderlyingStrikeCurrency)
+	}
+	if row.UnderlyingOptAttribute != nil {
+		g.SetUnderlyingOptAttribute(*row.UnderlyingOptAttribute)
+	}
+	if row.UnderlyingContractMultiplier != nil {
+		g.SetUnderlyingContractMultiplier(row.UnderlyingContractMultiplier.Decimal, row.UnderlyingContractMultiplier.Scale)
+	}
+	if row.UnderlyingCouponRate != nil {
+		g.SetUnderlyingCouponRate(row.UnderlyingCouponRate.Decimal, row.UnderlyingCouponRate.Scale)
+	}
+	if row.UnderlyingSecurityExchange != nil {
+		g.SetUnderlyingSecurityExchange(*row.UnderlyingSecurityExchange)
+	}
+	if row.UnderlyingIssuer != nil {
+		g.SetUnderlyingIssuer(*row.UnderlyingIssuer)
+	}
+	if row.EncodedUnderlyingIssuerLen != nil {
+		g.SetEncodedUnderlyingIssuerLen(*row.EncodedUnderlyingIssuerLen)
+	}
+	if row.EncodedUnderlyingIssuer != nil {
+		g.SetEncodedUnderlyingIssuer(*row.EncodedUnderlyingIssuer)
+	}
+	if row.UnderlyingSecurityDesc != nil {
+		g.SetUnderlyingSecurityDesc(*row.UnderlyingSecurityDesc)
+	}
+	if row.EncodedUnderlyingSecurityDescLen != nil {
+		g.SetEncodedUnderlyingSecurityDescLen(*row.EncodedUnderlyingSecurityDescLen)
+	}
+	if row.EncodedUnderlyingSecurityDesc != nil {
+		g.SetEncodedUnderlyingSecurityDesc(*row.EncodedUnderlyingSecurityDesc)
+	}
+	if row.UnderlyingCPProgram != nil {
+		g.SetUnderlyingCPProgram(*row.UnderlyingCPProgram)
+	}
+	if row.UnderlyingCPRegType != nil {
+		g.SetUnderlyingCPRegType(*row.UnderlyingCPRegType)
+	}
+	if row.UnderlyingCurrency != nil {
+		g.SetUnderlyingCurrency(*row.UnderlyingCurrency)
+	}
+	if row.UnderlyingQty != nil {
+		g.SetUnderlyingQty(row.UnderlyingQty.Decimal, row.UnderlyingQty.Scale)
+	}
+	if row.UnderlyingPx != nil {
+		g.SetUnderlyingPx(row.UnderlyingPx.Decimal, row.UnderlyingPx.Scale)
+	}
+	if row.UnderlyingDirtyPrice != nil {
+		g.SetUnderlyingDirtyPrice(row.UnderlyingDirtyPrice.Decimal, row.UnderlyingDirtyPrice.Scale)
+	}
+	if row.UnderlyingEndPrice != nil {
+		g.SetUnderlyingEndPrice(row.UnderlyingEndPrice.Decimal, row.UnderlyingEndPrice.Scale)
+	}
+	if row.UnderlyingStartValue != nil {
+		g.SetUnderlyingStartValue(row.UnderlyingStartValue.Decimal, row.UnderlyingStartValue.Scale)
+	}
+	if row.UnderlyingCurrentValue != nil {
+		g.SetUnderlyingCurrentValue(row.UnderlyingCurrentValue.Decimal, row.UnderlyingCurrentValue.Scale)
+	}
+	if row.UnderlyingEndValue != nil {
+		g.SetUnderlyingEndValue(row.UnderlyingEndValue.Decimal, row.UnderlyingEndValue.Scale)
+	}
+	if len(row.NoUnderlyingStips) > 0 {
+		f := NewNoUnderlyingStipsRepeatingGroup()
+		for _, r := range row.NoUnderlyingStips {
+			f.AddRow(r)
+		}
+		g.SetNoUnderlyingStips(f)
+	}
+	return g
+}
+
 //NoPosAmt is a repeating group element, Tag 753
 type NoPosAmt struct {
 	*quickfix.Group
@@ -3854,6 +4661,44 @@ func (m NoPosAmtRepeatingGroup) Get(i int) NoPosAmt {
 	return NoPosAmt{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPosAmt of each entry in the NoPosAmtRepeatingGroup
+func (m NoPosAmtRepeatingGroup) All() iter.Seq2[int, NoPosAmt] {
+	return func(yield func(int, NoPosAmt) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPosAmt entries of the NoPosAmtRepeatingGroup as a slice
+func (m NoPosAmtRepeatingGroup) Slice() []NoPosAmt {
+	s := make([]NoPosAmt, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPosAmtRow holds the values of a NoPosAmt, nil fields are left unset by AddRow
+type NoPosAmtRow struct {
+	PosAmtType *enum.PosAmtType
+	PosAmt     *quickfix.FIXDecimal
+}
+
+//AddRow creates and appends a new NoPosAmt to this group, setting the fields present in row
+func (m NoPosAmtRepeatingGroup) AddRow(row NoPosAmtRow) NoPosAmt {
+	g := m.Add()
+	if row.PosAmtType != nil {
+		g.SetPosAmtType(*row.PosAmtType)
+	}
+	if row.PosAmt != nil {
+		g.SetPosAmt(row.PosAmt.Decimal, row.PosAmt.Scale)
+	}
+	return g
+}
+
 //NoEvents is a repeating group element, Tag 864
 type NoEvents struct {
 	*quickfix.Group
@@ -3957,3 +4802,49 @@ func (m NoEventsRepeatingGroup) Add() NoEvents {
 func (m NoEventsRepeatingGroup) Get(i int) NoEvents {
 	return NoEvents{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoEvents of each entry in the NoEventsRepeatingGroup
+func (m NoEventsRepeatingGroup) All() iter.Seq2[int, NoEvents] {
+	return func(yield func(int, NoEvents) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoEvents entries of the NoEventsRepeatingGroup as a slice
+func (m NoEventsRepeatingGroup) Slice() []NoEvents {
+	s := make([]NoEvents, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoEventsRow holds the values of a NoEvents, nil fields are left unset by AddRow
+type NoEventsRow struct {
+	EventType *enum.EventType
+	EventDate *string
+	EventPx   *quickfix.FIXDecimal
+	EventText *string
+}
+
+//AddRow creates and appends a new NoEvents to this group, setting the fields present in row
+func (m NoEventsRepeatingGroup) AddRow(row NoEventsRow) NoEvents {
+	g := m.Add()
+	if row.EventType != nil {
+		g.SetEventType(*row.EventType)
+	}
+	if row.EventDate != nil {
+		g.SetEventDate(*row.EventDate)
+	}
+	if row.EventPx != nil {
+		g.SetEventPx(row.EventPx.Decimal, row.EventPx.Scale)
+	}
+	if row.EventText != nil {
+		g.SetEventText(*row.EventText)
+	}
+	return g
+}
@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/logon"
+	"github.com/quickfixgo/fix44/userrequest"
+	"github.com/quickfixgo/fix44/userresponse"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// logonRejectText is the text of all the rejected Logons, so that it does not tell
+// an unknown username from an incorrect password
+const logonRejectText = "logon failed"
+
+// userRejectText is the text of the UserResponses to the log offs and password changes
+// with invalid credentials, for the same reason
+const userRejectText = "invalid credentials"
+
+// Authenticator authenticates the Logons received by an acceptor against a Store
+// and answers the UserRequests of the counterparties with UserResponses.
+// Failed attempts are rate limited per username and per counterparty CompID.
+// An Authenticator is safe for concurrent use.
+type Authenticator struct {
+	Store Store
+	// Limiter, if set, locks out usernames and counterparties after too many failures
+	Limiter *Limiter
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// OnLogon, if set, is called for each Logon and UserRequest log on, err is nil if it is accepted
+	OnLogon func(sessionID quickfix.SessionID, username string, err error)
+
+	mu    sync.Mutex
+	users map[quickfix.SessionID]map[string]bool
+}
+
+// New returns an Authenticator of the store with the default Limiter
+func New(store Store) *Authenticator {
+	return &Authenticator{
+		Store:   store,
+		Limiter: NewLimiter(),
+		Send:    quickfix.SendToTarget,
+		users:   make(map[quickfix.SessionID]map[string]bool),
+	}
+}
+
+// authenticate checks the credentials and the rate limits, the username is logged on the session if valid
+func (a *Authenticator) authenticate(sessionID quickfix.SessionID, c Credentials) error {
+	err := a.check(sessionID, c)
+	if err == nil {
+		a.mu.Lock()
+		if a.users[sessionID] == nil {
+			a.users[sessionID] = make(map[string]bool)
+		}
+		a.users[sessionID][c.Username] = true
+		a.mu.Unlock()
+	}
+	if a.OnLogon != nil {
+		a.OnLogon(sessionID, c.Username, err)
+	}
+	return err
+}
+
+func (a *Authenticator) check(sessionID quickfix.SessionID, c Credentials) error {
+	if c.Username == "" {
+		return ErrNoUsername
+	}
+	return a.limited(sessionID, c.Username, func() error { return a.Store.Authenticate(c) })
+}
+
+// limited calls f unless the username or the counterparty is locked out, and records its result
+func (a *Authenticator) limited(sessionID quickfix.SessionID, username string, f func() error) error {
+	if a.Limiter == nil {
+		return f()
+	}
+	userKey, compKey := "user:"+username, "comp:"+sessionID.TargetCompID
+	if err := a.Limiter.Allow(userKey); err != nil {
+		return err
+	}
+	if err := a.Limiter.Allow(compKey); err != nil {
+		return err
+	}
+	err := f()
+	if err != nil && !errors.Is(err, ErrWeakPassword) {
+		a.Limiter.Fail(userKey)
+		a.Limiter.Fail(compKey)
+	} else if err == nil {
+		a.Limiter.Succeed(userKey)
+		a.Limiter.Succeed(compKey)
+	}
+	return err
+}
+
+// FromAdmin authenticates the Logons received, other admin messages are accepted.
+// It is meant to be called from FromAdmin of the acceptor, the quickfix.RejectLogon
+// returned for invalid credentials makes the session log out with a generic text,
+// the detailed error is passed to OnLogon.
+func (a *Authenticator) FromAdmin(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	if msgType != "A" {
+		return nil
+	}
+	l := logon.FromMessage(msg)
+	var c Credentials
+	c.Username, _ = l.GetUsername()
+	c.Password, _ = l.GetPassword()
+	c.RawData, _ = l.GetRawData()
+	if err := a.authenticate(sessionID, c); err != nil {
+		return quickfix.RejectLogon{Text: logonRejectText}
+	}
+	return nil
+}
+
+// OnLogout forgets the users logged on the session, it is meant to be called from OnLogout
+func (a *Authenticator) OnLogout(sessionID quickfix.SessionID) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.users, sessionID)
+}
+
+// LoggedOn returns true if the user is logged on the session, by a Logon or a UserRequest
+func (a *Authenticator) LoggedOn(sessionID quickfix.SessionID, username string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.users[sessionID][username]
+}
+
+// Process handles UserRequests, other messages are ignored.
+// It is meant to be called from FromApp.
+func (a *Authenticator) Process(msg *quickfix.Message, sessionID quickfix.SessionID) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	if msgType == "BE" {
+		return a.OnUserRequest(userrequest.FromMessage(msg), sessionID)
+	}
+	return nil
+}
+
+// OnUserRequest logs users on and off, changes passwords and reports the user status
+// of the session, and answers with a UserResponse. Logging a user off needs its credentials,
+// and the responses to invalid credentials do not tell why they are invalid.
+func (a *Authenticator) OnUserRequest(r userrequest.UserRequest, sessionID quickfix.SessionID) error {
+	id, err := r.GetUserRequestID()
+	if err != nil {
+		return err
+	}
+	requestType, err := r.GetUserRequestType()
+	if err != nil {
+		return err
+	}
+	var c Credentials
+	c.Username, _ = r.GetUsername()
+	c.Password, _ = r.GetPassword()
+	c.RawData, _ = r.GetRawData()
+	newPassword, _ := r.GetNewPassword()
+
+	var status enum.UserStatus
+	var text string
+	switch requestType {
+	case enum.UserRequestType_LOG_ON_USER:
+		if err := a.authenticate(sessionID, c); err != nil {
+			status, text = enum.UserStatus_NOT_LOGGED_IN, logonRejectText
+		} else {
+			status = enum.UserStatus_LOGGED_IN
+		}
+	case enum.UserRequestType_LOG_OFF_USER:
+		if err := a.check(sessionID, c); err != nil {
+			status, text = enum.UserStatus_OTHER, userRejectText
+			break
+		}
+		a.mu.Lock()
+		delete(a.users[sessionID], c.Username)
+		a.mu.Unlock()
+		status = enum.UserStatus_NOT_LOGGED_IN
+	case enum.UserRequestType_CHANGE_PASSWORD_FOR_USER:
+		switch err := a.changePassword(sessionID, c, newPassword); {
+		case err == nil:
+			status = enum.UserStatus_PASSWORD_CHANGED
+		case errors.Is(err, ErrWeakPassword):
+			status, text = enum.UserStatus_OTHER, err.Error()
+		default:
+			status, text = enum.UserStatus_OTHER, userRejectText
+		}
+	case enum.UserRequestType_REQUEST_INDIVIDUAL_USER_STATUS:
+		status = enum.UserStatus_NOT_LOGGED_IN
+		if a.LoggedOn(sessionID, c.Username) {
+			status = enum.UserStatus_LOGGED_IN
+		}
+	default:
+		status, text = enum.UserStatus_OTHER, "unsupported UserRequestType "+string(requestType)
+	}
+
+	resp := userresponse.New(field.NewUserRequestID(id), field.NewUsername(c.Username))
+	resp.SetUserStatus(status)
+	if text != "" {
+		resp.SetUserStatusText(text)
+	}
+	return a.Send(resp, sessionID)
+}
+
+func (a *Authenticator) changePassword(sessionID quickfix.SessionID, c Credentials, newPassword string) error {
+	if c.Username == "" {
+		return ErrNoUsername
+	}
+	if newPassword == "" {
+		return ErrWeakPassword
+	}
+	return a.limited(sessionID, c.Username, func() error { return a.Store.ChangePassword(c, newPassword) })
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/logon"
+	"github.com/quickfixgo/fix44/userrequest"
+	"github.com/quickfixgo/fix44/userresponse"
+	"github.com/quickfixgo/quickfix"
+)
+
+func TestMemoryStoreAuthenticate(t *testing.T) {
+	s := NewMemoryStore()
+	s.Add("alice", "secret123")
+	s.Add("bob", "secret456")
+	if err := s.SetDisabled("bob", true); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		c    Credentials
+		want error
+	}{
+		{Credentials{Username: "alice", Password: "secret123"}, nil},
+		{Credentials{Username: "alice", Password: "secret456"}, ErrBadPassword},
+		{Credentials{Username: "carol", Password: "secret123"}, ErrUnknownUser},
+		{Credentials{Username: "bob", Password: "secret456"}, ErrDisabled},
+		{Credentials{Username: "bob", Password: "secret123"}, ErrBadPassword},
+	}
+	for _, tt := range tests {
+		if err := s.Authenticate(tt.c); !errors.Is(err, tt.want) {
+			t.Errorf("Authenticate(%s, %s) = %v, want %v", tt.c.Username, tt.c.Password, err, tt.want)
+		}
+	}
+}
+
+func TestFromAdmin(t *testing.T) {
+	s := NewMemoryStore()
+	s.Add("alice", "secret123")
+	tests := []struct {
+		name     string
+		username string
+		password string
+		want     error
+	}{
+		{"valid", "alice", "secret123", nil},
+		{"incorrect password", "alice", "secret456", ErrBadPassword},
+		{"unknown user", "carol", "secret123", ErrUnknownUser},
+		{"no username", "", "secret123", ErrNoUsername},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New(s)
+			var logonErr error
+			a.OnLogon = func(sessionID quickfix.SessionID, username string, err error) { logonErr = err }
+			sessionID := quickfix.SessionID{TargetCompID: "FIRM"}
+			l := logon.New(field.NewEncryptMethod(enum.EncryptMethod_NONE_OTHER), field.NewHeartBtInt(30))
+			if tt.username != "" {
+				l.SetUsername(tt.username)
+			}
+			l.SetPassword(tt.password)
+			err := a.FromAdmin(l.ToMessage(), sessionID)
+			if !errors.Is(logonErr, tt.want) {
+				t.Errorf("got OnLogon error %v, want %v", logonErr, tt.want)
+			}
+			if tt.want == nil {
+				if err != nil || !a.LoggedOn(sessionID, tt.username) {
+					t.Errorf("got %v, want the user logged on", err)
+				}
+				return
+			}
+			// the reject does not tell why the Logon failed
+			var reject quickfix.RejectLogon
+			if !errors.As(err, &reject) || reject.Text != logonRejectText {
+				t.Errorf("got %#v, want a RejectLogon with the generic text", err)
+			}
+		})
+	}
+}
+
+// userRequest is a UserRequest of alice and the UserStatus and UserStatusText it is answered with
+type userRequest struct {
+	requestType enum.UserRequestType
+	password    string
+	newPassword string
+	wantStatus  enum.UserStatus
+	wantText    string
+}
+
+func TestOnUserRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		requests     []userRequest
+		wantLoggedOn bool
+	}{
+		{"log on", []userRequest{
+			{enum.UserRequestType_LOG_ON_USER, "secret123", "", enum.UserStatus_LOGGED_IN, ""},
+			{enum.UserRequestType_REQUEST_INDIVIDUAL_USER_STATUS, "", "", enum.UserStatus_LOGGED_IN, ""},
+		}, true},
+		{"incorrect password", []userRequest{
+			{enum.UserRequestType_LOG_ON_USER, "secret456", "", enum.UserStatus_NOT_LOGGED_IN, logonRejectText},
+		}, false},
+		{"log off", []userRequest{
+			{enum.UserRequestType_LOG_ON_USER, "secret123", "", enum.UserStatus_LOGGED_IN, ""},
+			{enum.UserRequestType_LOG_OFF_USER, "secret123", "", enum.UserStatus_NOT_LOGGED_IN, ""},
+		}, false},
+		{"log off without password", []userRequest{
+			{enum.UserRequestType_LOG_ON_USER, "secret123", "", enum.UserStatus_LOGGED_IN, ""},
+			{enum.UserRequestType_LOG_OFF_USER, "", "", enum.UserStatus_OTHER, userRejectText},
+			{enum.UserRequestType_LOG_OFF_USER, "secret456", "", enum.UserStatus_OTHER, userRejectText},
+		}, true},
+		{"change password", []userRequest{
+			{enum.UserRequestType_CHANGE_PASSWORD_FOR_USER, "secret123", "secret789", enum.UserStatus_PASSWORD_CHANGED, ""},
+			{enum.UserRequestType_LOG_ON_USER, "secret789", "", enum.UserStatus_LOGGED_IN, ""},
+		}, true},
+		{"weak password", []userRequest{
+			{enum.UserRequestType_CHANGE_PASSWORD_FOR_USER, "secret123", "short", enum.UserStatus_OTHER, ErrWeakPassword.Error()},
+		}, false},
+		{"change with incorrect password", []userRequest{
+			{enum.UserRequestType_CHANGE_PASSWORD_FOR_USER, "secret456", "secret789", enum.UserStatus_OTHER, userRejectText},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewMemoryStore()
+			s.Add("alice", "secret123")
+			a := New(s)
+			var responses []userresponse.UserResponse
+			a.Send = func(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+				responses = append(responses, userresponse.FromMessage(m.ToMessage()))
+				return nil
+			}
+			sessionID := quickfix.SessionID{TargetCompID: "FIRM"}
+			for i, req := range tt.requests {
+				r := userrequest.New(field.NewUserRequestID("r"), field.NewUserRequestType(req.requestType), field.NewUsername("alice"))
+				if req.password != "" {
+					r.SetPassword(req.password)
+				}
+				if req.newPassword != "" {
+					r.SetNewPassword(req.newPassword)
+				}
+				if err := a.Process(r.ToMessage(), sessionID); err != nil {
+					t.Fatal(err)
+				}
+				status, _ := responses[i].GetUserStatus()
+				text, _ := responses[i].GetUserStatusText()
+				if status != req.wantStatus || text != req.wantText {
+					t.Errorf("request %d: got %s %q, want %s %q", i, status, text, req.wantStatus, req.wantText)
+				}
+			}
+			if got := a.LoggedOn(sessionID, "alice"); got != tt.wantLoggedOn {
+				t.Errorf("got logged on %v, want %v", got, tt.wantLoggedOn)
+			}
+		})
+	}
+}
+
+func TestLogOnUserUnknown(t *testing.T) {
+	// an unknown user gets the same response as an incorrect password
+	s := NewMemoryStore()
+	s.Add("alice", "secret123")
+	a := New(s)
+	var got [][2]string
+	a.Send = func(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+		r := userresponse.FromMessage(m.ToMessage())
+		status, _ := r.GetUserStatus()
+		text, _ := r.GetUserStatusText()
+		got = append(got, [2]string{string(status), text})
+		return nil
+	}
+	for _, username := range []string{"alice", "carol"} {
+		r := userrequest.New(field.NewUserRequestID("r"), field.NewUserRequestType(enum.UserRequestType_LOG_ON_USER), field.NewUsername(username))
+		r.SetPassword("secret456")
+		if err := a.Process(r.ToMessage(), quickfix.SessionID{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(got) != 2 || got[0] != got[1] {
+		t.Errorf("got %v, want the same responses", got)
+	}
+}
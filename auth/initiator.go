@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/logon"
+	"github.com/quickfixgo/fix44/userrequest"
+	"github.com/quickfixgo/fix44/userresponse"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// Initiator adds its credentials to the Logons sent by an initiator
+// and checks the Logon answering them. An Initiator is safe for concurrent use.
+type Initiator struct {
+	mu          sync.Mutex
+	credentials Credentials
+	heartBtInt  int
+}
+
+// NewInitiator returns an Initiator logging on with the credentials
+func NewInitiator(c Credentials) *Initiator {
+	return &Initiator{credentials: c}
+}
+
+// SetPassword replaces the password of the next Logons, e.g. after a password change
+func (i *Initiator) SetPassword(password string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.credentials.Password = password
+}
+
+// Credentials returns the credentials of the next Logons
+func (i *Initiator) Credentials() Credentials {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.credentials
+}
+
+// ToAdmin adds the credentials to the Logons sent, it is meant to be called from ToAdmin
+func (i *Initiator) ToAdmin(msg *quickfix.Message, sessionID quickfix.SessionID) {
+	if msgType, _ := msg.MsgType(); msgType != "A" {
+		return
+	}
+	l := logon.FromMessage(msg)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.heartBtInt, _ = l.GetHeartBtInt()
+	c := i.credentials
+	if c.Username != "" {
+		l.SetUsername(c.Username)
+	}
+	if c.Password != "" {
+		l.SetPassword(c.Password)
+	}
+	if c.RawData != "" {
+		l.SetRawDataLength(len(c.RawData))
+		l.SetRawData(c.RawData)
+	}
+}
+
+// FromAdmin checks that the Logon received echoes the HeartBtInt sent and uses no encryption.
+// It is meant to be called from FromAdmin of the initiator, the quickfix.RejectLogon
+// returned for an invalid Logon makes the session log out.
+func (i *Initiator) FromAdmin(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	if msgType != "A" {
+		return nil
+	}
+	l := logon.FromMessage(msg)
+	if m, _ := l.GetEncryptMethod(); m != enum.EncryptMethod_NONE_OTHER {
+		return quickfix.RejectLogon{Text: fmt.Sprintf("auth: unsupported EncryptMethod %s", m)}
+	}
+	i.mu.Lock()
+	sent := i.heartBtInt
+	i.mu.Unlock()
+	if got, _ := l.GetHeartBtInt(); sent != 0 && got != sent {
+		return quickfix.RejectLogon{Text: fmt.Sprintf("auth: HeartBtInt %d differs from %d sent", got, sent)}
+	}
+	return nil
+}
+
+// ErrUnknownRequest is returned for a UserResponse to a UserRequest not sent by the Client
+var ErrUnknownRequest = errors.New("auth: unknown UserRequestID")
+
+// Response is a UserRequest sent and its UserResponse
+type Response struct {
+	UserRequestID string
+	RequestType   enum.UserRequestType
+	Username      string
+	// Done is set by the UserResponse
+	Done      bool
+	Status    enum.UserStatus
+	Text      string
+	UpdatedAt time.Time
+}
+
+// Client sends UserRequests and collects their UserResponses.
+// A Client is safe for concurrent use.
+type Client struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// Initiator, if set, gets the new password of its user once a password change is accepted
+	Initiator *Initiator
+	// OnResponse, if set, is called with each UserResponse, once the Client is unlocked
+	OnResponse func(Response)
+
+	mu          sync.Mutex
+	nextID      int
+	idPrefix    string
+	requests    map[string]*Response
+	newPassword map[string]string
+}
+
+// NewClient returns a Client for the session
+func NewClient(sessionID quickfix.SessionID) *Client {
+	return &Client{
+		SessionID:   sessionID,
+		Send:        quickfix.SendToTarget,
+		idPrefix:    time.Now().Format("150405") + "-",
+		requests:    make(map[string]*Response),
+		newPassword: make(map[string]string),
+	}
+}
+
+// newID must be called with mu held
+func (c *Client) newID() string {
+	c.nextID++
+	return fmt.Sprintf("%s%d", c.idPrefix, c.nextID)
+}
+
+func (c *Client) request(requestType enum.UserRequestType, cr Credentials, newPassword string) (string, error) {
+	if cr.Username == "" {
+		return "", ErrNoUsername
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id := c.newID()
+	msg := userrequest.New(field.NewUserRequestID(id), field.NewUserRequestType(requestType), field.NewUsername(cr.Username))
+	if cr.Password != "" {
+		msg.SetPassword(cr.Password)
+	}
+	if cr.RawData != "" {
+		msg.SetRawDataLength(len(cr.RawData))
+		msg.SetRawData(cr.RawData)
+	}
+	if newPassword != "" {
+		msg.SetNewPassword(newPassword)
+	}
+	if err := c.Send(msg, c.SessionID); err != nil {
+		return "", err
+	}
+	c.requests[id] = &Response{UserRequestID: id, RequestType: requestType, Username: cr.Username, UpdatedAt: time.Now()}
+	if newPassword != "" {
+		c.newPassword[id] = newPassword
+	}
+	return id, nil
+}
+
+// LogonUser logs an additional user on the session, it returns the UserRequestID
+func (c *Client) LogonUser(cr Credentials) (string, error) {
+	return c.request(enum.UserRequestType_LOG_ON_USER, cr, "")
+}
+
+// LogoffUser logs a user off the session
+func (c *Client) LogoffUser(username string) (string, error) {
+	return c.request(enum.UserRequestType_LOG_OFF_USER, Credentials{Username: username}, "")
+}
+
+// ChangePassword changes the password of a user
+func (c *Client) ChangePassword(cr Credentials, newPassword string) (string, error) {
+	if newPassword == "" {
+		return "", ErrWeakPassword
+	}
+	return c.request(enum.UserRequestType_CHANGE_PASSWORD_FOR_USER, cr, newPassword)
+}
+
+// UserStatus requests the status of a user
+func (c *Client) UserStatus(username string) (string, error) {
+	return c.request(enum.UserRequestType_REQUEST_INDIVIDUAL_USER_STATUS, Credentials{Username: username}, "")
+}
+
+// Process handles UserResponses, other messages are ignored.
+// It is meant to be called from FromApp.
+func (c *Client) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	if msgType == "BF" {
+		return c.OnUserResponse(userresponse.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnUserResponse records the UserResponse of a UserRequest, the password of
+// the Initiator is updated when its password change is accepted
+func (c *Client) OnUserResponse(r userresponse.UserResponse) error {
+	id, err := r.GetUserRequestID()
+	if err != nil {
+		return err
+	}
+	status, _ := r.GetUserStatus()
+	text, _ := r.GetUserStatusText()
+
+	c.mu.Lock()
+	req, ok := c.requests[id]
+	if !ok {
+		c.mu.Unlock()
+		return ErrUnknownRequest
+	}
+	req.Done, req.Status, req.Text, req.UpdatedAt = true, status, text, time.Now()
+	resp := *req
+	newPassword := c.newPassword[id]
+	delete(c.newPassword, id)
+	c.mu.Unlock()
+	if status == enum.UserStatus_PASSWORD_CHANGED && c.Initiator != nil && c.Initiator.Credentials().Username == resp.Username {
+		c.Initiator.SetPassword(newPassword)
+	}
+	if c.OnResponse != nil {
+		c.OnResponse(resp)
+	}
+	return nil
+}
+
+// Request returns a copy of the UserRequest with the UserRequestID
+func (c *Client) Request(userRequestID string) (Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.requests[userRequestID]
+	if !ok {
+		return Response{}, false
+	}
+	return *r, true
+}
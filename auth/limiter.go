@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLocked is returned while a username or a counterparty is locked out after too many failures
+var ErrLocked = errors.New("auth: too many failed attempts, locked out")
+
+// Limiter locks out a key, e.g. a username, after MaxFailures failed attempts
+// within Window, for Lockout. A Limiter is safe for concurrent use.
+type Limiter struct {
+	MaxFailures int
+	Window      time.Duration
+	Lockout     time.Duration
+
+	mu       sync.Mutex
+	failures map[string]*failures
+	// pruned is the last time the keys without recent failure were forgotten
+	pruned time.Time
+}
+
+type failures struct {
+	times       []time.Time
+	lockedUntil time.Time
+}
+
+// NewLimiter returns a Limiter locking out for 15 minutes after 5 failures within 5 minutes
+func NewLimiter() *Limiter {
+	return &Limiter{
+		MaxFailures: 5,
+		Window:      5 * time.Minute,
+		Lockout:     15 * time.Minute,
+		failures:    make(map[string]*failures),
+	}
+}
+
+// Allow returns ErrLocked if the key is locked out
+func (l *Limiter) Allow(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, ok := l.failures[key]
+	if ok && time.Now().Before(f.lockedUntil) {
+		return ErrLocked
+	}
+	return nil
+}
+
+// Fail records a failed attempt of the key
+func (l *Limiter) Fail(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if now.Sub(l.pruned) >= l.Window {
+		l.prune(now)
+	}
+	f, ok := l.failures[key]
+	if !ok {
+		f = &failures{}
+		l.failures[key] = f
+	}
+	recent := f.times[:0]
+	for _, t := range f.times {
+		if now.Sub(t) < l.Window {
+			recent = append(recent, t)
+		}
+	}
+	f.times = append(recent, now)
+	if l.MaxFailures > 0 && len(f.times) >= l.MaxFailures {
+		f.lockedUntil = now.Add(l.Lockout)
+		f.times = nil
+	}
+}
+
+// prune must be called with mu held, it forgets the keys neither locked out
+// nor with a failure within Window
+func (l *Limiter) prune(now time.Time) {
+	for key, f := range l.failures {
+		if now.Before(f.lockedUntil) {
+			continue
+		}
+		if n := len(f.times); n > 0 && now.Sub(f.times[n-1]) < l.Window {
+			continue
+		}
+		delete(l.failures, key)
+	}
+	l.pruned = now
+}
+
+// Succeed forgets the failed attempts of the key
+func (l *Limiter) Succeed(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if f, ok := l.failures[key]; ok && !time.Now().Before(f.lockedUntil) {
+		delete(l.failures, key)
+	}
+}
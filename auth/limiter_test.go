@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	tests := []struct {
+		name string
+		// steps are f for a failure, s for a success and w to wait for 20ms
+		steps string
+		want  error
+	}{
+		{"no failure", "", nil},
+		{"below the limit", "ff", nil},
+		{"at the limit", "fff", ErrLocked},
+		{"success resets the failures", "ffsff", nil},
+		{"success while locked out", "fffs", ErrLocked},
+		{"failures out of the window", "ffwf", nil},
+		{"lockout over", "fffww", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLimiter()
+			l.MaxFailures, l.Window, l.Lockout = 3, 15*time.Millisecond, 30*time.Millisecond
+			for _, step := range tt.steps {
+				switch step {
+				case 'f':
+					l.Fail("user:a")
+				case 's':
+					l.Succeed("user:a")
+				case 'w':
+					time.Sleep(20 * time.Millisecond)
+				}
+			}
+			if err := l.Allow("user:a"); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+			if err := l.Allow("user:b"); err != nil {
+				t.Errorf("got %v for another key", err)
+			}
+		})
+	}
+}
+
+func TestLimiterPrune(t *testing.T) {
+	l := NewLimiter()
+	l.MaxFailures, l.Window, l.Lockout = 2, 15*time.Millisecond, 30*time.Millisecond
+	l.Fail("user:a")
+	l.Fail("user:b")
+	l.Fail("user:b")
+	time.Sleep(20 * time.Millisecond)
+	l.Fail("user:c")
+	if _, ok := l.failures["user:a"]; ok {
+		t.Error("got the failures of user:a kept after the window")
+	}
+	if err := l.Allow("user:b"); !errors.Is(err, ErrLocked) {
+		t.Errorf("got %v, want user:b still locked out", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	l.Fail("user:c")
+	if len(l.failures) != 1 {
+		t.Errorf("got %d keys, want user:c only", len(l.failures))
+	}
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrNoUsername is returned for a Logon or a UserRequest without Username
+	ErrNoUsername = errors.New("auth: no username")
+	// ErrUnknownUser is returned by a Store for a username it does not know
+	ErrUnknownUser = errors.New("auth: unknown user")
+	// ErrBadPassword is returned by a Store for an incorrect password
+	ErrBadPassword = errors.New("auth: incorrect password")
+	// ErrDisabled is returned by a Store for a disabled user
+	ErrDisabled = errors.New("auth: user disabled")
+	// ErrWeakPassword is returned by a Store for a new password it does not accept
+	ErrWeakPassword = errors.New("auth: new password rejected")
+)
+
+// Credentials are the Username, Password and RawData of a Logon or a UserRequest
+type Credentials struct {
+	Username string
+	Password string
+	// RawData is sent by venues authenticating with a token or a certificate, it is optional
+	RawData string
+}
+
+// Store is a pluggable credential store, e.g. backed by a database or a directory.
+// Its methods may be called concurrently.
+type Store interface {
+	// Authenticate returns nil if the credentials are valid,
+	// ErrUnknownUser, ErrBadPassword, ErrDisabled or another error otherwise
+	Authenticate(c Credentials) error
+	// ChangePassword replaces the password of the user once the credentials are authenticated
+	ChangePassword(c Credentials, newPassword string) error
+}
+
+// DefaultMinPasswordLength is the minimum length of the new passwords of a MemoryStore
+const DefaultMinPasswordLength = 8
+
+// pbkdf2Iterations is the PBKDF2-SHA256 cost of the password hashes
+const pbkdf2Iterations = 100000
+
+// dummySalt is hashed with the passwords of the unknown users,
+// so that they are not rejected faster than the incorrect passwords
+var dummySalt = make([]byte, 16)
+
+type storedUser struct {
+	salt     []byte
+	hash     []byte
+	disabled bool
+}
+
+// MemoryStore is an in-memory Store of salted PBKDF2 password hashes,
+// RawData is ignored. A MemoryStore is safe for concurrent use.
+type MemoryStore struct {
+	// MinPasswordLength of the new passwords, DefaultMinPasswordLength by default
+	MinPasswordLength int
+
+	mu    sync.Mutex
+	users map[string]*storedUser
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{MinPasswordLength: DefaultMinPasswordLength, users: make(map[string]*storedUser)}
+}
+
+func hashPassword(password string, salt []byte) []byte {
+	h, err := pbkdf2.Key(sha256.New, password, salt, pbkdf2Iterations, sha256.Size)
+	if err != nil {
+		// only returned for key lengths or parameters forbidden in FIPS mode
+		panic(err)
+	}
+	return h
+}
+
+func newStoredUser(password string) *storedUser {
+	salt := make([]byte, 16)
+	rand.Read(salt)
+	return &storedUser{salt: salt, hash: hashPassword(password, salt)}
+}
+
+// Add adds a user or replaces its password
+func (s *MemoryStore) Add(username, password string) {
+	u := newStoredUser(password)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[username] = u
+}
+
+// Remove removes a user
+func (s *MemoryStore) Remove(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.users, username)
+}
+
+// SetDisabled disables or enables a user
+func (s *MemoryStore) SetDisabled(username string, disabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[username]
+	if !ok {
+		return ErrUnknownUser
+	}
+	u.disabled = disabled
+	return nil
+}
+
+// Authenticate implements Store
+func (s *MemoryStore) Authenticate(c Credentials) error {
+	s.mu.Lock()
+	u, ok := s.users[c.Username]
+	var salt, hash []byte
+	var disabled bool
+	if ok {
+		salt, hash, disabled = u.salt, u.hash, u.disabled
+	}
+	s.mu.Unlock()
+	if !ok {
+		hashPassword(c.Password, dummySalt)
+		return ErrUnknownUser
+	}
+	if subtle.ConstantTimeCompare(hashPassword(c.Password, salt), hash) != 1 {
+		return ErrBadPassword
+	}
+	if disabled {
+		return ErrDisabled
+	}
+	return nil
+}
+
+// ChangePassword implements Store, the new password must be long enough and differ from the current one
+func (s *MemoryStore) ChangePassword(c Credentials, newPassword string) error {
+	if err := s.Authenticate(c); err != nil {
+		return err
+	}
+	if len(newPassword) < s.MinPasswordLength || newPassword == c.Password {
+		return ErrWeakPassword
+	}
+	u := newStoredUser(newPassword)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.users[c.Username]; ok {
+		u.disabled = old.disabled
+	}
+	s.users[c.Username] = u
+	return nil
+}
@@ -2,6 +2,7 @@ package bidrequest
 
 import (
 	"github.com/shopspring/decimal"
+	"iter"
 	"time"
 
 	"github.com/quickfixgo/enum"
@@ -847,6 +848,80 @@ func (m NoBidDescriptorsRepeatingGroup) Get(i int) NoBidDescriptors {
 	return NoBidDescriptors{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoBidDescriptors of each entry in the NoBidDescriptorsRepeatingGroup
+func (m NoBidDescriptorsRepeatingGroup) All() iter.Seq2[int, NoBidDescriptors] {
+	return func(yield func(int, NoBidDescriptors) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoBidDescriptors entries of the NoBidDescriptorsRepeatingGroup as a slice
+func (m NoBidDescriptorsRepeatingGroup) Slice() []NoBidDescriptors {
+	s := make([]NoBidDescriptors, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoBidDescriptorsRow holds the values of a NoBidDescriptors, nil fields are left unset by AddRow
+type NoBidDescriptorsRow struct {
+	BidDescriptorType      *enum.BidDescriptorType
+	BidDescriptor          *string
+	SideValueInd           *enum.SideValueInd
+	LiquidityValue         *quickfix.FIXDecimal
+	LiquidityNumSecurities *int
+	LiquidityPctLow        *quickfix.FIXDecimal
+	LiquidityPctHigh       *quickfix.FIXDecimal
+	EFPTrackingError       *quickfix.FIXDecimal
+	FairValue              *quickfix.FIXDecimal
+	OutsideIndexPct        *quickfix.FIXDecimal
+	ValueOfFutures         *quickfix.FIXDecimal
+}
+
+//AddRow creates and appends a new NoBidDescriptors to this group, setting the fields present in row
+func (m NoBidDescriptorsRepeatingGroup) AddRow(row NoBidDescriptorsRow) NoBidDescriptors {
+	g := m.Add()
+	if row.BidDescriptorType != nil {
+		g.SetBidDescriptorType(*row.BidDescriptorType)
+	}
+	if row.BidDescriptor != nil {
+		g.SetBidDescriptor(*row.BidDescriptor)
+	}
+	if row.SideValueInd != nil {
+		g.SetSideValueInd(*row.SideValueInd)
+	}
+	if row.LiquidityValue != nil {
+		g.SetLiquidityValue(row.LiquidityValue.Decimal, row.LiquidityValue.Scale)
+	}
+	if row.LiquidityNumSecurities != nil {
+		g.SetLiquidityNumSecurities(*row.LiquidityNumSecurities)
+	}
+	if row.LiquidityPctLow != nil {
+		g.SetLiquidityPctLow(row.LiquidityPctLow.Decimal, row.LiquidityPctLow.Scale)
+	}
+	if row.LiquidityPctHigh != nil {
+		g.SetLiquidityPctHigh(row.LiquidityPctHigh.Decimal, row.LiquidityPctHigh.Scale)
+	}
+	if row.EFPTrackingError != nil {
+		g.SetEFPTrackingError(row.EFPTrackingError.Decimal, row.EFPTrackingError.Scale)
+	}
+	if row.FairValue != nil {
+		g.SetFairValue(row.FairValue.Decimal, row.FairValue.Scale)
+	}
+	if row.OutsideIndexPct != nil {
+		g.SetOutsideIndexPct(row.OutsideIndexPct.Decimal, row.OutsideIndexPct.Scale)
+	}
+	if row.ValueOfFutures != nil {
+		g.SetValueOfFutures(row.ValueOfFutures.Decimal, row.ValueOfFutures.Scale)
+	}
+	return g
+}
+
 //NoBidComponents is a repeating group element, Tag 420
 type NoBidComponents struct {
 	*quickfix.Group
@@ -1045,3 +1120,69 @@ func (m NoBidComponentsRepeatingGroup) Add() NoBidComponents {
 func (m NoBidComponentsRepeatingGroup) Get(i int) NoBidComponents {
 	return NoBidComponents{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoBidComponents of each entry in the NoBidComponentsRepeatingGroup
+func (m NoBidComponentsRepeatingGroup) All() iter.Seq2[int, NoBidComponents] {
+	return func(yield func(int, NoBidComponents) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoBidComponents entries of the NoBidComponentsRepeatingGroup as a slice
+func (m NoBidComponentsRepeatingGroup) Slice() []NoBidComponents {
+	s := make([]NoBidComponents, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoBidComponentsRow holds the values of a NoBidComponents, nil fields are left unset by AddRow
+type NoBidComponentsRow struct {
+	ListID              *string
+	Side                *enum.Side
+	TradingSessionID    *enum.TradingSessionID
+	TradingSessionSubID *enum.TradingSessionSubID
+	NetGrossInd         *enum.NetGrossInd
+	SettlType           *enum.SettlType
+	SettlDate           *string
+	Account             *string
+	AcctIDSource        *enum.AcctIDSource
+}
+
+//AddRow creates and appends a new NoBidComponents to this group, setting the fields present in row
+func (m NoBidComponentsRepeatingGroup) AddRow(row NoBidComponentsRow) NoBidComponents {
+	g := m.Add()
+	if row.ListID != nil {
+		g.SetListID(*row.ListID)
+	}
+	if row.Side != nil {
+		g.SetSide(*row.Side)
+	}
+	if row.TradingSessionID != nil {
+		g.SetTradingSessionID(*row.TradingSessionID)
+	}
+	if row.TradingSessionSubID != nil {
+		g.SetTradingSessionSubID(*row.TradingSessionSubID)
+	}
+	if row.NetGrossInd != nil {
+		g.SetNetGrossInd(*row.NetGrossInd)
+	}
+	if row.SettlType != nil {
+		g.SetSettlType(*row.SettlType)
+	}
+	if row.SettlDate != nil {
+		g.SetSettlDate(*row.SettlDate)
+	}
+	if row.Account != nil {
+		g.SetAccount(*row.Account)
+	}
+	if row.AcctIDSource != nil {
+		g.SetAcctIDSource(*row.AcctIDSource)
+	}
+	return g
+}
@@ -2,6 +2,7 @@ package bidresponse
 
 import (
 	"github.com/shopspring/decimal"
+	"iter"
 
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
@@ -480,3 +481,105 @@ func (m NoBidComponentsRepeatingGroup) Add() NoBidComponents {
 func (m NoBidComponentsRepeatingGroup) Get(i int) NoBidComponents {
 	return NoBidComponents{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoBidComponents of each entry in the NoBidComponentsRepeatingGroup
+func (m NoBidComponentsRepeatingGroup) All() iter.Seq2[int, NoBidComponents] {
+	return func(yield func(int, NoBidComponents) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoBidComponents entries of the NoBidComponentsRepeatingGroup as a slice
+func (m NoBidComponentsRepeatingGroup) Slice() []NoBidComponents {
+	s := make([]NoBidComponents, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoBidComponentsRow holds the values of a NoBidComponents, nil fields are left unset by AddRow
+type NoBidComponentsRow struct {
+	Commission          *quickfix.FIXDecimal
+	CommType            *enum.CommType
+	CommCurrency        *string
+	FundRenewWaiv       *enum.FundRenewWaiv
+	ListID              *string
+	Country             *string
+	Side                *enum.Side
+	Price               *quickfix.FIXDecimal
+	PriceType           *enum.PriceType
+	FairValue           *quickfix.FIXDecimal
+	NetGrossInd         *enum.NetGrossInd
+	SettlType           *enum.SettlType
+	SettlDate           *string
+	TradingSessionID    *enum.TradingSessionID
+	TradingSessionSubID *enum.TradingSessionSubID
+	Text                *string
+	EncodedTextLen      *int
+	EncodedText         *string
+}
+
+//AddRow creates and appends a new NoBidComponents to this group, setting the fields present in row
+func (m NoBidComponentsRepeatingGroup) AddRow(row NoBidComponentsRow) NoBidComponents {
+	g := m.Add()
+	if row.Commission != nil {
+		g.SetCommission(row.Commission.Decimal, row.Commission.Scale)
+	}
+	if row.CommType != nil {
+		g.SetCommType(*row.CommType)
+	}
+	if row.CommCurrency != nil {
+		g.SetCommCurrency(*row.CommCurrency)
+	}
+	if row.FundRenewWaiv != nil {
+		g.SetFundRenewWaiv(*row.FundRenewWaiv)
+	}
+	if row.ListID != nil {
+		g.SetListID(*row.ListID)
+	}
+	if row.Country != nil {
+		g.SetCountry(*row.Country)
+	}
+	if row.Side != nil {
+		g.SetSide(*row.Side)
+	}
+	if row.Price != nil {
+		g.SetPrice(row.Price.Decimal, row.Price.Scale)
+	}
+	if row.PriceType != nil {
+		g.SetPriceType(*row.PriceType)
+	}
+	if row.FairValue != nil {
+		g.SetFairValue(row.FairValue.Decimal, row.FairValue.Scale)
+	}
+	if row.NetGrossInd != nil {
+		g.SetNetGrossInd(*row.NetGrossInd)
+	}
+	if row.SettlType != nil {
+		g.SetSettlType(*row.SettlType)
+	}
+	if row.SettlDate != nil {
+		g.SetSettlDate(*row.SettlDate)
+	}
+	if row.TradingSessionID != nil {
+		g.SetTradingSessionID(*row.TradingSessionID)
+	}
+	if row.TradingSessionSubID != nil {
+		g.SetTradingSessionSubID(*row.TradingSessionSubID)
+	}
+	if row.Text != nil {
+		g.SetText(*row.Text)
+	}
+	if row.EncodedTextLen != nil {
+		g.SetEncodedTextLen(*row.EncodedTextLen)
+	}
+	if row.EncodedText != nil {
+		g.SetEncodedText(*row.EncodedText)
+	}
+	return g
+}
@@ -0,0 +1,160 @@
+package businessreject
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/businessmessagereject"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// Error is an application error answered with a BusinessMessageReject
+type Error struct {
+	Reason enum.BusinessRejectReason
+	// RefID, if set, is the BusinessRejectRefID, otherwise it is taken from the rejected message
+	RefID string
+	// Tag is the missing field of a conditionally required field missing error
+	Tag  quickfix.Tag
+	Text string
+}
+
+func (e *Error) Error() string {
+	return e.Text
+}
+
+// UnknownID returns the error of a message referring to an unknown ID, e.g. an OrigClOrdID
+func UnknownID(id string) *Error {
+	return &Error{Reason: enum.BusinessRejectReason_UNKNOWN_ID, RefID: id, Text: "unknown ID " + id}
+}
+
+// UnknownSecurity returns the error of a message on an unknown instrument
+func UnknownSecurity(symbol string) *Error {
+	return &Error{Reason: enum.BusinessRejectReason_UNKNOWN_SECURITY, Text: "unknown security " + symbol}
+}
+
+// UnsupportedMessageType returns the error of a message type the application does not handle
+func UnsupportedMessageType(msgType string) *Error {
+	return &Error{Reason: enum.BusinessRejectReason_UNSUPPORTED_MESSAGE_TYPE, Text: "unsupported MsgType " + msgType}
+}
+
+// ApplicationNotAvailable returns the error of a message received while the application is down
+func ApplicationNotAvailable(text string) *Error {
+	return &Error{Reason: enum.BusinessRejectReason_APPLICATION_NOT_AVAILABLE, Text: text}
+}
+
+// MissingField returns the error of a message without a conditionally required field
+func MissingField(t quickfix.Tag) *Error {
+	return &Error{Reason: enum.BusinessRejectReason_CONDITIONALLY_REQUIRED_FIELD_MISSING, Tag: t, Text: fmt.Sprintf("conditionally required field %d missing", t)}
+}
+
+// NotAuthorized returns the error of a message the sender is not allowed to send
+func NotAuthorized(text string) *Error {
+	return &Error{Reason: enum.BusinessRejectReason_NOT_AUTHORIZED, Text: text}
+}
+
+// asError returns the Error wrapped in err, or an Error with reason other and the text of err
+func asError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return &Error{Reason: enum.BusinessRejectReason_OTHER, Text: err.Error()}
+}
+
+// refIDTags are the business IDs of the message types, used as BusinessRejectRefID
+var refIDTags = map[string]quickfix.Tag{
+	"8":  tag.ExecID,
+	"AE": tag.TradeReportID,
+	"S":  tag.QuoteID,
+	"i":  tag.QuoteID,
+	"AJ": tag.QuoteRespID,
+	"V":  tag.MDReqID,
+	"AN": tag.PosReqID,
+	"AL": tag.PosReqID,
+	"J":  tag.AllocID,
+	"AK": tag.ConfirmID,
+	"AY": tag.CollAsgnID,
+	"BB": tag.CollInquiryID,
+	"BE": tag.UserRequestID,
+	"E":  tag.ListID,
+	"s":  tag.CrossID,
+}
+
+// fallbackRefIDTags are looked up in order for the other message types
+var fallbackRefIDTags = []quickfix.Tag{
+	tag.ClOrdID, tag.QuoteReqID, tag.SecurityReqID, tag.TradeRequestID, tag.MassStatusReqID,
+	tag.ListID, tag.CrossID, tag.QuoteID, tag.MDReqID, tag.PosReqID, tag.CollReqID,
+}
+
+// RefID returns the business ID of a message, e.g. the ClOrdID of an order, or "" if it has none
+func RefID(msg *quickfix.Message) string {
+	msgType, _ := msg.MsgType()
+	if t, ok := refIDTags[msgType]; ok {
+		if v, err := msg.Body.GetString(t); err == nil {
+			return v
+		}
+	}
+	for _, t := range fallbackRefIDTags {
+		if v, err := msg.Body.GetString(t); err == nil {
+			return v
+		}
+	}
+	return ""
+}
+
+// Reject returns the BusinessMessageReject of msg for err, with the RefMsgType, RefSeqNum
+// and BusinessRejectRefID of msg. The reason is the one of an Error, other otherwise.
+func Reject(msg *quickfix.Message, err error) businessmessagereject.BusinessMessageReject {
+	e := asError(err)
+	msgType, _ := msg.MsgType()
+	r := businessmessagereject.New(field.NewRefMsgType(msgType), field.NewBusinessRejectReason(e.Reason))
+	if seqNum, err := msg.Header.GetInt(tag.MsgSeqNum); err == nil {
+		r.SetRefSeqNum(seqNum)
+	}
+	refID := e.RefID
+	if refID == "" {
+		refID = RefID(msg)
+	}
+	if refID != "" {
+		r.SetBusinessRejectRefID(refID)
+	}
+	if e.Text != "" {
+		r.SetText(e.Text)
+	}
+	return r
+}
+
+// MessageRejectError returns err as the business reject error a quickfix.MessageRoute returns
+// to have the session send the BusinessMessageReject of msg
+func MessageRejectError(msg *quickfix.Message, err error) quickfix.MessageRejectError {
+	e := asError(err)
+	refID := e.RefID
+	if refID == "" {
+		refID = RefID(msg)
+	}
+	reason, _ := strconv.Atoi(string(e.Reason))
+	var refTag *quickfix.Tag
+	if e.Tag != 0 {
+		refTag = &e.Tag
+	}
+	return quickfix.NewBusinessMessageRejectErrorWithRefID(e.Text, reason, refID, refTag)
+}
+
+// Route returns a quickfix.MessageRoute calling handle and turning its errors
+// into BusinessMessageRejects sent by the session
+func Route(handle func(msg *quickfix.Message, sessionID quickfix.SessionID) error) quickfix.MessageRoute {
+	return func(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+		if err := handle(msg, sessionID); err != nil {
+			var rej quickfix.MessageRejectError
+			if errors.As(err, &rej) {
+				return rej
+			}
+			return MessageRejectError(msg, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+package businessreject
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/newordersingle"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+// newOrder returns a NewOrderSingle with the ClOrdID and MsgSeqNum
+func newOrder(clOrdID string, seqNum int) *quickfix.Message {
+	m := newordersingle.New(field.NewClOrdID(clOrdID), field.NewSide(enum.Side_BUY),
+		field.NewTransactTime(time.Now()), field.NewOrdType(enum.OrdType_MARKET)).ToMessage()
+	m.Header.SetInt(tag.MsgSeqNum, seqNum)
+	return m
+}
+
+func fill(execID string) *quickfix.Message {
+	r := executionreport.New(field.NewOrderID("o1"), field.NewExecID(execID), field.NewExecType(enum.ExecType_TRADE),
+		field.NewOrdStatus(enum.OrdStatus_FILLED), field.NewSide(enum.Side_BUY), field.NewLeavesQty(decimal.Zero, 0),
+		field.NewCumQty(decimal.NewFromInt(100), 0), field.NewAvgPx(decimal.NewFromInt(10), 0))
+	r.SetClOrdID("c1")
+	return r.ToMessage()
+}
+
+func TestRefID(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *quickfix.Message
+		want string
+	}{
+		{"ClOrdID", newOrder("c1", 1), "c1"},
+		{"ExecID before ClOrdID", fill("e1"), "e1"},
+		{"none", quickfix.NewMessage(), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RefID(tt.msg); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReject(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantReason enum.BusinessRejectReason
+		wantRefID  string
+		wantText   string
+	}{
+		{"unknown security", UnknownSecurity("XYZ"), enum.BusinessRejectReason_UNKNOWN_SECURITY, "c1", "unknown security XYZ"},
+		{"unknown ID", UnknownID("c0"), enum.BusinessRejectReason_UNKNOWN_ID, "c0", "unknown ID c0"},
+		{"wrapped", fmt.Errorf("risk: %w", NotAuthorized("over the limit")), enum.BusinessRejectReason_NOT_AUTHORIZED, "c1", "over the limit"},
+		{"other", errors.New("boom"), enum.BusinessRejectReason_OTHER, "c1", "boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Reject(newOrder("c1", 7), tt.err)
+			reason, _ := r.GetBusinessRejectReason()
+			refID, _ := r.GetBusinessRejectRefID()
+			text, _ := r.GetText()
+			refMsgType, _ := r.GetRefMsgType()
+			refSeqNum, _ := r.GetRefSeqNum()
+			if reason != tt.wantReason || refID != tt.wantRefID || text != tt.wantText {
+				t.Errorf("got %s %q %q, want %s %q %q", reason, refID, text, tt.wantReason, tt.wantRefID, tt.wantText)
+			}
+			if refMsgType != "D" || refSeqNum != 7 {
+				t.Errorf("got RefMsgType %s RefSeqNum %d, want D 7", refMsgType, refSeqNum)
+			}
+		})
+	}
+}
+
+func TestRoute(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantReason int
+		wantTag    quickfix.Tag
+	}{
+		{"handled", nil, 0, 0},
+		{"missing field", MissingField(tag.Price), 5, tag.Price},
+		{"unsupported", UnsupportedMessageType("D"), 3, 0},
+		{"session reject", quickfix.RequiredTagMissing(tag.Side), 1, tag.Side},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := Route(func(msg *quickfix.Message, sessionID quickfix.SessionID) error { return tt.err })
+			rej := route(newOrder("c1", 1), quickfix.SessionID{})
+			if tt.err == nil {
+				if rej != nil {
+					t.Errorf("got %v, want nil", rej)
+				}
+				return
+			}
+			if rej == nil || rej.RejectReason() != tt.wantReason {
+				t.Fatalf("got %v, want reason %d", rej, tt.wantReason)
+			}
+			var gotTag quickfix.Tag
+			if rej.RefTagID() != nil {
+				gotTag = *rej.RefTagID()
+			}
+			if gotTag != tt.wantTag {
+				t.Errorf("got RefTagID %d, want %d", gotTag, tt.wantTag)
+			}
+		})
+	}
+}
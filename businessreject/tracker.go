@@ -0,0 +1,165 @@
+package businessreject
+
+import (
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/businessmessagereject"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// Outbound is an application message sent, kept to correlate the BusinessMessageRejects
+type Outbound struct {
+	MsgType string
+	SeqNum  int
+	RefID   string
+	SentAt  time.Time
+	Message *quickfix.Message
+}
+
+// Rejection is a BusinessMessageReject received, with the outbound message it rejects
+type Rejection struct {
+	Reason     enum.BusinessRejectReason
+	RefMsgType string
+	RefSeqNum  int
+	RefID      string
+	Text       string
+	// Original is nil if the rejected message was not recorded or was evicted
+	Original *Outbound
+}
+
+// DefaultCapacity is the number of outbound messages a Tracker keeps by default
+const DefaultCapacity = 10000
+
+// Tracker records the outbound application messages and correlates the
+// BusinessMessageRejects received to them, first by RefSeqNum then by BusinessRejectRefID.
+// A Tracker is safe for concurrent use.
+type Tracker struct {
+	// Capacity is the number of outbound messages kept, the oldest are evicted first
+	Capacity int
+	// OnReject, if set, is called for each BusinessMessageReject received
+	OnReject func(Rejection)
+
+	mu       sync.Mutex
+	order    []*Outbound
+	bySeqNum map[int]*Outbound
+	byRefID  map[string]*Outbound
+}
+
+// NewTracker returns a Tracker keeping DefaultCapacity outbound messages
+func NewTracker() *Tracker {
+	return &Tracker{
+		Capacity: DefaultCapacity,
+		bySeqNum: make(map[int]*Outbound),
+		byRefID:  make(map[string]*Outbound),
+	}
+}
+
+// refKey is the byRefID key, the same ID may be used by messages of different types
+func refKey(msgType, refID string) string {
+	return msgType + "|" + refID
+}
+
+// Record keeps a copy of an outbound message. It is meant to be called from ToApp,
+// once the MsgSeqNum is set.
+func (t *Tracker) Record(msg *quickfix.Message) {
+	msgType, _ := msg.MsgType()
+	seqNum, _ := msg.Header.GetInt(tag.MsgSeqNum)
+	cp := quickfix.NewMessage()
+	msg.CopyInto(cp)
+	o := &Outbound{MsgType: msgType, SeqNum: seqNum, RefID: RefID(msg), SentAt: time.Now(), Message: cp}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.order = append(t.order, o)
+	if seqNum > 0 {
+		t.bySeqNum[seqNum] = o
+	}
+	if o.RefID != "" {
+		t.byRefID[refKey(msgType, o.RefID)] = o
+	}
+	for t.Capacity > 0 && len(t.order) > t.Capacity {
+		t.evict(t.order[0])
+		t.order = t.order[1:]
+	}
+}
+
+// evict must be called with mu held
+func (t *Tracker) evict(o *Outbound) {
+	if t.bySeqNum[o.SeqNum] == o {
+		delete(t.bySeqNum, o.SeqNum)
+	}
+	if k := refKey(o.MsgType, o.RefID); t.byRefID[k] == o {
+		delete(t.byRefID, k)
+	}
+}
+
+// Reset forgets the outbound messages, e.g. when the sequence numbers are reset
+func (t *Tracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.order = nil
+	t.bySeqNum = make(map[int]*Outbound)
+	t.byRefID = make(map[string]*Outbound)
+}
+
+// Process handles BusinessMessageRejects, other messages are ignored.
+// It is meant to be called from FromApp.
+func (t *Tracker) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	if msgType == "j" {
+		_, err := t.OnBusinessMessageReject(businessmessagereject.FromMessage(msg))
+		return err
+	}
+	return nil
+}
+
+// OnBusinessMessageReject returns the rejection with the outbound message it rejects
+func (t *Tracker) OnBusinessMessageReject(m businessmessagereject.BusinessMessageReject) (Rejection, error) {
+	var r Rejection
+	var err error
+	if r.RefMsgType, err = m.GetRefMsgType(); err != nil {
+		return Rejection{}, err
+	}
+	if r.Reason, err = m.GetBusinessRejectReason(); err != nil {
+		return Rejection{}, err
+	}
+	r.RefSeqNum, _ = m.GetRefSeqNum()
+	r.RefID, _ = m.GetBusinessRejectRefID()
+	r.Text, _ = m.GetText()
+
+	t.mu.Lock()
+	r.Original = t.find(r.RefMsgType, r.RefSeqNum, r.RefID)
+	t.mu.Unlock()
+	if t.OnReject != nil {
+		t.OnReject(r)
+	}
+	return r, nil
+}
+
+// find must be called with mu held
+func (t *Tracker) find(msgType string, seqNum int, refID string) *Outbound {
+	if o, ok := t.bySeqNum[seqNum]; ok && o.MsgType == msgType {
+		return o
+	}
+	if refID != "" {
+		return t.byRefID[refKey(msgType, refID)]
+	}
+	return nil
+}
+
+// Find returns the recorded outbound message of the type with the RefID
+func (t *Tracker) Find(msgType, refID string) (Outbound, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o, ok := t.byRefID[refKey(msgType, refID)]
+	if !ok {
+		return Outbound{}, false
+	}
+	return *o, true
+}
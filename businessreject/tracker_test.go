@@ -0,0 +1,72 @@
+package businessreject
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/businessmessagereject"
+	"github.com/quickfixgo/quickfix"
+)
+
+func businessReject(refMsgType string, refSeqNum int, refID string) *quickfix.Message {
+	r := businessmessagereject.New(field.NewRefMsgType(refMsgType), field.NewBusinessRejectReason(enum.BusinessRejectReason_UNKNOWN_SECURITY))
+	if refSeqNum > 0 {
+		r.SetRefSeqNum(refSeqNum)
+	}
+	if refID != "" {
+		r.SetBusinessRejectRefID(refID)
+	}
+	return r.ToMessage()
+}
+
+func TestTracker(t *testing.T) {
+	tests := []struct {
+		name      string
+		capacity  int
+		reject    *quickfix.Message
+		wantRefID string
+	}{
+		{"by RefSeqNum", 10, businessReject("D", 2, ""), "c2"},
+		{"by BusinessRejectRefID", 10, businessReject("D", 0, "c3"), "c3"},
+		{"RefSeqNum of another type", 10, businessReject("F", 2, "c1"), ""},
+		{"evicted", 2, businessReject("D", 1, "c1"), ""},
+		{"unknown", 10, businessReject("D", 9, "c9"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := NewTracker()
+			tr.Capacity = tt.capacity
+			var rejections []Rejection
+			tr.OnReject = func(r Rejection) { rejections = append(rejections, r) }
+			for i, id := range []string{"c1", "c2", "c3"} {
+				tr.Record(newOrder(id, i+1))
+			}
+			if err := tr.Process(tt.reject); err != nil {
+				t.Fatal(err)
+			}
+			if len(rejections) != 1 {
+				t.Fatalf("got %d rejections, want 1", len(rejections))
+			}
+			var got string
+			if o := rejections[0].Original; o != nil {
+				got = o.RefID
+			}
+			if got != tt.wantRefID {
+				t.Errorf("got original %q, want %q", got, tt.wantRefID)
+			}
+		})
+	}
+}
+
+func TestTrackerReset(t *testing.T) {
+	tr := NewTracker()
+	tr.Record(newOrder("c1", 1))
+	if _, ok := tr.Find("D", "c1"); !ok {
+		t.Fatal("recorded order not found")
+	}
+	tr.Reset()
+	if _, ok := tr.Find("D", "c1"); ok {
+		t.Error("got an order recorded before the reset")
+	}
+}
@@ -0,0 +1,161 @@
+package collateral
+
+import (
+	"sort"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/collateralreport"
+	"github.com/quickfixgo/quickfix"
+	"github.com/shopspring/decimal"
+)
+
+// Item is a collateral, a NoUnderlyings entry of the collateral messages
+type Item struct {
+	// Symbol is the UnderlyingSymbol, or the UnderlyingSecurityID if there is no symbol
+	Symbol   string
+	Qty      decimal.Decimal
+	Value    decimal.Decimal
+	Currency string
+}
+
+// underlying is implemented by the NoUnderlyings entries of the collateral messages
+type underlying interface {
+	GetUnderlyingSymbol() (string, quickfix.MessageRejectError)
+	GetUnderlyingSecurityID() (string, quickfix.MessageRejectError)
+	GetUnderlyingQty() (decimal.Decimal, quickfix.MessageRejectError)
+	GetUnderlyingCurrentValue() (decimal.Decimal, quickfix.MessageRejectError)
+	GetUnderlyingCurrency() (string, quickfix.MessageRejectError)
+}
+
+func itemOf(u underlying) Item {
+	var i Item
+	i.Symbol, _ = u.GetUnderlyingSymbol()
+	if i.Symbol == "" {
+		i.Symbol, _ = u.GetUnderlyingSecurityID()
+	}
+	i.Qty, _ = u.GetUnderlyingQty()
+	i.Value, _ = u.GetUnderlyingCurrentValue()
+	i.Currency, _ = u.GetUnderlyingCurrency()
+	return i
+}
+
+// addItem sums i into the item of the same Symbol
+func addItem(items []Item, i Item) []Item {
+	for k := range items {
+		if items[k].Symbol == i.Symbol {
+			items[k].Qty = items[k].Qty.Add(i.Qty)
+			items[k].Value = items[k].Value.Add(i.Value)
+			return items
+		}
+	}
+	return append(items, i)
+}
+
+// Key identifies a collateral balance, Symbol is the instrument of the reports,
+// empty for collateral held at the account level
+type Key struct {
+	Account string
+	Symbol  string
+}
+
+// Balance is the last reported collateral of an account and instrument,
+// its items summed by Symbol
+type Balance struct {
+	Key
+	CollRptID       string
+	CollInquiryID   string
+	Status          enum.CollStatus
+	Currency        string
+	MarginExcess    decimal.Decimal
+	TotalNetValue   decimal.Decimal
+	CashOutstanding decimal.Decimal
+	Items           []Item
+	UpdatedAt       time.Time
+}
+
+// Value returns the sum of the item values
+func (b Balance) Value() decimal.Decimal {
+	v := decimal.Zero
+	for _, i := range b.Items {
+		v = v.Add(i.Value)
+	}
+	return v
+}
+
+func (b *Balance) clone() Balance {
+	c := *b
+	c.Items = append([]Item(nil), b.Items...)
+	return c
+}
+
+// FromReport returns the balance of a CollateralReport
+func FromReport(r collateralreport.CollateralReport) (Balance, error) {
+	var b Balance
+	var err error
+	if b.CollRptID, err = r.GetCollRptID(); err != nil {
+		return b, err
+	}
+	if b.Status, err = r.GetCollStatus(); err != nil {
+		return b, err
+	}
+	b.Account, _ = r.GetAccount()
+	b.Symbol, _ = r.GetSymbol()
+	if b.Symbol == "" {
+		b.Symbol, _ = r.GetSecurityID()
+	}
+	b.CollInquiryID, _ = r.GetCollInquiryID()
+	b.Currency, _ = r.GetCurrency()
+	b.MarginExcess, _ = r.GetMarginExcess()
+	b.TotalNetValue, _ = r.GetTotalNetValue()
+	b.CashOutstanding, _ = r.GetCashOutstanding()
+	if g, err := r.GetNoUnderlyings(); err == nil {
+		for _, e := range g.All() {
+			b.Items = addItem(b.Items, itemOf(e))
+		}
+	}
+	b.UpdatedAt = time.Now()
+	return b, nil
+}
+
+// Balance returns a copy of the collateral balance of an account and instrument
+func (m *Manager) Balance(account string, symbol string) (Balance, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.balances[Key{Account: account, Symbol: symbol}]
+	if !ok {
+		return Balance{}, false
+	}
+	return b.clone(), true
+}
+
+// Balances returns copies of all the collateral balances, sorted by Account then Symbol
+func (m *Manager) Balances() []Balance {
+	m.mu.Lock()
+	balances := make([]Balance, 0, len(m.balances))
+	for _, b := range m.balances {
+		balances = append(balances, b.clone())
+	}
+	m.mu.Unlock()
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Account != balances[j].Account {
+			return balances[i].Account < balances[j].Account
+		}
+		return balances[i].Symbol < balances[j].Symbol
+	})
+	return balances
+}
+
+// Account returns the collateral of an account over all its instruments, summed by item Symbol
+func (m *Manager) Account(account string) []Item {
+	var items []Item
+	for _, b := range m.Balances() {
+		if b.Account != account {
+			continue
+		}
+		for _, i := range b.Items {
+			items = addItem(items, i)
+		}
+	}
+	return items
+}
@@ -0,0 +1,591 @@
+package collateral
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/collateralassignment"
+	"github.com/quickfixgo/fix44/collateralinquiry"
+	"github.com/quickfixgo/fix44/collateralinquiryack"
+	"github.com/quickfixgo/fix44/collateralreport"
+	"github.com/quickfixgo/fix44/collateralrequest"
+	"github.com/quickfixgo/fix44/collateralresponse"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrUnknownCollAsgnID is returned for a CollAsgnID not sent by the Manager
+	ErrUnknownCollAsgnID = errors.New("collateral: unknown CollAsgnID")
+	// ErrNotActive is returned when replacing or canceling an assignment that was
+	// declined, rejected, replaced or canceled
+	ErrNotActive = errors.New("collateral: assignment is not active")
+)
+
+// InquiryError is a CollateralInquiryAck rejecting an inquiry
+type InquiryError struct {
+	CollInquiryID string
+	Status        enum.CollInquiryStatus
+	Result        enum.CollInquiryResult
+	Text          string
+}
+
+func (e *InquiryError) Error() string {
+	s := fmt.Sprintf("collateral: inquiry %s rejected with CollInquiryStatus %s", e.CollInquiryID, e.Status)
+	if e.Result != "" {
+		s += fmt.Sprintf(", CollInquiryResult %s", e.Result)
+	}
+	if e.Text != "" {
+		s += ": " + e.Text
+	}
+	return s
+}
+
+// State is the state of a collateral assignment in the Manager
+type State int
+
+const (
+	// Pending assignments were sent without response yet
+	Pending State = iota
+	// Received assignments were acknowledged, not yet accepted
+	Received
+	Accepted
+	Declined
+	Rejected
+	// Replaced and Canceled assignments were superseded by an accepted replace or cancel
+	Replaced
+	Canceled
+)
+
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Received:
+		return "received"
+	case Accepted:
+		return "accepted"
+	case Declined:
+		return "declined"
+	case Rejected:
+		return "rejected"
+	case Replaced:
+		return "replaced"
+	case Canceled:
+		return "canceled"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Request is a CollateralRequest received from the counterparty, e.g. a margin call
+type Request struct {
+	CollReqID       string
+	Reason          enum.CollAsgnReason
+	Account         string
+	Symbol          string
+	Currency        string
+	MarginExcess    decimal.Decimal
+	TotalNetValue   decimal.Decimal
+	CashOutstanding decimal.Decimal
+	Items           []Item
+	ExpireTime      time.Time
+	Text            string
+	ReceivedAt      time.Time
+}
+
+// Assignment is a version of a CollateralAssignment sent by the Manager
+type Assignment struct {
+	CollAsgnID string
+	// RefCollAsgnID is the CollAsgnID replaced or canceled by this version
+	RefCollAsgnID string
+	// CollReqID is the answered CollateralRequest, empty for unsolicited assignments
+	CollReqID string
+	TransType enum.CollAsgnTransType
+	Reason    enum.CollAsgnReason
+	Account   string
+	Symbol    string
+	Items     []Item
+
+	State        State
+	CollRespID   string
+	RespType     enum.CollAsgnRespType
+	RejectReason enum.CollAsgnRejectReason
+	// CollStatus is the status of the last CollateralReport of the account and instrument
+	// once the assignment is accepted
+	CollStatus enum.CollStatus
+	Text       string
+	UpdatedAt  time.Time
+}
+
+// Key returns the balance key of the account and instrument of an assignment
+func (a Assignment) Key() Key {
+	return Key{Account: a.Account, Symbol: a.Symbol}
+}
+
+func (a *Assignment) clone() Assignment {
+	c := *a
+	c.Items = append([]Item(nil), a.Items...)
+	return c
+}
+
+// InquiryResult is the answer to a CollateralInquiry
+type InquiryResult struct {
+	CollInquiryID string
+	Balances      []Balance
+	// Err is an *InquiryError if the inquiry was rejected
+	Err error
+}
+
+type inquiry struct {
+	balances []Balance
+	// total is the TotNumReports announced by the counterparty, -1 if unknown
+	total int
+	done  func(InquiryResult)
+}
+
+// Manager sends CollateralAssignments answering the CollateralRequests, tracks their
+// CollateralResponses, and keeps the collateral balances reported by CollateralReports,
+// which can be asked with CollateralInquiries.
+// A Manager is safe for concurrent use.
+type Manager struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// OnRequest, if set, is called with each CollateralRequest
+	OnRequest func(Request)
+	// OnAssignment, if set, is called with a copy of an assignment each time it changes
+	OnAssignment func(Assignment)
+	// OnBalance, if set, is called with each reported balance.
+	// The callbacks are called once the Manager is unlocked, so they can call the Manager.
+	OnBalance func(Balance)
+
+	mu          sync.Mutex
+	nextID      int
+	idPrefix    string
+	requests    map[string]*Request
+	assignments map[string]*Assignment
+	balances    map[Key]*Balance
+	inquiries   map[string]*inquiry
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns a Manager for the session
+func New(sessionID quickfix.SessionID) *Manager {
+	return &Manager{
+		SessionID:   sessionID,
+		Send:        quickfix.SendToTarget,
+		idPrefix:    time.Now().Format("150405") + "-",
+		requests:    make(map[string]*Request),
+		assignments: make(map[string]*Assignment),
+		balances:    make(map[Key]*Balance),
+		inquiries:   make(map[string]*inquiry),
+	}
+}
+
+// newID must be called with mu held
+func (m *Manager) newID() string {
+	m.nextID++
+	return fmt.Sprintf("%s%d", m.idPrefix, m.nextID)
+}
+
+// Assign sends a new CollateralAssignment of the items to the account and instrument of a,
+// answering the CollateralRequest a.CollReqID if not empty. It returns the CollAsgnID.
+func (m *Manager) Assign(a Assignment) (string, error) {
+	m.mu.Lock()
+	defer m.unlock()
+	if r, ok := m.requests[a.CollReqID]; ok && a.Reason == "" {
+		a.Reason = r.Reason
+	}
+	return m.send(&Assignment{CollReqID: a.CollReqID, TransType: enum.CollAsgnTransType_NEW, Reason: a.Reason,
+		Account: a.Account, Symbol: a.Symbol, Items: append([]Item(nil), a.Items...)})
+}
+
+// Replace sends a new version of an assignment with other items, it returns the new CollAsgnID
+func (m *Manager) Replace(collAsgnID string, items []Item) (string, error) {
+	m.mu.Lock()
+	defer m.unlock()
+	old, err := m.active(collAsgnID)
+	if err != nil {
+		return "", err
+	}
+	return m.send(&Assignment{RefCollAsgnID: collAsgnID, CollReqID: old.CollReqID, TransType: enum.CollAsgnTransType_REPLACE,
+		Reason: old.Reason, Account: old.Account, Symbol: old.Symbol, Items: append([]Item(nil), items...)})
+}
+
+// Cancel asks to cancel an assignment, it returns the CollAsgnID of the cancel
+func (m *Manager) Cancel(collAsgnID string) (string, error) {
+	m.mu.Lock()
+	defer m.unlock()
+	old, err := m.active(collAsgnID)
+	if err != nil {
+		return "", err
+	}
+	return m.send(&Assignment{RefCollAsgnID: collAsgnID, CollReqID: old.CollReqID, TransType: enum.CollAsgnTransType_CANCEL,
+		Reason: old.Reason, Account: old.Account, Symbol: old.Symbol, Items: old.Items})
+}
+
+// active must be called with mu held
+func (m *Manager) active(collAsgnID string) (*Assignment, error) {
+	a, ok := m.assignments[collAsgnID]
+	if !ok {
+		return nil, ErrUnknownCollAsgnID
+	}
+	if a.TransType == enum.CollAsgnTransType_CANCEL {
+		return nil, ErrNotActive
+	}
+	switch a.State {
+	case Declined, Rejected, Replaced, Canceled:
+		return nil, ErrNotActive
+	}
+	return a, nil
+}
+
+// send must be called with mu held
+func (m *Manager) send(a *Assignment) (string, error) {
+	a.CollAsgnID = m.newID()
+	a.UpdatedAt = time.Now()
+	msg := collateralassignment.New(field.NewCollAsgnID(a.CollAsgnID), field.NewCollAsgnReason(a.Reason),
+		field.NewCollAsgnTransType(a.TransType), field.NewTransactTime(a.UpdatedAt))
+	if a.CollReqID != "" {
+		msg.SetCollReqID(a.CollReqID)
+	}
+	if a.RefCollAsgnID != "" {
+		msg.SetCollAsgnRefID(a.RefCollAsgnID)
+	}
+	if a.Account != "" {
+		msg.SetAccount(a.Account)
+	}
+	if a.Symbol != "" {
+		msg.SetSymbol(a.Symbol)
+	}
+	if len(a.Items) > 0 {
+		underlyings := collateralassignment.NewNoUnderlyingsRepeatingGroup()
+		for _, i := range a.Items {
+			e := underlyings.Add()
+			e.SetUnderlyingSymbol(i.Symbol)
+			if i.Currency != "" {
+				e.SetUnderlyingCurrency(i.Currency)
+			}
+			e.SetUnderlyingQty(i.Qty, scale(i.Qty))
+			if !i.Value.IsZero() {
+				e.SetUnderlyingCurrentValue(i.Value, scale(i.Value))
+			}
+		}
+		msg.SetNoUnderlyings(underlyings)
+	}
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+	m.assignments[a.CollAsgnID] = a
+	m.changed(a)
+	return a.CollAsgnID, nil
+}
+
+// Inquire sends a CollateralInquiry for the balances of an account and instrument, both optional.
+// done is called once all the reports are received or the inquiry is rejected.
+// It returns the CollInquiryID.
+func (m *Manager) Inquire(account string, symbol string, done func(InquiryResult)) (string, error) {
+	m.mu.Lock()
+	id := m.newID()
+	m.inquiries[id] = &inquiry{total: -1, done: done}
+	m.mu.Unlock()
+
+	msg := collateralinquiry.New()
+	msg.SetCollInquiryID(id)
+	msg.SetSubscriptionRequestType(enum.SubscriptionRequestType_SNAPSHOT)
+	if account != "" {
+		msg.SetAccount(account)
+	}
+	if symbol != "" {
+		msg.SetSymbol(symbol)
+	}
+	if err := m.Send(msg, m.SessionID); err != nil {
+		m.mu.Lock()
+		delete(m.inquiries, id)
+		m.mu.Unlock()
+		return "", err
+	}
+	return id, nil
+}
+
+// Process handles a CollateralRequest, a CollateralResponse, a CollateralReport or
+// a CollateralInquiryAck, other messages are ignored. It is meant to be called from FromApp.
+func (m *Manager) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "AX":
+		return m.OnCollateralRequest(collateralrequest.FromMessage(msg))
+	case "AZ":
+		return m.OnCollateralResponse(collateralresponse.FromMessage(msg))
+	case "BA":
+		return m.OnCollateralReport(collateralreport.FromMessage(msg))
+	case "BG":
+		return m.OnCollateralInquiryAck(collateralinquiryack.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnCollateralRequest records a CollateralRequest to be answered with Assign
+func (m *Manager) OnCollateralRequest(msg collateralrequest.CollateralRequest) error {
+	var r Request
+	var err error
+	if r.CollReqID, err = msg.GetCollReqID(); err != nil {
+		return err
+	}
+	if r.Reason, err = msg.GetCollAsgnReason(); err != nil {
+		return err
+	}
+	r.Account, _ = msg.GetAccount()
+	r.Symbol, _ = msg.GetSymbol()
+	if r.Symbol == "" {
+		r.Symbol, _ = msg.GetSecurityID()
+	}
+	r.Currency, _ = msg.GetCurrency()
+	r.MarginExcess, _ = msg.GetMarginExcess()
+	r.TotalNetValue, _ = msg.GetTotalNetValue()
+	r.CashOutstanding, _ = msg.GetCashOutstanding()
+	r.ExpireTime, _ = msg.GetExpireTime()
+	r.Text, _ = msg.GetText()
+	if g, err := msg.GetNoUnderlyings(); err == nil {
+		for _, e := range g.All() {
+			r.Items = addItem(r.Items, itemOf(e))
+		}
+	}
+	r.ReceivedAt = time.Now()
+
+	m.mu.Lock()
+	m.requests[r.CollReqID] = &r
+	m.mu.Unlock()
+	if m.OnRequest != nil {
+		m.OnRequest(r)
+	}
+	return nil
+}
+
+// Requests returns the CollateralRequests without accepted assignment, sorted by CollReqID
+func (m *Manager) Requests() []Request {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	answered := make(map[string]bool)
+	for _, a := range m.assignments {
+		if a.State == Accepted && a.TransType != enum.CollAsgnTransType_CANCEL {
+			answered[a.CollReqID] = true
+		}
+	}
+	var requests []Request
+	for id, r := range m.requests {
+		if !answered[id] {
+			c := *r
+			c.Items = append([]Item(nil), r.Items...)
+			requests = append(requests, c)
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].CollReqID < requests[j].CollReqID })
+	return requests
+}
+
+// OnCollateralResponse applies the CollAsgnRespType of a response to its assignment
+func (m *Manager) OnCollateralResponse(r collateralresponse.CollateralResponse) error {
+	collAsgnID, err := r.GetCollAsgnID()
+	if err != nil {
+		return err
+	}
+	respType, err := r.GetCollAsgnRespType()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.unlock()
+	a, ok := m.assignments[collAsgnID]
+	if !ok {
+		return ErrUnknownCollAsgnID
+	}
+	a.CollRespID, _ = r.GetCollRespID()
+	a.RespType = respType
+	a.RejectReason, _ = r.GetCollAsgnRejectReason()
+	a.Text, _ = r.GetText()
+	a.UpdatedAt = time.Now()
+	switch respType {
+	case enum.CollAsgnRespType_RECEIVED:
+		if a.State == Pending {
+			a.State = Received
+		}
+	case enum.CollAsgnRespType_ACCEPTED:
+		a.State = Accepted
+		if ref, ok := m.assignments[a.RefCollAsgnID]; ok {
+			if a.TransType == enum.CollAsgnTransType_CANCEL {
+				ref.State = Canceled
+			} else {
+				ref.State = Replaced
+			}
+			ref.UpdatedAt = a.UpdatedAt
+			m.changed(ref)
+		}
+	case enum.CollAsgnRespType_DECLINED:
+		a.State = Declined
+	case enum.CollAsgnRespType_REJECTED:
+		a.State = Rejected
+	}
+	m.changed(a)
+	return nil
+}
+
+// OnCollateralReport replaces the balance of the account and instrument of a report,
+// and adds it to its inquiry. A CollateralInquiry is complete on its report with
+// LastRptRequested or when TotNumReports reports are received.
+func (m *Manager) OnCollateralReport(r collateralreport.CollateralReport) error {
+	b, err := FromReport(r)
+	if err != nil {
+		return err
+	}
+	last, _ := r.GetLastRptRequested()
+	total, totalErr := r.GetTotNumReports()
+
+	m.mu.Lock()
+	c := b.clone()
+	m.balances[b.Key] = &c
+	for _, a := range m.assignments {
+		if a.State == Accepted && a.TransType != enum.CollAsgnTransType_CANCEL && a.Key() == b.Key {
+			a.CollStatus = b.Status
+			a.UpdatedAt = b.UpdatedAt
+			m.changed(a)
+		}
+	}
+	var res *InquiryResult
+	var done func(InquiryResult)
+	if inq, ok := m.inquiries[b.CollInquiryID]; ok {
+		inq.balances = append(inq.balances, b)
+		if totalErr == nil {
+			inq.total = total
+		}
+		if last || (inq.total >= 0 && len(inq.balances) >= inq.total) {
+			delete(m.inquiries, b.CollInquiryID)
+			res = &InquiryResult{CollInquiryID: b.CollInquiryID, Balances: inq.balances}
+			done = inq.done
+		}
+	}
+	m.unlock()
+	if m.OnBalance != nil {
+		m.OnBalance(b)
+	}
+	if res != nil && done != nil {
+		done(*res)
+	}
+	return nil
+}
+
+// OnCollateralInquiryAck records the number of reports announced for an inquiry,
+// or ends it when it is rejected or answered with no report
+func (m *Manager) OnCollateralInquiryAck(a collateralinquiryack.CollateralInquiryAck) error {
+	id, err := a.GetCollInquiryID()
+	if err != nil {
+		return err
+	}
+	status, err := a.GetCollInquiryStatus()
+	if err != nil {
+		return err
+	}
+	result, _ := a.GetCollInquiryResult()
+	total, totalErr := a.GetTotNumReports()
+
+	m.mu.Lock()
+	inq, ok := m.inquiries[id]
+	if !ok {
+		m.mu.Unlock()
+		return nil
+	}
+	var res *InquiryResult
+	switch {
+	case status == enum.CollInquiryStatus_REJECTED || (result != "" && result != enum.CollInquiryResult_SUCCESSFUL):
+		inqErr := &InquiryError{CollInquiryID: id, Status: status, Result: result}
+		inqErr.Text, _ = a.GetText()
+		res = &InquiryResult{CollInquiryID: id, Balances: inq.balances, Err: inqErr}
+	case totalErr == nil:
+		inq.total = total
+		if len(inq.balances) >= total {
+			res = &InquiryResult{CollInquiryID: id, Balances: inq.balances}
+		}
+	}
+	if res != nil {
+		delete(m.inquiries, id)
+	}
+	m.mu.Unlock()
+	if res != nil && inq.done != nil {
+		inq.done(*res)
+	}
+	return nil
+}
+
+// Pending returns the CollInquiryIDs still waiting for reports, sorted
+func (m *Manager) Pending() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.inquiries))
+	for id := range m.inquiries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// changed queues the call of OnAssignment with a copy of a, it must be called with mu held
+func (m *Manager) changed(a *Assignment) {
+	if m.OnAssignment != nil {
+		c := a.clone()
+		m.callbacks = append(m.callbacks, func() { m.OnAssignment(c) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (m *Manager) unlock() {
+	callbacks := m.callbacks
+	m.callbacks = nil
+	m.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// Assignment returns a copy of the assignment with the CollAsgnID
+func (m *Manager) Assignment(collAsgnID string) (Assignment, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.assignments[collAsgnID]
+	if !ok {
+		return Assignment{}, false
+	}
+	return a.clone(), true
+}
+
+// Assignments returns copies of all the assignments, sorted by CollAsgnID
+func (m *Manager) Assignments() []Assignment {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	as := make([]Assignment, 0, len(m.assignments))
+	for _, a := range m.assignments {
+		as = append(as, a.clone())
+	}
+	sort.Slice(as, func(i, j int) bool { return as[i].CollAsgnID < as[j].CollAsgnID })
+	return as
+}
+
+// scale returns the number of decimal places of v without its trailing zeros,
+// so that a quantity or a value is sent unrounded
+func scale(v decimal.Decimal) int32 {
+	var s int32
+	for !v.Truncate(s).Equal(v) {
+		s++
+	}
+	return s
+}
@@ -0,0 +1,192 @@
+package collateral
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/collateralassignment"
+	"github.com/quickfixgo/fix44/collateralinquiryack"
+	"github.com/quickfixgo/fix44/collateralreport"
+	"github.com/quickfixgo/fix44/collateralresponse"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var session = quickfix.SessionID{SenderCompID: "FIRM", TargetCompID: "CCP"}
+
+// custodian returns a Manager whose sends are appended to sent
+func custodian(sent *[]*quickfix.Message) *Manager {
+	m := New(session)
+	m.idPrefix = "c"
+	m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+		*sent = append(*sent, msg.ToMessage())
+		return nil
+	}
+	return m
+}
+
+func response(collAsgnID string, respType enum.CollAsgnRespType) *quickfix.Message {
+	return collateralresponse.New(field.NewCollRespID("r-"+collAsgnID), field.NewCollAsgnID(collAsgnID),
+		field.NewCollAsgnReason(enum.CollAsgnReason_INITIAL), field.NewCollAsgnRespType(respType),
+		field.NewTransactTime(time.Now())).ToMessage()
+}
+
+func report(collInquiryID string, account string, last bool, values ...string) *quickfix.Message {
+	r := collateralreport.New(field.NewCollRptID("rpt-"+account), field.NewCollStatus(enum.CollStatus_ASSIGNED))
+	r.SetAccount(account)
+	if collInquiryID != "" {
+		r.SetCollInquiryID(collInquiryID)
+	}
+	if last {
+		r.SetLastRptRequested(true)
+	}
+	g := collateralreport.NewNoUnderlyingsRepeatingGroup()
+	for _, v := range values {
+		e := g.Add()
+		e.SetUnderlyingSymbol("VND")
+		e.SetUnderlyingCurrentValue(decimal.RequireFromString(v), 2)
+	}
+	r.SetNoUnderlyings(g)
+	return r.ToMessage()
+}
+
+func TestAssignScales(t *testing.T) {
+	var sent []*quickfix.Message
+	m := custodian(&sent)
+	items := []Item{
+		{Symbol: "VND", Qty: decimal.RequireFromString("1500"), Value: decimal.RequireFromString("27450000")},
+		{Symbol: "BOND1", Qty: decimal.RequireFromString("12.5"), Value: decimal.RequireFromString("1250.125")},
+	}
+	if _, err := m.Assign(Assignment{Account: "ACC1", Reason: enum.CollAsgnReason_INITIAL, Items: items}); err != nil {
+		t.Fatal(err)
+	}
+	g, err := collateralassignment.FromMessage(sent[0]).GetNoUnderlyings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]string{{"1500", "27450000"}, {"12.5", "1250.125"}}
+	for i, w := range want {
+		qty, _ := g.Get(i).GetString(tag.UnderlyingQty)
+		value, _ := g.Get(i).GetString(tag.UnderlyingCurrentValue)
+		if qty != w[0] || value != w[1] {
+			t.Errorf("item %d: got %s %s, want %s %s", i, qty, value, w[0], w[1])
+		}
+	}
+}
+
+func TestResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace bool
+		resp    []enum.CollAsgnRespType
+		want    State
+		wantOld State
+	}{
+		{"received", false, []enum.CollAsgnRespType{enum.CollAsgnRespType_RECEIVED}, Received, Received},
+		{"accepted", false, []enum.CollAsgnRespType{enum.CollAsgnRespType_RECEIVED, enum.CollAsgnRespType_ACCEPTED}, Accepted, Accepted},
+		{"declined", false, []enum.CollAsgnRespType{enum.CollAsgnRespType_DECLINED}, Declined, Declined},
+		{"replace accepted", true, []enum.CollAsgnRespType{enum.CollAsgnRespType_ACCEPTED}, Accepted, Replaced},
+		{"replace rejected", true, []enum.CollAsgnRespType{enum.CollAsgnRespType_REJECTED}, Rejected, Accepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []*quickfix.Message
+			m := custodian(&sent)
+			item := Item{Symbol: "VND", Qty: decimal.NewFromInt(100)}
+			old, err := m.Assign(Assignment{Account: "ACC1", Reason: enum.CollAsgnReason_INITIAL, Items: []Item{item}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			id := old
+			if tt.replace {
+				if err := m.Process(response(old, enum.CollAsgnRespType_ACCEPTED)); err != nil {
+					t.Fatal(err)
+				}
+				if id, err = m.Replace(old, []Item{item, item}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, r := range tt.resp {
+				if err := m.Process(response(id, r)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if a, _ := m.Assignment(id); a.State != tt.want {
+				t.Errorf("got %v, want %v", a.State, tt.want)
+			}
+			if a, _ := m.Assignment(old); a.State != tt.wantOld {
+				t.Errorf("got %v for the replaced assignment, want %v", a.State, tt.wantOld)
+			}
+		})
+	}
+}
+
+func TestReplaceInactive(t *testing.T) {
+	var sent []*quickfix.Message
+	m := custodian(&sent)
+	if _, err := m.Cancel("unknown"); err != ErrUnknownCollAsgnID {
+		t.Errorf("got %v, want %v", err, ErrUnknownCollAsgnID)
+	}
+	id, _ := m.Assign(Assignment{Account: "ACC1", Reason: enum.CollAsgnReason_INITIAL})
+	if err := m.Process(response(id, enum.CollAsgnRespType_DECLINED)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Replace(id, nil); err != ErrNotActive {
+		t.Errorf("got %v, want %v", err, ErrNotActive)
+	}
+}
+
+func TestInquire(t *testing.T) {
+	var sent []*quickfix.Message
+	m := custodian(&sent)
+	var results []InquiryResult
+	id, err := m.Inquire("", "", func(r InquiryResult) { results = append(results, r) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Process(report(id, "ACC1", false, "100.5", "200")); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("got %d results before the last report", len(results))
+	}
+	if err := m.Process(report(id, "ACC2", true, "10")); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Err != nil || len(results[0].Balances) != 2 {
+		t.Fatalf("got %+v, want one result with two balances", results)
+	}
+	b, ok := m.Balance("ACC1", "")
+	if !ok || !b.Value().Equal(decimal.RequireFromString("300.5")) {
+		t.Errorf("got %v %v, want a value of 300.5", b.Value(), ok)
+	}
+	var accounts []string
+	for _, b := range m.Balances() {
+		accounts = append(accounts, b.Account)
+	}
+	if want := []string{"ACC1", "ACC2"}; !reflect.DeepEqual(accounts, want) {
+		t.Errorf("got %v, want %v", accounts, want)
+	}
+}
+
+func TestInquiryRejected(t *testing.T) {
+	var sent []*quickfix.Message
+	m := custodian(&sent)
+	var results []InquiryResult
+	id, _ := m.Inquire("ACC1", "", func(r InquiryResult) { results = append(results, r) })
+	ack := collateralinquiryack.New(field.NewCollInquiryID(id), field.NewCollInquiryStatus(enum.CollInquiryStatus_REJECTED))
+	ack.SetText("unknown account")
+	if err := m.Process(ack.ToMessage()); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if e, ok := results[0].Err.(*InquiryError); !ok || e.Text != "unknown account" {
+		t.Errorf("got %v, want an InquiryError", results[0].Err)
+	}
+}
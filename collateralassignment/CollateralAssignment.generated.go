@@ -2,6 +2,7 @@ package collateralassignment
 
 import (
 	"github.com/shopspring/decimal"
+	"iter"
 	"time"
 
 	"github.com/quickfixgo/enum"
@@ -2277,6 +2278,44 @@ func (m NoSettlPartySubIDsRepeatingGroup) Get(i int) NoSettlPartySubIDs {
 	return NoSettlPartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoSettlPartySubIDs of each entry in the NoSettlPartySubIDsRepeatingGroup
+func (m NoSettlPartySubIDsRepeatingGroup) All() iter.Seq2[int, NoSettlPartySubIDs] {
+	return func(yield func(int, NoSettlPartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoSettlPartySubIDs entries of the NoSettlPartySubIDsRepeatingGroup as a slice
+func (m NoSettlPartySubIDsRepeatingGroup) Slice() []NoSettlPartySubIDs {
+	s := make([]NoSettlPartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoSettlPartySubIDsRow holds the values of a NoSettlPartySubIDs, nil fields are left unset by AddRow
+type NoSettlPartySubIDsRow struct {
+	SettlPartySubID     *string
+	SettlPartySubIDType *int
+}
+
+//AddRow creates and appends a new NoSettlPartySubIDs to this group, setting the fields present in row
+func (m NoSettlPartySubIDsRepeatingGroup) AddRow(row NoSettlPartySubIDsRow) NoSettlPartySubIDs {
+	g := m.Add()
+	if row.SettlPartySubID != nil {
+		g.SetSettlPartySubID(*row.SettlPartySubID)
+	}
+	if row.SettlPartySubIDType != nil {
+		g.SetSettlPartySubIDType(*row.SettlPartySubIDType)
+	}
+	return g
+}
+
 //NoSettlPartyIDsRepeatingGroup is a repeating group, Tag 781
 type NoSettlPartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -2300,6 +2339,56 @@ func (m NoSettlPartyIDsRepeatingGroup) Get(i int) NoSettlPartyIDs {
 	return NoSettlPartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoSettlPartyIDs of each entry in the NoSettlPartyIDsRepeatingGroup
+func (m NoSettlPartyIDsRepeatingGroup) All() iter.Seq2[int, NoSettlPartyIDs] {
+	return func(yield func(int, NoSettlPartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoSettlPartyIDs entries of the NoSettlPartyIDsRepeatingGroup as a slice
+func (m NoSettlPartyIDsRepeatingGroup) Slice() []NoSettlPartyIDs {
+	s := make([]NoSettlPartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoSettlPartyIDsRow holds the values of a NoSettlPartyIDs, nil fields are left unset by AddRow
+type NoSettlPartyIDsRow struct {
+	SettlPartyID       *string
+	SettlPartyIDSource *string
+	SettlPartyRole     *int
+	NoSettlPartySubIDs []NoSettlPartySubIDsRow
+}
+
+//AddRow creates and appends a new NoSettlPartyIDs to this group, setting the fields present in row
+func (m NoSettlPartyIDsRepeatingGroup) AddRow(row NoSettlPartyIDsRow) NoSettlPartyIDs {
+	g := m.Add()
+	if row.SettlPartyID != nil {
+		g.SetSettlPartyID(*row.SettlPartyID)
+	}
+	if row.SettlPartyIDSource != nil {
+		g.SetSettlPartyIDSource(*row.SettlPartyIDSource)
+	}
+	if row.SettlPartyRole != nil {
+		g.SetSettlPartyRole(*row.SettlPartyRole)
+	}
+	if len(row.NoSettlPartySubIDs) > 0 {
+		f := NewNoSettlPartySubIDsRepeatingGroup()
+		for _, r := range row.NoSettlPartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoSettlPartySubIDs(f)
+	}
+	return g
+}
+
 //NoDlvyInstRepeatingGroup is a repeating group, Tag 85
 type NoDlvyInstRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -2323,6 +2412,52 @@ func (m NoDlvyInstRepeatingGroup) Get(i int) NoDlvyInst {
 	return NoDlvyInst{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoDlvyInst of each entry in the NoDlvyInstRepeatingGroup
+func (m NoDlvyInstRepeatingGroup) All() iter.Seq2[int, NoDlvyInst] {
+	return func(yield func(int, NoDlvyInst) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoDlvyInst entries of the NoDlvyInstRepeatingGroup as a slice
+func (m NoDlvyInstRepeatingGroup) Slice() []NoDlvyInst {
+	s := make([]NoDlvyInst, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoDlvyInstRow holds the values of a NoDlvyInst, nil fields are left unset by AddRow
+type NoDlvyInstRow struct {
+	SettlInstSource *enum.SettlInstSource
+	DlvyInstType    *enum.DlvyInstType
+	NoSettlPartyIDs []NoSettlPartyIDsRow
+}
+
+//AddRow creates and appends a new NoDlvyInst to this group, setting the fields present in row
+func (m NoDlvyInstRepeatingGroup) AddRow(row NoDlvyInstRow) NoDlvyInst {
+	g := m.Add()
+	if row.SettlInstSource != nil {
+		g.SetSettlInstSource(*row.SettlInstSource)
+	}
+	if row.DlvyInstType != nil {
+		g.SetDlvyInstType(*row.DlvyInstType)
+	}
+	if len(row.NoSettlPartyIDs) > 0 {
+		f := NewNoSettlPartyIDsRepeatingGroup()
+		for _, r := range row.NoSettlPartyIDs {
+			f.AddRow(r)
+		}
+		g.SetNoSettlPartyIDs(f)
+	}
+	return g
+}
+
 //NoExecs is a repeating group element, Tag 124
 type NoExecs struct {
 	*quickfix.Group
@@ -2370,6 +2505,40 @@ func (m NoExecsRepeatingGroup) Get(i int) NoExecs {
 	return NoExecs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoExecs of each entry in the NoExecsRepeatingGroup
+func (m NoExecsRepeatingGroup) All() iter.Seq2[int, NoExecs] {
+	return func(yield func(int, NoExecs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoExecs entries of the NoExecsRepeatingGroup as a slice
+func (m NoExecsRepeatingGroup) Slice() []NoExecs {
+	s := make([]NoExecs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoExecsRow holds the values of a NoExecs, nil fields are left unset by AddRow
+type NoExecsRow struct {
+	ExecID *string
+}
+
+//AddRow creates and appends a new NoExecs to this group, setting the fields present in row
+func (m NoExecsRepeatingGroup) AddRow(row NoExecsRow) NoExecs {
+	g := m.Add()
+	if row.ExecID != nil {
+		g.SetExecID(*row.ExecID)
+	}
+	return g
+}
+
 //NoMiscFees is a repeating group element, Tag 136
 type NoMiscFees struct {
 	*quickfix.Group
@@ -2474,6 +2643,52 @@ func (m NoMiscFeesRepeatingGroup) Get(i int) NoMiscFees {
 	return NoMiscFees{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoMiscFees of each entry in the NoMiscFeesRepeatingGroup
+func (m NoMiscFeesRepeatingGroup) All() iter.Seq2[int, NoMiscFees] {
+	return func(yield func(int, NoMiscFees) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoMiscFees entries of the NoMiscFeesRepeatingGroup as a slice
+func (m NoMiscFeesRepeatingGroup) Slice() []NoMiscFees {
+	s := make([]NoMiscFees, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoMiscFeesRow holds the values of a NoMiscFees, nil fields are left unset by AddRow
+type NoMiscFeesRow struct {
+	MiscFeeAmt   *quickfix.FIXDecimal
+	MiscFeeCurr  *string
+	MiscFeeType  *enum.MiscFeeType
+	MiscFeeBasis *enum.MiscFeeBasis
+}
+
+//AddRow creates and appends a new NoMiscFees to this group, setting the fields present in row
+func (m NoMiscFeesRepeatingGroup) AddRow(row NoMiscFeesRow) NoMiscFees {
+	g := m.Add()
+	if row.MiscFeeAmt != nil {
+		g.SetMiscFeeAmt(row.MiscFeeAmt.Decimal, row.MiscFeeAmt.Scale)
+	}
+	if row.MiscFeeCurr != nil {
+		g.SetMiscFeeCurr(*row.MiscFeeCurr)
+	}
+	if row.MiscFeeType != nil {
+		g.SetMiscFeeType(*row.MiscFeeType)
+	}
+	if row.MiscFeeBasis != nil {
+		g.SetMiscFeeBasis(*row.MiscFeeBasis)
+	}
+	return g
+}
+
 //NoStipulations is a repeating group element, Tag 232
 type NoStipulations struct {
 	*quickfix.Group
@@ -2540,6 +2755,44 @@ func (m NoStipulationsRepeatingGroup) Get(i int) NoStipulations {
 	return NoStipulations{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoStipulations of each entry in the NoStipulationsRepeatingGroup
+func (m NoStipulationsRepeatingGroup) All() iter.Seq2[int, NoStipulations] {
+	return func(yield func(int, NoStipulations) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoStipulations entries of the NoStipulationsRepeatingGroup as a slice
+func (m NoStipulationsRepeatingGroup) Slice() []NoStipulations {
+	s := make([]NoStipulations, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoStipulationsRow holds the values of a NoStipulations, nil fields are left unset by AddRow
+type NoStipulationsRow struct {
+	StipulationType  *enum.StipulationType
+	StipulationValue *string
+}
+
+//AddRow creates and appends a new NoStipulations to this group, setting the fields present in row
+func (m NoStipulationsRepeatingGroup) AddRow(row NoStipulationsRow) NoStipulations {
+	g := m.Add()
+	if row.StipulationType != nil {
+		g.SetStipulationType(*row.StipulationType)
+	}
+	if row.StipulationValue != nil {
+		g.SetStipulationValue(*row.StipulationValue)
+	}
+	return g
+}
+
 //NoPartyIDs is a repeating group element, Tag 453
 type NoPartyIDs struct {
 	*quickfix.Group
@@ -2685,6 +2938,44 @@ func (m NoPartySubIDsRepeatingGroup) Get(i int) NoPartySubIDs {
 	return NoPartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPartySubIDs of each entry in the NoPartySubIDsRepeatingGroup
+func (m NoPartySubIDsRepeatingGroup) All() iter.Seq2[int, NoPartySubIDs] {
+	return func(yield func(int, NoPartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPartySubIDs entries of the NoPartySubIDsRepeatingGroup as a slice
+func (m NoPartySubIDsRepeatingGroup) Slice() []NoPartySubIDs {
+	s := make([]NoPartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPartySubIDsRow holds the values of a NoPartySubIDs, nil fields are left unset by AddRow
+type NoPartySubIDsRow struct {
+	PartySubID     *string
+	PartySubIDType *enum.PartySubIDType
+}
+
+//AddRow creates and appends a new NoPartySubIDs to this group, setting the fields present in row
+func (m NoPartySubIDsRepeatingGroup) AddRow(row NoPartySubIDsRow) NoPartySubIDs {
+	g := m.Add()
+	if row.PartySubID != nil {
+		g.SetPartySubID(*row.PartySubID)
+	}
+	if row.PartySubIDType != nil {
+		g.SetPartySubIDType(*row.PartySubIDType)
+	}
+	return g
+}
+
 //NoPartyIDsRepeatingGroup is a repeating group, Tag 453
 type NoPartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -2708,6 +2999,56 @@ func (m NoPartyIDsRepeatingGroup) Get(i int) NoPartyIDs {
 	return NoPartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPartyIDs of each entry in the NoPartyIDsRepeatingGroup
+func (m NoPartyIDsRepeatingGroup) All() iter.Seq2[int, NoPartyIDs] {
+	return func(yield func(int, NoPartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPartyIDs entries of the NoPartyIDsRepeatingGroup as a slice
+func (m NoPartyIDsRepeatingGroup) Slice() []NoPartyIDs {
+	s := make([]NoPartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPartyIDsRow holds the values of a NoPartyIDs, nil fields are left unset by AddRow
+type NoPartyIDsRow struct {
+	PartyID       *string
+	PartyIDSource *enum.PartyIDSource
+	PartyRole     *enum.PartyRole
+	NoPartySubIDs []NoPartySubIDsRow
+}
+
+//AddRow creates and appends a new NoPartyIDs to this group, setting the fields present in row
+func (m NoPartyIDsRepeatingGroup) AddRow(row NoPartyIDsRow) NoPartyIDs {
+	g := m.Add()
+	if row.PartyID != nil {
+		g.SetPartyID(*row.PartyID)
+	}
+	if row.PartyIDSource != nil {
+		g.SetPartyIDSource(*row.PartyIDSource)
+	}
+	if row.PartyRole != nil {
+		g.SetPartyRole(*row.PartyRole)
+	}
+	if len(row.NoPartySubIDs) > 0 {
+		f := NewNoPartySubIDsRepeatingGroup()
+		for _, r := range row.NoPartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoPartySubIDs(f)
+	}
+	return g
+}
+
 //NoSecurityAltID is a repeating group element, Tag 454
 type NoSecurityAltID struct {
 	*quickfix.Group
@@ -2774,6 +3115,44 @@ func (m NoSecurityAltIDRepeatingGroup) Get(i int) NoSecurityAltID {
 	return NoSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoSecurityAltID of each entry in the NoSecurityAltIDRepeatingGroup
+func (m NoSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoSecurityAltID] {
+	return func(yield func(int, NoSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoSecurityAltID entries of the NoSecurityAltIDRepeatingGroup as a slice
+func (m NoSecurityAltIDRepeatingGroup) Slice() []NoSecurityAltID {
+	s := make([]NoSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoSecurityAltIDRow holds the values of a NoSecurityAltID, nil fields are left unset by AddRow
+type NoSecurityAltIDRow struct {
+	SecurityAltID       *string
+	SecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoSecurityAltID to this group, setting the fields present in row
+func (m NoSecurityAltIDRepeatingGroup) AddRow(row NoSecurityAltIDRow) NoSecurityAltID {
+	g := m.Add()
+	if row.SecurityAltID != nil {
+		g.SetSecurityAltID(*row.SecurityAltID)
+	}
+	if row.SecurityAltIDSource != nil {
+		g.SetSecurityAltIDSource(*row.SecurityAltIDSource)
+	}
+	return g
+}
+
 //NoLegs is a repeating group element, Tag 555
 type NoLegs struct {
 	*quickfix.Group
@@ -3641,6 +4020,44 @@ func (m NoLegSecurityAltIDRepeatingGroup) Get(i int) NoLegSecurityAltID {
 	return NoLegSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoLegSecurityAltID of each entry in the NoLegSecurityAltIDRepeatingGroup
+func (m NoLegSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoLegSecurityAltID] {
+	return func(yield func(int, NoLegSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoLegSecurityAltID entries of the NoLegSecurityAltIDRepeatingGroup as a slice
+func (m NoLegSecurityAltIDRepeatingGroup) Slice() []NoLegSecurityAltID {
+	s := make([]NoLegSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoLegSecurityAltIDRow holds the values of a NoLegSecurityAltID, nil fields are left unset by AddRow
+type NoLegSecurityAltIDRow struct {
+	LegSecurityAltID       *string
+	LegSecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoLegSecurityAltID to this group, setting the fields present in row
+func (m NoLegSecurityAltIDRepeatingGroup) AddRow(row NoLegSecurityAltIDRow) NoLegSecurityAltID {
+	g := m.Add()
+	if row.LegSecurityAltID != nil {
+		g.SetLegSecurityAltID(*row.LegSecurityAltID)
+	}
+	if row.LegSecurityAltIDSource != nil {
+		g.SetLegSecurityAltIDSource(*row.LegSecurityAltIDSource)
+	}
+	return g
+}
+
 //NoLegsRepeatingGroup is a repeating group, Tag 555
 type NoLegsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -3664,6 +4081,208 @@ func (m NoLegsRepeatingGroup) Get(i int) NoLegs {
 	return NoLegs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoLegs of each entry in the NoLegsRepeatingGroup
+func (m NoLegsRepeatingGroup) All() iter.Seq2[int, NoLegs] {
+	return func(yield func(int, NoLegs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoLegs entries of the NoLegsRepeatingGroup as a slice
+func (m NoLegsRepeatingGroup) Slice() []NoLegs {
+	s := make([]NoLegs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoLegsRow holds the values of a NoLegs, nil fields are left unset by AddRow
+type NoLegsRow struct {
+	LegSymbol                     *string
+	LegSymbolSfx                  *string
+	LegSecurityID                 *string
+	LegSecurityIDSource           *string
+	NoLegSecurityAltID            []NoLegSecurityAltIDRow
+	LegProduct                    *int
+	LegCFICode                    *string
+	LegSecurityType               *string
+	LegSecuritySubType            *string
+	LegMaturityMonthYear          *string
+	LegMaturityDate               *string
+	LegCouponPaymentDate          *string
+	LegIssueDate                  *string
+	LegRepoCollateralSecurityType *int
+	LegRepurchaseTerm             *int
+	LegRepurchaseRate             *quickfix.FIXDecimal
+	LegFactor                     *quickfix.FIXDecimal
+	LegCreditRating               *string
+	LegInstrRegistry              *string
+	LegCountryOfIssue             *string
+	LegStateOrProvinceOfIssue     *string
+	LegLocaleOfIssue              *string
+	LegRedemptionDate             *string
+	LegStrikePrice                *quickfix.FIXDecimal
+	LegStrikeCurrency             *string
+	LegOptAttribute               *string
+	LegContractMultiplier         *quickfix.FIXDecimal
+	LegCouponRate                 *quickfix.FIXDecimal
+	LegSecurityExchange           *string
+	LegIssuer                     *string
+	EncodedLegIssuerLen           *int
+	EncodedLegIssuer              *string
+	LegSecurityDesc               *string
+	EncodedLegSecurityDescLen     *int
+	EncodedLegSecurityDesc        *string
+	LegRatioQty                   *quickfix.FIXDecimal
+	LegSide                       *string
+	LegCurrency                   *string
+	LegPool                       *string
+	LegDatedDate                  *string
+	LegContractSettlMonth         *string
+	LegInterestAccrualDate        *string
+}
+
+//AddRow creates and appends a new NoLegs to this group, setting the fields present in row
+func (m NoLegsRepeatingGroup) AddRow(row NoLegsRow) NoLegs {
+	g := m.Add()
+	if row.LegSymbol != nil {
+		g.SetLegSymbol(*row.LegSymbol)
+	}
+	if row.LegSymbolSfx != nil {
+		g.SetLegSymbolSfx(*row.LegSymbolSfx)
+	}
+	if row.LegSecurityID != nil {
+		g.SetLegSecurityID(*row.LegSecurityID)
+	}
+	if row.LegSecurityIDSource != nil {
+		g.SetLegSecurityIDSource(*row.LegSecurityIDSource)
+	}
+	if len(row.NoLegSecurityAltID) > 0 {
+		f := NewNoLegSecurityAltIDRepeatingGroup()
+		for _, r := range row.NoLegSecurityAltID {
+			f.AddRow(r)
+		}
+		g.SetNoLegSecurityAltID(f)
+	}
+	if row.LegProduct != nil {
+		g.SetLegProduct(*row.LegProduct)
+	}
+	if row.LegCFICode != nil {
+		g.SetLegCFICode(*row.LegCFICode)
+	}
+	if row.LegSecurityType != nil {
+		g.SetLegSecurityType(*row.LegSecurityType)
+	}
+	if row.LegSecuritySubType != nil {
+		g.SetLegSecuritySubType(*row.LegSecuritySubType)
+	}
+	if row.LegMaturityMonthYear != nil {
+		g.SetLegMaturityMonthYear(*row.LegMaturityMonthYear)
+	}
+	if row.LegMaturityDate != nil {
+		g.SetLegMaturityDate(*row.LegMaturityDate)
+	}
+	if row.LegCouponPaymentDate != nil {
+		g.SetLegCouponPaymentDate(*row.LegCouponPaymentDate)
+	}
+	if row.LegIssueDate != nil {
+		g.SetLegIssueDate(*row.LegIssueDate)
+	}
+	if row.LegRepoCollateralSecurityType != nil {
+		g.SetLegRepoCollateralSecurityType(*row.LegRepoCollateralSecurityType)
+	}
+	if row.LegRepurchaseTerm != nil {
+		g.SetLegRepurchaseTerm(*row.LegRepurchaseTerm)
+	}
+	if row.LegRepurchaseRate != nil {
+		g.SetLegRepurchaseRate(row.LegRepurchaseRate.Decimal, row.LegRepurchaseRate.Scale)
+	}
+	if row.LegFactor != nil {
+		g.SetLegFactor(row.LegFactor.Decimal, row.LegFactor.Scale)
+	}
+	if row.LegCreditRating != nil {
+		g.SetLegCreditRating(*row.LegCreditRating)
+	}
+	if row.LegInstrRegistry != nil {
+		g.SetLegInstrRegistry(*row.LegInstrRegistry)
+	}
+	if row.LegCountryOfIssue != nil {
+		g.SetLegCountryOfIssue(*row.LegCountryOfIssue)
+	}
+	if row.LegStateOrProvinceOfIssue != nil {
+		g.SetLegStateOrProvinceOfIssue(*row.LegStateOrProvinceOfIssue)
+	}
+	if row.LegLocaleOfIssue != nil {
+		g.SetLegLocaleOfIssue(*row.LegLocaleOfIssue)
+	}
+	if row.LegRedemptionDate != nil {
+		g.SetLegRedemptionDate(*row.LegRedemptionDate)
+	}
+	if row.LegStrikePrice != nil {
+		g.SetLegStrikePrice(row.LegStrikePrice.Decimal, row.LegStrikePrice.Scale)
+	}
+	if row.LegStrikeCurrency != nil {
+		g.SetLegStrikeCurrency(*row.LegStrikeCurrency)
+	}
+	if row.LegOptAttribute != nil {
+		g.SetLegOptAttribute(*row.LegOptAttribute)
+	}
+	if row.LegContractMultiplier != nil {
+		g.SetLegContractMultiplier(row.LegContractMultiplier.Decimal, row.LegContractMultiplier.Scale)
+	}
+	if row.LegCouponRate != nil {
+		g.SetLegCouponRate(row.LegCouponRate.Decimal, row.LegCouponRate.Scale)
+	}
+	if row.LegSecurityExchange != nil {
+		g.SetLegSecurityExchange(*row.LegSecurityExchange)
+	}
+	if row.LegIssuer != nil {
+		g.SetLegIssuer(*row.LegIssuer)
+	}
+	if row.EncodedLegIssuerLen != nil {
+		g.SetEncodedLegIssuerLen(*row.EncodedLegIssuerLen)
+	}
+	if row.EncodedLegIssuer != nil {
+		g.SetEncodedLegIssuer(*row.EncodedLegIssuer)
+	}
+	if row.LegSecurityDesc != nil {
+		g.SetLegSecurityDesc(*row.LegSecurityDesc)
+	}
+	if row.EncodedLegSecurityDescLen != nil {
+		g.SetEncodedLegSecurityDescLen(*row.EncodedLegSecurityDescLen)
+	}
+	if row.EncodedLegSecurityDesc != nil {
+		g.SetEncodedLegSecurityDesc(*row.EncodedLegSecurityDesc)
+	}
+	if row.LegRatioQty != nil {
+		g.SetLegRatioQty(row.LegRatioQty.Decimal, row.LegRatioQty.Scale)
+	}
+	if row.LegSide != nil {
+		g.SetLegSide(*row.LegSide)
+	}
+	if row.LegCurrency != nil {
+		g.SetLegCurrency(*row.LegCurrency)
+	}
+	if row.LegPool != nil {
+		g.SetLegPool(*row.LegPool)
+	}
+	if row.LegDatedDate != nil {
+		g.SetLegDatedDate(*row.LegDatedDate)
+	}
+	if row.LegContractSettlMonth != nil {
+		g.SetLegContractSettlMonth(*row.LegContractSettlMonth)
+	}
+	if row.LegInterestAccrualDate != nil {
+		g.SetLegInterestAccrualDate(*row.LegInterestAccrualDate)
+	}
+	return g
+}
+
 //NoUnderlyings is a repeating group element, Tag 711
 type NoUnderlyings struct {
 	*quickfix.Group
@@ -4624,6 +5243,44 @@ func (m NoUnderlyingSecurityAltIDRepeatingGroup) Get(i int) NoUnderlyingSecurity
 	return NoUnderlyingSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoUnderlyingSecurityAltID of each entry in the NoUnderlyingSecurityAltIDRepeatingGroup
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoUnderlyingSecurityAltID] {
+	return func(yield func(int, NoUnderlyingSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyingSecurityAltID entries of the NoUnderlyingSecurityAltIDRepeatingGroup as a slice
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) Slice() []NoUnderlyingSecurityAltID {
+	s := make([]NoUnderlyingSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingSecurityAltIDRow holds the values of a NoUnderlyingSecurityAltID, nil fields are left unset by AddRow
+type NoUnderlyingSecurityAltIDRow struct {
+	UnderlyingSecurityAltID       *string
+	UnderlyingSecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoUnderlyingSecurityAltID to this group, setting the fields present in row
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) AddRow(row NoUnderlyingSecurityAltIDRow) NoUnderlyingSecurityAltID {
+	g := m.Add()
+	if row.UnderlyingSecurityAltID != nil {
+		g.SetUnderlyingSecurityAltID(*row.UnderlyingSecurityAltID)
+	}
+	if row.UnderlyingSecurityAltIDSource != nil {
+		g.SetUnderlyingSecurityAltIDSource(*row.UnderlyingSecurityAltIDSource)
+	}
+	return g
+}
+
 //NoUnderlyingStips is a repeating group element, Tag 887
 type NoUnderlyingStips struct {
 	*quickfix.Group
@@ -4690,6 +5347,44 @@ func (m NoUnderlyingStipsRepeatingGroup) Get(i int) NoUnderlyingStips {
 	return NoUnderlyingStips{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoUnderlyingStips of each entry in the NoUnderlyingStipsRepeatingGroup
+func (m NoUnderlyingStipsRepeatingGroup) All() iter.Seq2[int, NoUnderlyingStips] {
+	return func(yield func(int, NoUnderlyingStips) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyingStips entries of the NoUnderlyingStipsRepeatingGroup as a slice
+func (m NoUnderlyingStipsRepeatingGroup) Slice() []NoUnderlyingStips {
+	s := make([]NoUnderlyingStips, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingStipsRow holds the values of a NoUnderlyingStips, nil fields are left unset by AddRow
+type NoUnderlyingStipsRow struct {
+	UnderlyingStipType  *string
+	UnderlyingStipValue *string
+}
+
+//AddRow creates and appends a new NoUnderlyingStips to this group, setting the fields present in row
+func (m NoUnderlyingStipsRepeatingGroup) AddRow(row NoUnderlyingStipsRow) NoUnderlyingStips {
+	g := m.Add()
+	if row.UnderlyingStipType != nil {
+		g.SetUnderlyingStipType(*row.UnderlyingStipType)
+	}
+	if row.UnderlyingStipValue != nil {
+		g.SetUnderlyingStipValue(*row.UnderlyingStipValue)
+	}
+	return g
+}
+
 //NoUnderlyingsRepeatingGroup is a repeating group, Tag 711
 type NoUnderlyingsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -4713,6 +5408,232 @@ func (m NoUnderlyingsRepeatingGroup) Get(i int) NoUnderlyings {
 	return NoUnderlyings{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoUnderlyings of each entry in the NoUnderlyingsRepeatingGroup
+func (m NoUnderlyingsRepeatingGroup) All() iter.Seq2[int, NoUnderlyings] {
+	return func(yield func(int, NoUnderlyings) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyings entries of the NoUnderlyingsRepeatingGroup as a slice
+func (m NoUnderlyingsRepeatingGroup) Slice() []NoUnderlyings {
+	s := make([]NoUnderlyings, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingsRow holds the values of a NoUnderlyings, nil fields are left unset by AddRow
+type NoUnderlyingsRow struct {
+	UnderlyingSymbol                     *string
+	UnderlyingSymbolSfx                  *string
+	UnderlyingSecurityID                 *string
+	UnderlyingSecurityIDSource           *string
+	NoUnderlyingSecurityAltID            []NoUnderlyingSecurityAltIDRow
+	UnderlyingProduct                    *int
+	UnderlyingCFICode                    *string
+	UnderlyingSecurityType               *string
+	UnderlyingSecuritySubType            *string
+	UnderlyingMaturityMonthYear          *string
+	UnderlyingMaturityDate               *string
+	UnderlyingCouponPaymentDate          *string
+	UnderlyingIssueDate                  *string
+	UnderlyingRepoCollateralSecurityType *int
+	UnderlyingRepurchaseTerm             *int
+	UnderlyingRepurchaseRate             *quickfix.FIXDecimal
+	UnderlyingFactor                     *quickfix.FIXDecimal
+	UnderlyingCreditRating               *string
+	UnderlyingInstrRegistry              *string
+	UnderlyingCountryOfIssue             *string
+	UnderlyingStateOrProvinceOfIssue     *string
+	UnderlyingLocaleOfIssue              *string
+	UnderlyingRedemptionDate             *string
+	UnderlyingStrikePrice                *quickfix.FIXDecimal
+	UnderlyingStrikeCurrency             *string
+	UnderlyingOptAttribute               *string
+	UnderlyingContractMultiplier         *quickfix.FIXDecimal
+	UnderlyingCouponRate                 *quickfix.FIXDecimal
+	UnderlyingSecurityExchange           *string
+	UnderlyingIssuer                     *string
+	EncodedUnderlyingIssuerLen           *int
+	EncodedUnderlyingIssuer              *string
+	UnderlyingSecurityDesc               *string
+	EncodedUnderlyingSecurityDescLen     *int
+	EncodedUnderlyingSecurityDesc        *string
+	UnderlyingCPProgram                  *string
+	UnderlyingCPRegType                  *string
+	UnderlyingCurrency                   *string
+	UnderlyingQty                        *quickfix.FIXDecimal
+	UnderlyingPx                         *quickfix.FIXDecimal
+	UnderlyingDirtyPrice                 *quickfix.FIXDecimal
+	UnderlyingEndPrice                   *quickfix.FIXDecimal
+	UnderlyingStartValue                 *quickfix.FIXDecimal
+	UnderlyingCurrentValue               *quickfix.FIXDecimal
+	UnderlyingEndValue                   *quickfix.FIXDecimal
+	NoUnderlyingStips                    []NoUnderlyingStipsRow
+	CollAction                           *enum.CollAction
+}
+
+//AddRow creates and appends a new NoUnderlyings to this group, setting the fields present in row
+func (m NoUnderlyingsRepeatingGroup) AddRow(row NoUnderlyingsRow) NoUnderlyings {
+	g := m.Add()
+	if row.UnderlyingSymbol != nil {
+		g.SetUnderlyingSymbol(*row.UnderlyingSymbol)
+	}
+	if row.UnderlyingSymbolSfx != nil {
+		g.SetUnderlyingSymbolSfx(*row.UnderlyingSymbolSfx)
+	}
+	if row.UnderlyingSecurityID != nil {
+		g.SetUnderlyingSecurityID(*row.UnderlyingSecurityID)
+	}
+	if row.UnderlyingSecurityIDSource != nil {
+		g.SetUnderlyingSecurityIDSource(*row.UnderlyingSecurityIDSource)
+	}
+	if len(row.NoUnderlyingSecurityAltID) > 0 {
+		f := NewNoUnderlyingSecurityAltIDRepeatingGroup()
+		for _, r := range row.NoUnderlyingSecurityAltID {
+			f.AddRow(r)
+		}
+		g.SetNoUnderlyingSecurityAltID(f)
+	}
+	if row.UnderlyingProduct != nil {
+		g.SetUnderlyingProduct(*row.UnderlyingProduct)
+	}
+	if row.UnderlyingCFICode != nil {
+		g.SetUnderlyingCFICode(*row.UnderlyingCFICode)
+	}
+	if row.UnderlyingSecurityType != nil {
+		g.SetUnderlyingSecurityType(*row.UnderlyingSecurityType)
+	}
+	if row.UnderlyingSecuritySubType != nil {
+		g.SetUnderlyingSecuritySubType(*row.UnderlyingSecuritySubType)
+	}
+	if row.UnderlyingMaturityMonthYear != nil {
+		g.SetUnderlyingMaturityMonthYear(*row.UnderlyingMaturityMonthYear)
+	}
+	if row.UnderlyingMaturityDate != nil {
+		g.SetUnderlyingMaturityDate(*row.UnderlyingMaturityDate)
+	}
+	if row.UnderlyingCouponPaymentDate != nil {
+		g.SetUnderlyingCouponPaymentDate(*row.UnderlyingCouponPaymentDate)
+	}
+	if row.UnderlyingIssueDate != nil {
+		g.SetUnderlyingIssueDate(*row.UnderlyingIssueDate)
+	}
+	if row.UnderlyingRepoCollateralSecurityType != nil {
+		g.SetUnderlyingRepoCollateralSecurityType(*row.UnderlyingRepoCollateralSecurityType)
+	}
+	if row.UnderlyingRepurchaseTerm != nil {
+		g.SetUnderlyingRepurchaseTerm(*row.UnderlyingRepurchaseTerm)
+	}
+	if row.UnderlyingRepurchaseRate != nil {
+		g.SetUnderlyingRepurchaseRate(row.UnderlyingRepurchaseRate.Decimal, row.UnderlyingRepurchaseRate.Scale)
+	}
+	if row.UnderlyingFactor != nil {
+		g.SetUnderlyingFactor(row.UnderlyingFactor.Decimal, row.UnderlyingFactor.Scale)
+	}
+	if row.UnderlyingCreditRating != nil {
+		g.SetUnderlyingCreditRating(*row.UnderlyingCreditRating)
+	}
+	if row.UnderlyingInstrRegistry != nil {
+		g.SetUnderlyingInstrRegistry(*row.UnderlyingInstrRegistry)
+	}
+	if row.UnderlyingCountryOfIssue != nil {
+		g.SetUnderlyingCountryOfIssue(*row.UnderlyingCountryOfIssue)
+	}
+	if row.UnderlyingStateOrProvinceOfIssue != nil {
+		g.SetUnderlyingStateOrProvinceOfIssue(*row.UnderlyingStateOrProvinceOfIssue)
+	}
+	if row.UnderlyingLocaleOfIssue != nil {
+		g.SetUnderlyingLocaleOfIssue(*row.UnderlyingLocaleOfIssue)
+	}
+	if row.UnderlyingRedemptionDate != nil {
+		g.SetUnderlyingRedemptionDate(*row.UnderlyingRedemptionDate)
+	}
+	if row.UnderlyingStrikePrice != nil {
+		g.SetUnderlyingStrikePrice(row.UnderlyingStrikePrice.Decimal, row.UnderlyingStrikePrice.Scale)
+	}
+	if row.UnderlyingStrikeCurrency != nil {
+		g.SetUnderlyingStrikeCurrency(*row.UnderlyingStrikeCurrency)
+	}
+	if row.UnderlyingOptAttribute != nil {
+		g.SetUnderlyingOptAttribute(*row.UnderlyingOptAttribute)
+	}
+	if row.UnderlyingContractMultiplier != nil {
+		g.SetUnderlyingContractMultiplier(row.UnderlyingContractMultiplier.Decimal, row.UnderlyingContractMultiplier.Scale)
+	}
+	if row.UnderlyingCouponRate != nil {
+		g.SetUnderlyingCouponRate(row.UnderlyingCouponRate.Decimal, row.UnderlyingCouponRate.Scale)
+	}
+	if row.UnderlyingSecurityExchange != nil {
+		g.SetUnderlyingSecurityExchange(*row.UnderlyingSecurityExchange)
+	}
+	if row.UnderlyingIssuer != nil {
+		g.SetUnderlyingIssuer(*row.UnderlyingIssuer)
+	}
+	if row.EncodedUnderlyingIssuerLen != nil {
+		g.SetEncodedUnderlyingIssuerLen(*row.EncodedUnderlyingIssuerLen)
+	}
+	if row.EncodedUnderlyingIssuer != nil {
+		g.SetEncodedUnderlyingIssuer(*row.EncodedUnderlyingIssuer)
+	}
+	if row.UnderlyingSecurityDesc != nil {
+		g.SetUnderlyingSecurityDesc(*row.UnderlyingSecurityDesc)
+	}
+	if row.EncodedUnderlyingSecurityDescLen != nil {
+		g.SetEncodedUnderlyingSecurityDescLen(*row.EncodedUnderlyingSecurityDescLen)
+	}
+	if row.EncodedUnderlyingSecurityDesc != nil {
+		g.SetEncodedUnderlyingSecurityDesc(*row.EncodedUnderlyingSecurityDesc)
+	}
+	if row.UnderlyingCPProgram != nil {
+		g.SetUnderlyingCPProgram(*row.UnderlyingCPProgram)
+	}
+	if row.UnderlyingCPRegType != nil {
+		g.SetUnderlyingCPRegType(*row.UnderlyingCPRegType)
+	}
+	if row.UnderlyingCurrency != nil {
+		g.SetUnderlyingCurrency(*row.UnderlyingCurrency)
+	}
+	if row.UnderlyingQty != nil {
+		g.SetUnderlyingQty(row.UnderlyingQty.Decimal, row.UnderlyingQty.Scale)
+	}
+	if row.UnderlyingPx != nil {
+		g.SetUnderlyingPx(row.UnderlyingPx.Decimal, row.UnderlyingPx.Scale)
+	}
+	if row.UnderlyingDirtyPrice != nil {
+		g.SetUnderlyingDirtyPrice(row.UnderlyingDirtyPrice.Decimal, row.UnderlyingDirtyPrice.Scale)
+	}
+	if row.UnderlyingEndPrice != nil {
+		g.SetUnderlyingEndPrice(row.UnderlyingEndPrice.Decimal, row.UnderlyingEndPrice.Scale)
+	}
+	if row.UnderlyingStartValue != nil {
+		g.SetUnderlyingStartValue(row.UnderlyingStartValue.Decimal, row.UnderlyingStartValue.Scale)
+	}
+	if row.UnderlyingCurrentValue != nil {
+		g.SetUnderlyingCurrentValue(row.UnderlyingCurrentValue.Decimal, row.UnderlyingCurrentValue.Scale)
+	}
+	if row.UnderlyingEndValue != nil {
+		g.SetUnderlyingEndValue(row.UnderlyingEndValue.Decimal, row.UnderlyingEndValue.Scale)
+	}
+	if len(row.NoUnderlyingStips) > 0 {
+		f := NewNoUnderlyingStipsRepeatingGroup()
+		for _, r := range row.NoUnderlyingStips {
+			f.AddRow(r)
+		}
+		g.SetNoUnderlyingStips(f)
+	}
+	if row.CollAction != nil {
+		g.SetCollAction(*row.CollAction)
+	}
+	return g
+}
+
 //NoTrdRegTimestamps is a repeating group element, Tag 768
 type NoTrdRegTimestamps struct {
 	*quickfix.Group
@@ -4798,6 +5719,48 @@ func (m NoTrdRegTimestampsRepeatingGroup) Get(i int) NoTrdRegTimestamps {
 	return NoTrdRegTimestamps{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoTrdRegTimestamps of each entry in the NoTrdRegTimestampsRepeatingGroup
+func (m NoTrdRegTimestampsRepeatingGroup) All() iter.Seq2[int, NoTrdRegTimestamps] {
+	return func(yield func(int, NoTrdRegTimestamps) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoTrdRegTimestamps entries of the NoTrdRegTimestampsRepeatingGroup as a slice
+func (m NoTrdRegTimestampsRepeatingGroup) Slice() []NoTrdRegTimestamps {
+	s := make([]NoTrdRegTimestamps, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoTrdRegTimestampsRow holds the values of a NoTrdRegTimestamps, nil fields are left unset by AddRow
+type NoTrdRegTimestampsRow struct {
+	TrdRegTimestamp       *time.Time
+	TrdRegTimestampType   *enum.TrdRegTimestampType
+	TrdRegTimestampOrigin *string
+}
+
+//AddRow creates and appends a new NoTrdRegTimestamps to this group, setting the fields present in row
+func (m NoTrdRegTimestampsRepeatingGroup) AddRow(row NoTrdRegTimestampsRow) NoTrdRegTimestamps {
+	g := m.Add()
+	if row.TrdRegTimestamp != nil {
+		g.SetTrdRegTimestamp(*row.TrdRegTimestamp)
+	}
+	if row.TrdRegTimestampType != nil {
+		g.SetTrdRegTimestampType(*row.TrdRegTimestampType)
+	}
+	if row.TrdRegTimestampOrigin != nil {
+		g.SetTrdRegTimestampOrigin(*row.TrdRegTimestampOrigin)
+	}
+	return g
+}
+
 //NoEvents is a repeating group element, Tag 864
 type NoEvents struct {
 	*quickfix.Group
@@ -4902,6 +5865,52 @@ func (m NoEventsRepeatingGroup) Get(i int) NoEvents {
 	return NoEvents{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoEvents of each entry in the NoEventsRepeatingGroup
+func (m NoEventsRepeatingGroup) All() iter.Seq2[int, NoEvents] {
+	return func(yield func(int, NoEvents) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoEvents entries of the NoEventsRepeatingGroup as a slice
+func (m NoEventsRepeatingGroup) Slice() []NoEvents {
+	s := make([]NoEvents, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoEventsRow holds the values of a NoEvents, nil fields are left unset by AddRow
+type NoEventsRow struct {
+	EventType *enum.EventType
+	EventDate *string
+	EventPx   *quickfix.FIXDecimal
+	EventText *string
+}
+
+//AddRow creates and appends a new NoEvents to this group, setting the fields present in row
+func (m NoEventsRepeatingGroup) AddRow(row NoEventsRow) NoEvents {
+	g := m.Add()
+	if row.EventType != nil {
+		g.SetEventType(*row.EventType)
+	}
+	if row.EventDate != nil {
+		g.SetEventDate(*row.EventDate)
+	}
+	if row.EventPx != nil {
+		g.SetEventPx(row.EventPx.Decimal, row.EventPx.Scale)
+	}
+	if row.EventText != nil {
+		g.SetEventText(*row.EventText)
+	}
+	return g
+}
+
 //NoTrades is a repeating group element, Tag 897
 type NoTrades struct {
 	*quickfix.Group
@@ -4967,3 +5976,41 @@ func (m NoTradesRepeatingGroup) Add() NoTrades {
 func (m NoTradesRepeatingGroup) Get(i int) NoTrades {
 	return NoTrades{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoTrades of each entry in the NoTradesRepeatingGroup
+func (m NoTradesRepeatingGroup) All() iter.Seq2[int, NoTrades] {
+	return func(yield func(int, NoTrades) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoTrades entries of the NoTradesRepeatingGroup as a slice
+func (m NoTradesRepeatingGroup) Slice() []NoTrades {
+	s := make([]NoTrades, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoTradesRow holds the values of a NoTrades, nil fields are left unset by AddRow
+type NoTradesRow struct {
+	TradeReportID          *string
+	SecondaryTradeReportID *string
+}
+
+//AddRow creates and appends a new NoTrades to this group, setting the fields present in row
+func (m NoTradesRepeatingGroup) AddRow(row NoTradesRow) NoTrades {
+	g := m.Add()
+	if row.TradeReportID != nil {
+		g.SetTradeReportID(*row.TradeReportID)
+	}
+	if row.SecondaryTradeReportID != nil {
+		g.SetSecondaryTradeReportID(*row.SecondaryTradeReportID)
+	}
+	return g
+}
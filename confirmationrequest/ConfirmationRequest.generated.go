@@ -2,6 +2,7 @@ package confirmationrequest
 
 import (
 	"github.com/shopspring/decimal"
+	"iter"
 	"time"
 
 	"github.com/quickfixgo/enum"
@@ -624,6 +625,44 @@ func (m NoNested2PartySubIDsRepeatingGroup) Get(i int) NoNested2PartySubIDs {
 	return NoNested2PartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoNested2PartySubIDs of each entry in the NoNested2PartySubIDsRepeatingGroup
+func (m NoNested2PartySubIDsRepeatingGroup) All() iter.Seq2[int, NoNested2PartySubIDs] {
+	return func(yield func(int, NoNested2PartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoNested2PartySubIDs entries of the NoNested2PartySubIDsRepeatingGroup as a slice
+func (m NoNested2PartySubIDsRepeatingGroup) Slice() []NoNested2PartySubIDs {
+	s := make([]NoNested2PartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoNested2PartySubIDsRow holds the values of a NoNested2PartySubIDs, nil fields are left unset by AddRow
+type NoNested2PartySubIDsRow struct {
+	Nested2PartySubID     *string
+	Nested2PartySubIDType *int
+}
+
+//AddRow creates and appends a new NoNested2PartySubIDs to this group, setting the fields present in row
+func (m NoNested2PartySubIDsRepeatingGroup) AddRow(row NoNested2PartySubIDsRow) NoNested2PartySubIDs {
+	g := m.Add()
+	if row.Nested2PartySubID != nil {
+		g.SetNested2PartySubID(*row.Nested2PartySubID)
+	}
+	if row.Nested2PartySubIDType != nil {
+		g.SetNested2PartySubIDType(*row.Nested2PartySubIDType)
+	}
+	return g
+}
+
 //NoNested2PartyIDsRepeatingGroup is a repeating group, Tag 756
 type NoNested2PartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -647,6 +686,56 @@ func (m NoNested2PartyIDsRepeatingGroup) Get(i int) NoNested2PartyIDs {
 	return NoNested2PartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoNested2PartyIDs of each entry in the NoNested2PartyIDsRepeatingGroup
+func (m NoNested2PartyIDsRepeatingGroup) All() iter.Seq2[int, NoNested2PartyIDs] {
+	return func(yield func(int, NoNested2PartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoNested2PartyIDs entries of the NoNested2PartyIDsRepeatingGroup as a slice
+func (m NoNested2PartyIDsRepeatingGroup) Slice() []NoNested2PartyIDs {
+	s := make([]NoNested2PartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoNested2PartyIDsRow holds the values of a NoNested2PartyIDs, nil fields are left unset by AddRow
+type NoNested2PartyIDsRow struct {
+	Nested2PartyID       *string
+	Nested2PartyIDSource *string
+	Nested2PartyRole     *int
+	NoNested2PartySubIDs []NoNested2PartySubIDsRow
+}
+
+//AddRow creates and appends a new NoNested2PartyIDs to this group, setting the fields present in row
+func (m NoNested2PartyIDsRepeatingGroup) AddRow(row NoNested2PartyIDsRow) NoNested2PartyIDs {
+	g := m.Add()
+	if row.Nested2PartyID != nil {
+		g.SetNested2PartyID(*row.Nested2PartyID)
+	}
+	if row.Nested2PartyIDSource != nil {
+		g.SetNested2PartyIDSource(*row.Nested2PartyIDSource)
+	}
+	if row.Nested2PartyRole != nil {
+		g.SetNested2PartyRole(*row.Nested2PartyRole)
+	}
+	if len(row.NoNested2PartySubIDs) > 0 {
+		f := NewNoNested2PartySubIDsRepeatingGroup()
+		for _, r := range row.NoNested2PartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoNested2PartySubIDs(f)
+	}
+	return g
+}
+
 //NoOrdersRepeatingGroup is a repeating group, Tag 73
 type NoOrdersRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -669,3 +758,73 @@ func (m NoOrdersRepeatingGroup) Add() NoOrders {
 func (m NoOrdersRepeatingGroup) Get(i int) NoOrders {
 	return NoOrders{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoOrders of each entry in the NoOrdersRepeatingGroup
+func (m NoOrdersRepeatingGroup) All() iter.Seq2[int, NoOrders] {
+	return func(yield func(int, NoOrders) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoOrders entries of the NoOrdersRepeatingGroup as a slice
+func (m NoOrdersRepeatingGroup) Slice() []NoOrders {
+	s := make([]NoOrders, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoOrdersRow holds the values of a NoOrders, nil fields are left unset by AddRow
+type NoOrdersRow struct {
+	ClOrdID           *string
+	OrderID           *string
+	SecondaryOrderID  *string
+	SecondaryClOrdID  *string
+	ListID            *string
+	NoNested2PartyIDs []NoNested2PartyIDsRow
+	OrderQty          *quickfix.FIXDecimal
+	OrderAvgPx        *quickfix.FIXDecimal
+	OrderBookingQty   *quickfix.FIXDecimal
+}
+
+//AddRow creates and appends a new NoOrders to this group, setting the fields present in row
+func (m NoOrdersRepeatingGroup) AddRow(row NoOrdersRow) NoOrders {
+	g := m.Add()
+	if row.ClOrdID != nil {
+		g.SetClOrdID(*row.ClOrdID)
+	}
+	if row.OrderID != nil {
+		g.SetOrderID(*row.OrderID)
+	}
+	if row.SecondaryOrderID != nil {
+		g.SetSecondaryOrderID(*row.SecondaryOrderID)
+	}
+	if row.SecondaryClOrdID != nil {
+		g.SetSecondaryClOrdID(*row.SecondaryClOrdID)
+	}
+	if row.ListID != nil {
+		g.SetListID(*row.ListID)
+	}
+	if len(row.NoNested2PartyIDs) > 0 {
+		f := NewNoNested2PartyIDsRepeatingGroup()
+		for _, r := range row.NoNested2PartyIDs {
+			f.AddRow(r)
+		}
+		g.SetNoNested2PartyIDs(f)
+	}
+	if row.OrderQty != nil {
+		g.SetOrderQty(row.OrderQty.Decimal, row.OrderQty.Scale)
+	}
+	if row.OrderAvgPx != nil {
+		g.SetOrderAvgPx(row.OrderAvgPx.Decimal, row.OrderAvgPx.Scale)
+	}
+	if row.OrderBookingQty != nil {
+		g.SetOrderBookingQty(row.OrderBookingQty.Decimal, row.OrderBookingQty.Scale)
+	}
+	return g
+}
@@ -0,0 +1,59 @@
+package cross
+
+import (
+	"fmt"
+
+	"github.com/quickfixgo/fix44/hnxinfogate"
+	"github.com/shopspring/decimal"
+)
+
+// Band is the put-through price band of an instrument, from the CeilingPricePT
+// and FloorPricePT of the HNX InfoGate StockInfo
+type Band struct {
+	Floor   decimal.Decimal
+	Ceiling decimal.Decimal
+}
+
+// Contains returns true if px is within the band, bounds included
+func (b Band) Contains(px decimal.Decimal) bool {
+	return !px.LessThan(b.Floor) && !px.GreaterThan(b.Ceiling)
+}
+
+// BandFromStockInfo returns the Symbol and the put-through band of a StockInfo
+func BandFromStockInfo(si hnxinfogate.StockInfo) (string, Band, error) {
+	symbol, err := si.GetSymbol()
+	if err != nil {
+		return "", Band{}, err
+	}
+	floor, err := si.GetFloorPricePT()
+	if err != nil {
+		return "", Band{}, err
+	}
+	ceiling, err := si.GetCeilingPricePT()
+	if err != nil {
+		return "", Band{}, err
+	}
+	return symbol, Band{Floor: decimal.NewFromFloat(floor), Ceiling: decimal.NewFromFloat(ceiling)}, nil
+}
+
+// PriceError is returned for a cross priced outside the put-through band of its instrument
+type PriceError struct {
+	Symbol string
+	Price  decimal.Decimal
+	Band   Band
+}
+
+func (e *PriceError) Error() string {
+	return fmt.Sprintf("cross: price %s of %s is outside the put-through band [%s, %s]",
+		e.Price, e.Symbol, e.Band.Floor, e.Band.Ceiling)
+}
+
+// scale returns the number of decimal places of v without its trailing zeros,
+// so that a quantity or a price is sent unrounded
+func scale(v decimal.Decimal) int32 {
+	var s int32
+	for !v.Truncate(s).Equal(v) {
+		s++
+	}
+	return s
+}
@@ -0,0 +1,505 @@
+package cross
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/crossordercancelreplacerequest"
+	"github.com/quickfixgo/fix44/crossordercancelrequest"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/hnxinfogate"
+	"github.com/quickfixgo/fix44/newordercross"
+	"github.com/quickfixgo/fix44/ordercancelreject"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrNoBand is returned for a cross on an instrument without known put-through band
+	ErrNoBand = errors.New("cross: unknown put-through band")
+	// ErrInvalidSides is returned for a cross without a buy and a sell side of the same positive quantity
+	ErrInvalidSides = errors.New("cross: a cross needs a buy and a sell side of the same quantity")
+	// ErrUnknownCross is returned for a CrossID not sent by the Manager
+	ErrUnknownCross = errors.New("cross: unknown CrossID")
+	// ErrDuplicateCrossID is returned for a cross reusing a CrossID already sent
+	ErrDuplicateCrossID = errors.New("cross: duplicate CrossID")
+	// ErrCrossClosed is returned when replacing or canceling a cross that is done
+	ErrCrossClosed = errors.New("cross: cross is not open")
+)
+
+// Side is a side of a cross
+type Side struct {
+	Side enum.Side
+	// ClOrdID is generated by Submit if empty
+	ClOrdID  string
+	Account  string
+	OrderQty decimal.Decimal
+}
+
+// Cross is a two-sided order, e.g. an HNX put-through deal
+type Cross struct {
+	// CrossID is generated by Submit if empty
+	CrossID string
+	Symbol  string
+	Price   decimal.Decimal
+	// OrdType defaults to limit
+	OrdType enum.OrdType
+	// CrossType defaults to executed in full, CrossPrioritization to none
+	CrossType           enum.CrossType
+	CrossPrioritization enum.CrossPrioritization
+	Buy, Sell           Side
+}
+
+// Execution is the execution state of a side of a cross
+type Execution struct {
+	OrderID   string
+	OrdStatus enum.OrdStatus
+	CumQty    decimal.Decimal
+	LeavesQty decimal.Decimal
+	AvgPx     decimal.Decimal
+	Text      string
+}
+
+// Done returns true if the side cannot be filled anymore
+func (e Execution) Done() bool {
+	switch e.OrdStatus {
+	case enum.OrdStatus_FILLED, enum.OrdStatus_CANCELED, enum.OrdStatus_REJECTED, enum.OrdStatus_EXPIRED:
+		return true
+	}
+	return false
+}
+
+// Order is the state of a cross sent by the Manager
+type Order struct {
+	Cross
+	// CrossIDs is the chain of accepted CrossIDs, Cross.CrossID being the last one
+	CrossIDs []string
+	// Pending is the CrossID of a replace or cancel waiting for the ExecutionReports of both sides
+	Pending   string
+	BuyExec   Execution
+	SellExec  Execution
+	UpdatedAt time.Time
+
+	request *request
+}
+
+// request is a replace or cancel of the sides of a cross
+type request struct {
+	crossID  string
+	price    decimal.Decimal
+	buy      Side
+	sell     Side
+	reported map[enum.Side]bool
+}
+
+// IsOpen returns true while a side of the cross can still be filled
+func (o Order) IsOpen() bool {
+	return !o.BuyExec.Done() || !o.SellExec.Done()
+}
+
+func (o *Order) clone() Order {
+	c := *o
+	c.CrossIDs = append([]string(nil), o.CrossIDs...)
+	c.request = nil
+	return c
+}
+
+// exec returns the execution of the side, nil if side is not a side of the cross
+func (o *Order) exec(side enum.Side) *Execution {
+	switch side {
+	case o.Buy.Side:
+		return &o.BuyExec
+	case o.Sell.Side:
+		return &o.SellExec
+	}
+	return nil
+}
+
+// Manager sends crosses checked against the put-through bands and tracks the
+// execution of their sides from ExecutionReports. A Manager is safe for concurrent use.
+type Manager struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// OnChange, if set, is called with a copy of a cross each time it changes.
+	// It is called once the Manager is unlocked, so it can call the Manager.
+	OnChange func(Order)
+
+	mu       sync.Mutex
+	nextID   int
+	idPrefix string
+	bands    map[string]Band
+	orders   []*Order
+	// byCrossID maps all the CrossIDs of the crosses, including the pending ones
+	byCrossID map[string]*Order
+	execIDs   map[string]bool
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns a Manager for the session
+func New(sessionID quickfix.SessionID) *Manager {
+	return &Manager{
+		SessionID: sessionID,
+		Send:      quickfix.SendToTarget,
+		idPrefix:  time.Now().Format("150405") + "-",
+		bands:     make(map[string]Band),
+		byCrossID: make(map[string]*Order),
+		execIDs:   make(map[string]bool),
+	}
+}
+
+// newID must be called with mu held
+func (m *Manager) newID() string {
+	m.nextID++
+	return fmt.Sprintf("%s%d", m.idPrefix, m.nextID)
+}
+
+// changed queues the call of OnChange with a copy of o, it must be called with mu held
+func (m *Manager) changed(o *Order) {
+	if m.OnChange != nil {
+		c := o.clone()
+		m.callbacks = append(m.callbacks, func() { m.OnChange(c) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (m *Manager) unlock() {
+	callbacks := m.callbacks
+	m.callbacks = nil
+	m.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// SetBand sets the put-through band of an instrument
+func (m *Manager) SetBand(symbol string, b Band) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bands[symbol] = b
+}
+
+// Band returns the put-through band of an instrument
+func (m *Manager) Band(symbol string) (Band, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.bands[symbol]
+	return b, ok
+}
+
+// check must be called with mu held
+func (m *Manager) check(symbol string, price decimal.Decimal, buy, sell Side) error {
+	if buy.Side != enum.Side_BUY || sell.Side != enum.Side_SELL ||
+		!buy.OrderQty.IsPositive() || !buy.OrderQty.Equal(sell.OrderQty) {
+		return ErrInvalidSides
+	}
+	b, ok := m.bands[symbol]
+	if !ok {
+		return ErrNoBand
+	}
+	if !b.Contains(price) {
+		return &PriceError{Symbol: symbol, Price: price, Band: b}
+	}
+	return nil
+}
+
+// Submit checks the cross against the put-through band of its instrument and sends it
+// as a NewOrderCross, it returns the CrossID
+func (m *Manager) Submit(c Cross) (string, error) {
+	if c.Buy.Side == "" {
+		c.Buy.Side = enum.Side_BUY
+	}
+	if c.Sell.Side == "" {
+		c.Sell.Side = enum.Side_SELL
+	}
+	if c.OrdType == "" {
+		c.OrdType = enum.OrdType_LIMIT
+	}
+	if c.CrossType == "" {
+		c.CrossType = enum.CrossType_CROSS_TRADE_WHICH_IS_EXECUTED_COMPLETELY_OR_NOT_BOTH_SIDES_ARE_TREATED_IN_THE_SAME_MANNER_THIS_IS_EQUIVALENT_TO_AN_ALL_OR_NONE
+	}
+	if c.CrossPrioritization == "" {
+		c.CrossPrioritization = enum.CrossPrioritization_NONE
+	}
+
+	m.mu.Lock()
+	defer m.unlock()
+	if err := m.check(c.Symbol, c.Price, c.Buy, c.Sell); err != nil {
+		return "", err
+	}
+	if c.CrossID == "" {
+		c.CrossID = m.newID()
+	}
+	if _, ok := m.byCrossID[c.CrossID]; ok {
+		return "", ErrDuplicateCrossID
+	}
+	if c.Buy.ClOrdID == "" {
+		c.Buy.ClOrdID = m.newID()
+	}
+	if c.Sell.ClOrdID == "" {
+		c.Sell.ClOrdID = m.newID()
+	}
+	now := time.Now()
+	msg := newordercross.New(field.NewCrossID(c.CrossID), field.NewCrossType(c.CrossType),
+		field.NewCrossPrioritization(c.CrossPrioritization), field.NewTransactTime(now), field.NewOrdType(c.OrdType))
+	msg.SetSymbol(c.Symbol)
+	msg.SetPrice(c.Price, scale(c.Price))
+	sides := newordercross.NewNoSidesRepeatingGroup()
+	for _, s := range []Side{c.Buy, c.Sell} {
+		row := sides.Add()
+		row.SetSide(s.Side)
+		row.SetClOrdID(s.ClOrdID)
+		if s.Account != "" {
+			row.SetAccount(s.Account)
+		}
+		row.SetOrderQty(s.OrderQty, scale(s.OrderQty))
+	}
+	msg.SetNoSides(sides)
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+
+	o := &Order{Cross: c, CrossIDs: []string{c.CrossID}, UpdatedAt: now,
+		BuyExec:  Execution{OrdStatus: enum.OrdStatus_PENDING_NEW, LeavesQty: c.Buy.OrderQty},
+		SellExec: Execution{OrdStatus: enum.OrdStatus_PENDING_NEW, LeavesQty: c.Sell.OrderQty}}
+	m.orders = append(m.orders, o)
+	m.byCrossID[c.CrossID] = o
+	m.changed(o)
+	return c.CrossID, nil
+}
+
+// open must be called with mu held
+func (m *Manager) open(crossID string) (*Order, error) {
+	o, ok := m.byCrossID[crossID]
+	if !ok {
+		return nil, ErrUnknownCross
+	}
+	if !o.IsOpen() {
+		return nil, ErrCrossClosed
+	}
+	return o, nil
+}
+
+// newRequest must be called with mu held, it gives new ClOrdIDs to the sides
+func (m *Manager) newRequest(o *Order, price, orderQty decimal.Decimal) *request {
+	r := &request{crossID: m.newID(), price: price, buy: o.Buy, sell: o.Sell, reported: make(map[enum.Side]bool)}
+	r.buy.ClOrdID, r.sell.ClOrdID = m.newID(), m.newID()
+	r.buy.OrderQty, r.sell.OrderQty = orderQty, orderQty
+	return r
+}
+
+// pend must be called with mu held
+func (m *Manager) pend(o *Order, r *request) {
+	o.request = r
+	o.Pending = r.crossID
+	m.byCrossID[r.crossID] = o
+	m.changed(o)
+}
+
+// Replace checks the new price against the put-through band and sends a
+// CrossOrderCancelReplaceRequest for both sides, it returns the new CrossID
+func (m *Manager) Replace(crossID string, price, orderQty decimal.Decimal) (string, error) {
+	m.mu.Lock()
+	defer m.unlock()
+	o, err := m.open(crossID)
+	if err != nil {
+		return "", err
+	}
+	r := m.newRequest(o, price, orderQty)
+	if err := m.check(o.Symbol, price, r.buy, r.sell); err != nil {
+		return "", err
+	}
+	msg := crossordercancelreplacerequest.New(field.NewCrossID(r.crossID), field.NewOrigCrossID(o.CrossID),
+		field.NewCrossType(o.CrossType), field.NewCrossPrioritization(o.CrossPrioritization),
+		field.NewTransactTime(time.Now()), field.NewOrdType(o.OrdType))
+	if o.BuyExec.OrderID != "" {
+		msg.SetOrderID(o.BuyExec.OrderID)
+	}
+	msg.SetSymbol(o.Symbol)
+	msg.SetPrice(price, scale(price))
+	sides := crossordercancelreplacerequest.NewNoSidesRepeatingGroup()
+	for _, s := range [][2]Side{{o.Buy, r.buy}, {o.Sell, r.sell}} {
+		row := sides.Add()
+		row.SetSide(s[1].Side)
+		row.SetOrigClOrdID(s[0].ClOrdID)
+		row.SetClOrdID(s[1].ClOrdID)
+		if s[1].Account != "" {
+			row.SetAccount(s[1].Account)
+		}
+		row.SetOrderQty(s[1].OrderQty, scale(s[1].OrderQty))
+	}
+	msg.SetNoSides(sides)
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+	m.pend(o, r)
+	return r.crossID, nil
+}
+
+// Cancel sends a CrossOrderCancelRequest for both sides of a cross, it returns the CrossID of the request
+func (m *Manager) Cancel(crossID string) (string, error) {
+	m.mu.Lock()
+	defer m.unlock()
+	o, err := m.open(crossID)
+	if err != nil {
+		return "", err
+	}
+	r := m.newRequest(o, o.Price, o.Buy.OrderQty)
+	msg := crossordercancelrequest.New(field.NewCrossID(r.crossID), field.NewOrigCrossID(o.CrossID),
+		field.NewCrossType(o.CrossType), field.NewCrossPrioritization(o.CrossPrioritization),
+		field.NewTransactTime(time.Now()))
+	if o.BuyExec.OrderID != "" {
+		msg.SetOrderID(o.BuyExec.OrderID)
+	}
+	msg.SetSymbol(o.Symbol)
+	sides := crossordercancelrequest.NewNoSidesRepeatingGroup()
+	for _, s := range [][2]Side{{o.Buy, r.buy}, {o.Sell, r.sell}} {
+		row := sides.Add()
+		row.SetSide(s[1].Side)
+		row.SetOrigClOrdID(s[0].ClOrdID)
+		row.SetClOrdID(s[1].ClOrdID)
+		row.SetOrderQty(s[1].OrderQty, scale(s[1].OrderQty))
+	}
+	msg.SetNoSides(sides)
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+	m.pend(o, r)
+	return r.crossID, nil
+}
+
+// Process applies the put-through bands of InfoGate StockInfo and the ExecutionReports and
+// OrderCancelRejects of the crosses, other messages are ignored. It is meant to be called from FromApp.
+func (m *Manager) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "SI":
+		return m.OnStockInfo(hnxinfogate.FromMessageToStockInfo(msg))
+	case "8":
+		return m.OnExecutionReport(executionreport.FromMessage(msg))
+	case "9":
+		return m.OnOrderCancelReject(ordercancelreject.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnStockInfo sets the put-through band of the instrument of a StockInfo,
+// StockInfo without band are ignored
+func (m *Manager) OnStockInfo(si hnxinfogate.StockInfo) error {
+	symbol, b, err := BandFromStockInfo(si)
+	if err != nil {
+		return nil
+	}
+	m.SetBand(symbol, b)
+	return nil
+}
+
+// OnExecutionReport applies an ExecutionReport to the side of its cross.
+// Reports without a known CrossID or a Side of the cross and duplicate ExecIDs are ignored.
+func (m *Manager) OnExecutionReport(r executionreport.ExecutionReport) error {
+	execID, err := r.GetExecID()
+	if err != nil {
+		return err
+	}
+	execType, _ := r.GetExecType()
+	crossID, _ := r.GetCrossID()
+	origCrossID, _ := r.GetOrigCrossID()
+	side, _ := r.GetSide()
+
+	m.mu.Lock()
+	defer m.unlock()
+	o, ok := m.byCrossID[crossID]
+	if !ok {
+		o, ok = m.byCrossID[origCrossID]
+	}
+	if !ok || m.execIDs[execID] {
+		return nil
+	}
+	e := o.exec(side)
+	if e == nil {
+		return nil
+	}
+	m.execIDs[execID] = true
+
+	if req := o.request; req != nil && req.crossID == crossID &&
+		(execType == enum.ExecType_REPLACED || execType == enum.ExecType_CANCELED) {
+		req.reported[side] = true
+		if req.reported[o.Buy.Side] && req.reported[o.Sell.Side] {
+			// the request is done once both sides are reported
+			if execType == enum.ExecType_REPLACED {
+				o.Price = req.price
+			}
+			o.Buy, o.Sell = req.buy, req.sell
+			o.CrossID = req.crossID
+			o.CrossIDs = append(o.CrossIDs, req.crossID)
+			o.Pending, o.request = "", nil
+		}
+	}
+	if orderID, err := r.GetOrderID(); err == nil {
+		e.OrderID = orderID
+	}
+	if v, err := r.GetOrdStatus(); err == nil {
+		e.OrdStatus = v
+	}
+	e.CumQty, _ = r.GetCumQty()
+	e.LeavesQty, _ = r.GetLeavesQty()
+	e.AvgPx, _ = r.GetAvgPx()
+	e.Text, _ = r.GetText()
+	o.UpdatedAt = time.Now()
+	m.changed(o)
+	return nil
+}
+
+// OnOrderCancelReject clears the pending replace or cancel rejected by r, whose ClOrdID is the
+// CrossID of the request or the ClOrdID of one of its sides. Other rejects are ignored.
+func (m *Manager) OnOrderCancelReject(r ordercancelreject.OrderCancelReject) error {
+	clOrdID, err := r.GetClOrdID()
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.unlock()
+	for _, o := range m.orders {
+		req := o.request
+		if req == nil || clOrdID != req.crossID && clOrdID != req.buy.ClOrdID && clOrdID != req.sell.ClOrdID {
+			continue
+		}
+		delete(m.byCrossID, req.crossID)
+		o.Pending, o.request = "", nil
+		o.UpdatedAt = time.Now()
+		m.changed(o)
+		return nil
+	}
+	return nil
+}
+
+// Order returns a copy of the cross with any of its CrossIDs
+func (m *Manager) Order(crossID string) (Order, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.byCrossID[crossID]
+	if !ok {
+		return Order{}, false
+	}
+	return o.clone(), true
+}
+
+// Orders returns copies of all the crosses, in the order they were submitted
+func (m *Manager) Orders() []Order {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	os := make([]Order, 0, len(m.orders))
+	for _, o := range m.orders {
+		os = append(os, o.clone())
+	}
+	return os
+}
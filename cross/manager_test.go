@@ -0,0 +1,192 @@
+package cross
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/crossordercancelreplacerequest"
+	"github.com/quickfixgo/fix44/crossordercancelrequest"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/newordercross"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+// putThrough returns a Manager with the band [17, 19] on VND whose sends are appended to sent
+func putThrough(sent *[]*quickfix.Message) *Manager {
+	m := New(quickfix.SessionID{SenderCompID: "FIRM", TargetCompID: "HNX"})
+	m.idPrefix = "x"
+	m.SetBand("VND", Band{Floor: decimal.NewFromInt(17), Ceiling: decimal.NewFromInt(19)})
+	m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+		*sent = append(*sent, msg.ToMessage())
+		return nil
+	}
+	return m
+}
+
+func deal(px, qty string) Cross {
+	q := decimal.RequireFromString(qty)
+	return Cross{Symbol: "VND", Price: decimal.RequireFromString(px), Buy: Side{OrderQty: q}, Sell: Side{OrderQty: q}}
+}
+
+func report(execID, crossID string, side enum.Side, execType enum.ExecType, status enum.OrdStatus) *quickfix.Message {
+	r := executionreport.New(field.NewOrderID("o-"+string(side)), field.NewExecID(execID), field.NewExecType(execType),
+		field.NewOrdStatus(status), field.NewSide(side), field.NewLeavesQty(decimal.Zero, 0),
+		field.NewCumQty(decimal.Zero, 0), field.NewAvgPx(decimal.Zero, 0))
+	r.SetCrossID(crossID)
+	return r.ToMessage()
+}
+
+func TestSubmit(t *testing.T) {
+	tests := []struct {
+		name    string
+		cross   Cross
+		wantErr string
+	}{
+		{"in band", deal("18.5", "1000"), ""},
+		{"on the ceiling", deal("19", "1000"), ""},
+		{"above the band", deal("19.1", "1000"), "cross: price 19.1 of VND is outside the put-through band [17, 19]"},
+		{"unknown band", Cross{Symbol: "SHB", Price: decimal.NewFromInt(10), Buy: Side{OrderQty: decimal.NewFromInt(1)},
+			Sell: Side{OrderQty: decimal.NewFromInt(1)}}, ErrNoBand.Error()},
+		{"different quantities", Cross{Symbol: "VND", Price: decimal.NewFromInt(18), Buy: Side{OrderQty: decimal.NewFromInt(1)},
+			Sell: Side{OrderQty: decimal.NewFromInt(2)}}, ErrInvalidSides.Error()},
+		{"no quantity", deal("18", "0"), ErrInvalidSides.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []*quickfix.Message
+			m := putThrough(&sent)
+			_, err := m.Submit(tt.cross)
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Fatalf("got %q, want %q", got, tt.wantErr)
+			}
+			if want := map[bool]int{true: 1, false: 0}[err == nil]; len(sent) != want {
+				t.Errorf("got %d messages sent, want %d", len(sent), want)
+			}
+		})
+	}
+}
+
+// sideQtys returns the OrderQty of the NoSides of a cross message
+func sideQtys(t *testing.T, msg *quickfix.Message) []string {
+	var qtys []string
+	add := func(row *quickfix.Group) {
+		qty, _ := row.GetString(tag.OrderQty)
+		qtys = append(qtys, qty)
+	}
+	msgType, _ := msg.Header.GetString(tag.MsgType)
+	switch msgType {
+	case "s":
+		g, err := newordercross.FromMessage(msg).GetNoSides()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range g.All() {
+			add(row.Group)
+		}
+	case "t":
+		g, err := crossordercancelreplacerequest.FromMessage(msg).GetNoSides()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range g.All() {
+			add(row.Group)
+		}
+	case "u":
+		g, err := crossordercancelrequest.FromMessage(msg).GetNoSides()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range g.All() {
+			add(row.Group)
+		}
+	}
+	return qtys
+}
+
+func TestScales(t *testing.T) {
+	var sent []*quickfix.Message
+	m := putThrough(&sent)
+	id, err := m.Submit(deal("18.125", "2.5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Replace(id, decimal.RequireFromString("18.25"), decimal.RequireFromString("3.75")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Cancel(id); err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ px, qty string }{{"18.125", "2.5"}, {"18.25", "3.75"}, {"", "2.5"}}
+	for i, w := range want {
+		px, _ := sent[i].Body.GetString(tag.Price)
+		qtys := sideQtys(t, sent[i])
+		if len(qtys) != 2 {
+			t.Fatalf("message %d: got %d sides, want 2", i, len(qtys))
+		}
+		for k, qty := range qtys {
+			if px != w.px || qty != w.qty {
+				t.Errorf("message %d side %d: got %s@%s, want %s@%s", i, k, qty, px, w.qty, w.px)
+			}
+		}
+	}
+}
+
+func TestExecutionReport(t *testing.T) {
+	var sent []*quickfix.Message
+	m := putThrough(&sent)
+	id, err := m.Submit(deal("18", "1000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports := []*quickfix.Message{
+		report("e1", id, enum.Side_BUY, enum.ExecType_NEW, enum.OrdStatus_NEW),
+		report("e2", id, enum.Side_SELL_SHORT, enum.ExecType_NEW, enum.OrdStatus_REJECTED),
+		report("e3", id, enum.Side_SELL, enum.ExecType_NEW, enum.OrdStatus_NEW),
+	}
+	for _, r := range reports {
+		if err := m.Process(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	o, _ := m.Order(id)
+	if o.BuyExec.OrdStatus != enum.OrdStatus_NEW || o.SellExec.OrdStatus != enum.OrdStatus_NEW {
+		t.Errorf("got buy %s sell %s, want both new", o.BuyExec.OrdStatus, o.SellExec.OrdStatus)
+	}
+	if o.SellExec.OrderID != "o-2" {
+		t.Errorf("got sell OrderID %s, want o-2", o.SellExec.OrderID)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	var sent []*quickfix.Message
+	m := putThrough(&sent)
+	id, _ := m.Submit(deal("18", "1000"))
+	if _, err := m.Replace(id, decimal.NewFromInt(20), decimal.NewFromInt(1000)); err == nil {
+		t.Fatal("got a replace outside the band")
+	}
+	newID, err := m.Replace(id, decimal.RequireFromString("18.5"), decimal.NewFromInt(500))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Process(report("e1", newID, enum.Side_BUY, enum.ExecType_REPLACED, enum.OrdStatus_REPLACED)); err != nil {
+		t.Fatal(err)
+	}
+	if o, _ := m.Order(id); o.Pending != newID || o.CrossID != id {
+		t.Errorf("got CrossID %s pending %s, want the replace pending until both sides are reported", o.CrossID, o.Pending)
+	}
+	if err := m.Process(report("e2", newID, enum.Side_SELL, enum.ExecType_REPLACED, enum.OrdStatus_REPLACED)); err != nil {
+		t.Fatal(err)
+	}
+	o, _ := m.Order(id)
+	if o.Pending != "" || o.CrossID != newID || !o.Price.Equal(decimal.RequireFromString("18.5")) ||
+		!o.Sell.OrderQty.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("got %+v, want the replace applied", o)
+	}
+}
@@ -2,6 +2,7 @@ package derivativesecuritylist
 
 import (
 	"github.com/shopspring/decimal"
+	"iter"
 
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
@@ -2111,6 +2112,44 @@ func (m NoSecurityAltIDRepeatingGroup) Get(i int) NoSecurityAltID {
 	return NoSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoSecurityAltID of each entry in the NoSecurityAltIDRepeatingGroup
+func (m NoSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoSecurityAltID] {
+	return func(yield func(int, NoSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoSecurityAltID entries of the NoSecurityAltIDRepeatingGroup as a slice
+func (m NoSecurityAltIDRepeatingGroup) Slice() []NoSecurityAltID {
+	s := make([]NoSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoSecurityAltIDRow holds the values of a NoSecurityAltID, nil fields are left unset by AddRow
+type NoSecurityAltIDRow struct {
+	SecurityAltID       *string
+	SecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoSecurityAltID to this group, setting the fields present in row
+func (m NoSecurityAltIDRepeatingGroup) AddRow(row NoSecurityAltIDRow) NoSecurityAltID {
+	g := m.Add()
+	if row.SecurityAltID != nil {
+		g.SetSecurityAltID(*row.SecurityAltID)
+	}
+	if row.SecurityAltIDSource != nil {
+		g.SetSecurityAltIDSource(*row.SecurityAltIDSource)
+	}
+	return g
+}
+
 //NoEvents is a repeating group element, Tag 864
 type NoEvents struct {
 	*quickfix.Group
@@ -2215,6 +2254,52 @@ func (m NoEventsRepeatingGroup) Get(i int) NoEvents {
 	return NoEvents{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoEvents of each entry in the NoEventsRepeatingGroup
+func (m NoEventsRepeatingGroup) All() iter.Seq2[int, NoEvents] {
+	return func(yield func(int, NoEvents) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoEvents entries of the NoEventsRepeatingGroup as a slice
+func (m NoEventsRepeatingGroup) Slice() []NoEvents {
+	s := make([]NoEvents, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoEventsRow holds the values of a NoEvents, nil fields are left unset by AddRow
+type NoEventsRow struct {
+	EventType *enum.EventType
+	EventDate *string
+	EventPx   *quickfix.FIXDecimal
+	EventText *string
+}
+
+//AddRow creates and appends a new NoEvents to this group, setting the fields present in row
+func (m NoEventsRepeatingGroup) AddRow(row NoEventsRow) NoEvents {
+	g := m.Add()
+	if row.EventType != nil {
+		g.SetEventType(*row.EventType)
+	}
+	if row.EventDate != nil {
+		g.SetEventDate(*row.EventDate)
+	}
+	if row.EventPx != nil {
+		g.SetEventPx(row.EventPx.Decimal, row.EventPx.Scale)
+	}
+	if row.EventText != nil {
+		g.SetEventText(*row.EventText)
+	}
+	return g
+}
+
 //NoInstrAttrib is a repeating group element, Tag 870
 type NoInstrAttrib struct {
 	*quickfix.Group
@@ -2281,6 +2366,44 @@ func (m NoInstrAttribRepeatingGroup) Get(i int) NoInstrAttrib {
 	return NoInstrAttrib{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoInstrAttrib of each entry in the NoInstrAttribRepeatingGroup
+func (m NoInstrAttribRepeatingGroup) All() iter.Seq2[int, NoInstrAttrib] {
+	return func(yield func(int, NoInstrAttrib) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoInstrAttrib entries of the NoInstrAttribRepeatingGroup as a slice
+func (m NoInstrAttribRepeatingGroup) Slice() []NoInstrAttrib {
+	s := make([]NoInstrAttrib, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoInstrAttribRow holds the values of a NoInstrAttrib, nil fields are left unset by AddRow
+type NoInstrAttribRow struct {
+	InstrAttribType  *enum.InstrAttribType
+	InstrAttribValue *string
+}
+
+//AddRow creates and appends a new NoInstrAttrib to this group, setting the fields present in row
+func (m NoInstrAttribRepeatingGroup) AddRow(row NoInstrAttribRow) NoInstrAttrib {
+	g := m.Add()
+	if row.InstrAttribType != nil {
+		g.SetInstrAttribType(*row.InstrAttribType)
+	}
+	if row.InstrAttribValue != nil {
+		g.SetInstrAttribValue(*row.InstrAttribValue)
+	}
+	return g
+}
+
 //NoLegs is a repeating group element, Tag 555
 type NoLegs struct {
 	*quickfix.Group
@@ -3148,6 +3271,44 @@ func (m NoLegSecurityAltIDRepeatingGroup) Get(i int) NoLegSecurityAltID {
 	return NoLegSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoLegSecurityAltID of each entry in the NoLegSecurityAltIDRepeatingGroup
+func (m NoLegSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoLegSecurityAltID] {
+	return func(yield func(int, NoLegSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoLegSecurityAltID entries of the NoLegSecurityAltIDRepeatingGroup as a slice
+func (m NoLegSecurityAltIDRepeatingGroup) Slice() []NoLegSecurityAltID {
+	s := make([]NoLegSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoLegSecurityAltIDRow holds the values of a NoLegSecurityAltID, nil fields are left unset by AddRow
+type NoLegSecurityAltIDRow struct {
+	LegSecurityAltID       *string
+	LegSecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoLegSecurityAltID to this group, setting the fields present in row
+func (m NoLegSecurityAltIDRepeatingGroup) AddRow(row NoLegSecurityAltIDRow) NoLegSecurityAltID {
+	g := m.Add()
+	if row.LegSecurityAltID != nil {
+		g.SetLegSecurityAltID(*row.LegSecurityAltID)
+	}
+	if row.LegSecurityAltIDSource != nil {
+		g.SetLegSecurityAltIDSource(*row.LegSecurityAltIDSource)
+	}
+	return g
+}
+
 //NoLegsRepeatingGroup is a repeating group, Tag 555
 type NoLegsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -3171,6 +3332,208 @@ func (m NoLegsRepeatingGroup) Get(i int) NoLegs {
 	return NoLegs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoLegs of each entry in the NoLegsRepeatingGroup
+func (m NoLegsRepeatingGroup) All() iter.Seq2[int, NoLegs] {
+	return func(yield func(int, NoLegs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoLegs entries of the NoLegsRepeatingGroup as a slice
+func (m NoLegsRepeatingGroup) Slice() []NoLegs {
+	s := make([]NoLegs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoLegsRow holds the values of a NoLegs, nil fields are left unset by AddRow
+type NoLegsRow struct {
+	LegSymbol                     *string
+	LegSymbolSfx                  *string
+	LegSecurityID                 *string
+	LegSecurityIDSource           *string
+	NoLegSecurityAltID            []NoLegSecurityAltIDRow
+	LegProduct                    *int
+	LegCFICode                    *string
+	LegSecurityType               *string
+	LegSecuritySubType            *string
+	LegMaturityMonthYear          *string
+	LegMaturityDate               *string
+	LegCouponPaymentDate          *string
+	LegIssueDate                  *string
+	LegRepoCollateralSecurityType *int
+	LegRepurchaseTerm             *int
+	LegRepurchaseRate             *quickfix.FIXDecimal
+	LegFactor                     *quickfix.FIXDecimal
+	LegCreditRating               *string
+	LegInstrRegistry              *string
+	LegCountryOfIssue             *string
+	LegStateOrProvinceOfIssue     *string
+	LegLocaleOfIssue              *string
+	LegRedemptionDate             *string
+	LegStrikePrice                *quickfix.FIXDecimal
+	LegStrikeCurrency             *string
+	LegOptAttribute               *string
+	LegContractMultiplier         *quickfix.FIXDecimal
+	LegCouponRate                 *quickfix.FIXDecimal
+	LegSecurityExchange           *string
+	LegIssuer                     *string
+	EncodedLegIssuerLen           *int
+	EncodedLegIssuer              *string
+	LegSecurityDesc               *string
+	EncodedLegSecurityDescLen     *int
+	EncodedLegSecurityDesc        *string
+	LegRatioQty                   *quickfix.FIXDecimal
+	LegSide                       *string
+	LegCurrency                   *string
+	LegPool                       *string
+	LegDatedDate                  *string
+	LegContractSettlMonth         *string
+	LegInterestAccrualDate        *string
+}
+
+//AddRow creates and appends a new NoLegs to this group, setting the fields present in row
+func (m NoLegsRepeatingGroup) AddRow(row NoLegsRow) NoLegs {
+	g := m.Add()
+	if row.LegSymbol != nil {
+		g.SetLegSymbol(*row.LegSymbol)
+	}
+	if row.LegSymbolSfx != nil {
+		g.SetLegSymbolSfx(*row.LegSymbolSfx)
+	}
+	if row.LegSecurityID != nil {
+		g.SetLegSecurityID(*row.LegSecurityID)
+	}
+	if row.LegSecurityIDSource != nil {
+		g.SetLegSecurityIDSource(*row.LegSecurityIDSource)
+	}
+	if len(row.NoLegSecurityAltID) > 0 {
+		f := NewNoLegSecurityAltIDRepeatingGroup()
+		for _, r := range row.NoLegSecurityAltID {
+			f.AddRow(r)
+		}
+		g.SetNoLegSecurityAltID(f)
+	}
+	if row.LegProduct != nil {
+		g.SetLegProduct(*row.LegProduct)
+	}
+	if row.LegCFICode != nil {
+		g.SetLegCFICode(*row.LegCFICode)
+	}
+	if row.LegSecurityType != nil {
+		g.SetLegSecurityType(*row.LegSecurityType)
+	}
+	if row.LegSecuritySubType != nil {
+		g.SetLegSecuritySubType(*row.LegSecuritySubType)
+	}
+	if row.LegMaturityMonthYear != nil {
+		g.SetLegMaturityMonthYear(*row.LegMaturityMonthYear)
+	}
+	if row.LegMaturityDate != nil {
+		g.SetLegMaturityDate(*row.LegMaturityDate)
+	}
+	if row.LegCouponPaymentDate != nil {
+		g.SetLegCouponPaymentDate(*row.LegCouponPaymentDate)
+	}
+	if row.LegIssueDate != nil {
+		g.SetLegIssueDate(*row.LegIssueDate)
+	}
+	if row.LegRepoCollateralSecurityType != nil {
+		g.SetLegRepoCollateralSecurityType(*row.LegRepoCollateralSecurityType)
+	}
+	if row.LegRepurchaseTerm != nil {
+		g.SetLegRepurchaseTerm(*row.LegRepurchaseTerm)
+	}
+	if row.LegRepurchaseRate != nil {
+		g.SetLegRepurchaseRate(row.LegRepurchaseRate.Decimal, row.LegRepurchaseRate.Scale)
+	}
+	if row.LegFactor != nil {
+		g.SetLegFactor(row.LegFactor.Decimal, row.LegFactor.Scale)
+	}
+	if row.LegCreditRating != nil {
+		g.SetLegCreditRating(*row.LegCreditRating)
+	}
+	if row.LegInstrRegistry != nil {
+		g.SetLegInstrRegistry(*row.LegInstrRegistry)
+	}
+	if row.LegCountryOfIssue != nil {
+		g.SetLegCountryOfIssue(*row.LegCountryOfIssue)
+	}
+	if row.LegStateOrProvinceOfIssue != nil {
+		g.SetLegStateOrProvinceOfIssue(*row.LegStateOrProvinceOfIssue)
+	}
+	if row.LegLocaleOfIssue != nil {
+		g.SetLegLocaleOfIssue(*row.LegLocaleOfIssue)
+	}
+	if row.LegRedemptionDate != nil {
+		g.SetLegRedemptionDate(*row.LegRedemptionDate)
+	}
+	if row.LegStrikePrice != nil {
+		g.SetLegStrikePrice(row.LegStrikePrice.Decimal, row.LegStrikePrice.Scale)
+	}
+	if row.LegStrikeCurrency != nil {
+		g.SetLegStrikeCurrency(*row.LegStrikeCurrency)
+	}
+	if row.LegOptAttribute != nil {
+		g.SetLegOptAttribute(*row.LegOptAttribute)
+	}
+	if row.LegContractMultiplier != nil {
+		g.SetLegContractMultiplier(row.LegContractMultiplier.Decimal, row.LegContractMultiplier.Scale)
+	}
+	if row.LegCouponRate != nil {
+		g.SetLegCouponRate(row.LegCouponRate.Decimal, row.LegCouponRate.Scale)
+	}
+	if row.LegSecurityExchange != nil {
+		g.SetLegSecurityExchange(*row.LegSecurityExchange)
+	}
+	if row.LegIssuer != nil {
+		g.SetLegIssuer(*row.LegIssuer)
+	}
+	if row.EncodedLegIssuerLen != nil {
+		g.SetEncodedLegIssuerLen(*row.EncodedLegIssuerLen)
+	}
+	if row.EncodedLegIssuer != nil {
+		g.SetEncodedLegIssuer(*row.EncodedLegIssuer)
+	}
+	if row.LegSecurityDesc != nil {
+		g.SetLegSecurityDesc(*row.LegSecurityDesc)
+	}
+	if row.EncodedLegSecurityDescLen != nil {
+		g.SetEncodedLegSecurityDescLen(*row.EncodedLegSecurityDescLen)
+	}
+	if row.EncodedLegSecurityDesc != nil {
+		g.SetEncodedLegSecurityDesc(*row.EncodedLegSecurityDesc)
+	}
+	if row.LegRatioQty != nil {
+		g.SetLegRatioQty(row.LegRatioQty.Decimal, row.LegRatioQty.Scale)
+	}
+	if row.LegSide != nil {
+		g.SetLegSide(*row.LegSide)
+	}
+	if row.LegCurrency != nil {
+		g.SetLegCurrency(*row.LegCurrency)
+	}
+	if row.LegPool != nil {
+		g.SetLegPool(*row.LegPool)
+	}
+	if row.LegDatedDate != nil {
+		g.SetLegDatedDate(*row.LegDatedDate)
+	}
+	if row.LegContractSettlMonth != nil {
+		g.SetLegContractSettlMonth(*row.LegContractSettlMonth)
+	}
+	if row.LegInterestAccrualDate != nil {
+		g.SetLegInterestAccrualDate(*row.LegInterestAccrualDate)
+	}
+	return g
+}
+
 //NoRelatedSymRepeatingGroup is a repeating group, Tag 146
 type NoRelatedSymRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -3194,6 +3557,264 @@ func (m NoRelatedSymRepeatingGroup) Get(i int) NoRelatedSym {
 	return NoRelatedSym{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoRelatedSym of each entry in the NoRelatedSymRepeatingGroup
+func (m NoRelatedSymRepeatingGroup) All() iter.Seq2[int, NoRelatedSym] {
+	return func(yield func(int, NoRelatedSym) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoRelatedSym entries of the NoRelatedSymRepeatingGroup as a slice
+func (m NoRelatedSymRepeatingGroup) Slice() []NoRelatedSym {
+	s := make([]NoRelatedSym, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoRelatedSymRow holds the values of a NoRelatedSym, nil fields are left unset by AddRow
+type NoRelatedSymRow struct {
+	Symbol                     *string
+	SymbolSfx                  *enum.SymbolSfx
+	SecurityID                 *string
+	SecurityIDSource           *enum.SecurityIDSource
+	NoSecurityAltID            []NoSecurityAltIDRow
+	Product                    *enum.Product
+	CFICode                    *string
+	SecurityType               *enum.SecurityType
+	SecuritySubType            *string
+	MaturityMonthYear          *string
+	MaturityDate               *string
+	CouponPaymentDate          *string
+	IssueDate                  *string
+	RepoCollateralSecurityType *int
+	RepurchaseTerm             *int
+	RepurchaseRate             *quickfix.FIXDecimal
+	Factor                     *quickfix.FIXDecimal
+	CreditRating               *string
+	InstrRegistry              *enum.InstrRegistry
+	CountryOfIssue             *string
+	StateOrProvinceOfIssue     *string
+	LocaleOfIssue              *string
+	RedemptionDate             *string
+	StrikePrice                *quickfix.FIXDecimal
+	StrikeCurrency             *string
+	OptAttribute               *string
+	ContractMultiplier         *quickfix.FIXDecimal
+	CouponRate                 *quickfix.FIXDecimal
+	SecurityExchange           *string
+	Issuer                     *string
+	EncodedIssuerLen           *int
+	EncodedIssuer              *string
+	SecurityDesc               *string
+	EncodedSecurityDescLen     *int
+	EncodedSecurityDesc        *string
+	Pool                       *string
+	ContractSettlMonth         *string
+	CPProgram                  *enum.CPProgram
+	CPRegType                  *string
+	NoEvents                   []NoEventsRow
+	DatedDate                  *string
+	InterestAccrualDate        *string
+	Currency                   *string
+	ExpirationCycle            *enum.ExpirationCycle
+	DeliveryForm               *enum.DeliveryForm
+	PctAtRisk                  *quickfix.FIXDecimal
+	NoInstrAttrib              []NoInstrAttribRow
+	NoLegs                     []NoLegsRow
+	TradingSessionID           *enum.TradingSessionID
+	TradingSessionSubID        *enum.TradingSessionSubID
+	Text                       *string
+	EncodedTextLen             *int
+	EncodedText                *string
+}
+
+//AddRow creates and appends a new NoRelatedSym to this group, setting the fields present in row
+func (m NoRelatedSymRepeatingGroup) AddRow(row NoRelatedSymRow) NoRelatedSym {
+	g := m.Add()
+	if row.Symbol != nil {
+		g.SetSymbol(*row.Symbol)
+	}
+	if row.SymbolSfx != nil {
+		g.SetSymbolSfx(*row.SymbolSfx)
+	}
+	if row.SecurityID != nil {
+		g.SetSecurityID(*row.SecurityID)
+	}
+	if row.SecurityIDSource != nil {
+		g.SetSecurityIDSource(*row.SecurityIDSource)
+	}
+	if len(row.NoSecurityAltID) > 0 {
+		f := NewNoSecurityAltIDRepeatingGroup()
+		for _, r := range row.NoSecurityAltID {
+			f.AddRow(r)
+		}
+		g.SetNoSecurityAltID(f)
+	}
+	if row.Product != nil {
+		g.SetProduct(*row.Product)
+	}
+	if row.CFICode != nil {
+		g.SetCFICode(*row.CFICode)
+	}
+	if row.SecurityType != nil {
+		g.SetSecurityType(*row.SecurityType)
+	}
+	if row.SecuritySubType != nil {
+		g.SetSecuritySubType(*row.SecuritySubType)
+	}
+	if row.MaturityMonthYear != nil {
+		g.SetMaturityMonthYear(*row.MaturityMonthYear)
+	}
+	if row.MaturityDate != nil {
+		g.SetMaturityDate(*row.MaturityDate)
+	}
+	if row.CouponPaymentDate != nil {
+		g.SetCouponPaymentDate(*row.CouponPaymentDate)
+	}
+	if row.IssueDate != nil {
+		g.SetIssueDate(*row.IssueDate)
+	}
+	if row.RepoCollateralSecurityType != nil {
+		g.SetRepoCollateralSecurityType(*row.RepoCollateralSecurityType)
+	}
+	if row.RepurchaseTerm != nil {
+		g.SetRepurchaseTerm(*row.RepurchaseTerm)
+	}
+	if row.RepurchaseRate != nil {
+		g.SetRepurchaseRate(row.RepurchaseRate.Decimal, row.RepurchaseRate.Scale)
+	}
+	if row.Factor != nil {
+		g.SetFactor(row.Factor.Decimal, row.Factor.Scale)
+	}
+	if row.CreditRating != nil {
+		g.SetCreditRating(*row.CreditRating)
+	}
+	if row.InstrRegistry != nil {
+		g.SetInstrRegistry(*row.InstrRegistry)
+	}
+	if row.CountryOfIssue != nil {
+		g.SetCountryOfIssue(*row.CountryOfIssue)
+	}
+	if row.StateOrProvinceOfIssue != nil {
+		g.SetStateOrProvinceOfIssue(*row.StateOrProvinceOfIssue)
+	}
+	if row.LocaleOfIssue != nil {
+		g.SetLocaleOfIssue(*row.LocaleOfIssue)
+	}
+	if row.RedemptionDate != nil {
+		g.SetRedemptionDate(*row.RedemptionDate)
+	}
+	if row.StrikePrice != nil {
+		g.SetStrikePrice(row.StrikePrice.Decimal, row.StrikePrice.Scale)
+	}
+	if row.StrikeCurrency != nil {
+		g.SetStrikeCurrency(*row.StrikeCurrency)
+	}
+	if row.OptAttribute != nil {
+		g.SetOptAttribute(*row.OptAttribute)
+	}
+	if row.ContractMultiplier != nil {
+		g.SetContractMultiplier(row.ContractMultiplier.Decimal, row.ContractMultiplier.Scale)
+	}
+	if row.CouponRate != nil {
+		g.SetCouponRate(row.CouponRate.Decimal, row.CouponRate.Scale)
+	}
+	if row.SecurityExchange != nil {
+		g.SetSecurityExchange(*row.SecurityExchange)
+	}
+	if row.Issuer != nil {
+		g.SetIssuer(*row.Issuer)
+	}
+	if row.EncodedIssuerLen != nil {
+		g.SetEncodedIssuerLen(*row.EncodedIssuerLen)
+	}
+	if row.EncodedIssuer != nil {
+		g.SetEncodedIssuer(*row.EncodedIssuer)
+	}
+	if row.SecurityDesc != nil {
+		g.SetSecurityDesc(*row.SecurityDesc)
+	}
+	if row.EncodedSecurityDescLen != nil {
+		g.SetEncodedSecurityDescLen(*row.EncodedSecurityDescLen)
+	}
+	if row.EncodedSecurityDesc != nil {
+		g.SetEncodedSecurityDesc(*row.EncodedSecurityDesc)
+	}
+	if row.Pool != nil {
+		g.SetPool(*row.Pool)
+	}
+	if row.ContractSettlMonth != nil {
+		g.SetContractSettlMonth(*row.ContractSettlMonth)
+	}
+	if row.CPProgram != nil {
+		g.SetCPProgram(*row.CPProgram)
+	}
+	if row.CPRegType != nil {
+		g.SetCPRegType(*row.CPRegType)
+	}
+	if len(row.NoEvents) > 0 {
+		f := NewNoEventsRepeatingGroup()
+		for _, r := range row.NoEvents {
+			f.AddRow(r)
+		}
+		g.SetNoEvents(f)
+	}
+	if row.DatedDate != nil {
+		g.SetDatedDate(*row.DatedDate)
+	}
+	if row.InterestAccrualDate != nil {
+		g.SetInterestAccrualDate(*row.InterestAccrualDate)
+	}
+	if row.Currency != nil {
+		g.SetCurrency(*row.Currency)
+	}
+	if row.ExpirationCycle != nil {
+		g.SetExpirationCycle(*row.ExpirationCycle)
+	}
+	if row.DeliveryForm != nil {
+		g.SetDeliveryForm(*row.DeliveryForm)
+	}
+	if row.PctAtRisk != nil {
+		g.SetPctAtRisk(row.PctAtRisk.Decimal, row.PctAtRisk.Scale)
+	}
+	if len(row.NoInstrAttrib) > 0 {
+		f := NewNoInstrAttribRepeatingGroup()
+		for _, r := range row.NoInstrAttrib {
+			f.AddRow(r)
+		}
+		g.SetNoInstrAttrib(f)
+	}
+	if len(row.NoLegs) > 0 {
+		f := NewNoLegsRepeatingGroup()
+		for _, r := range row.NoLegs {
+			f.AddRow(r)
+		}
+		g.SetNoLegs(f)
+	}
+	if row.TradingSessionID != nil {
+		g.SetTradingSessionID(*row.TradingSessionID)
+	}
+	if row.TradingSessionSubID != nil {
+		g.SetTradingSessionSubID(*row.TradingSessionSubID)
+	}
+	if row.Text != nil {
+		g.SetText(*row.Text)
+	}
+	if row.EncodedTextLen != nil {
+		g.SetEncodedTextLen(*row.EncodedTextLen)
+	}
+	if row.EncodedText != nil {
+		g.SetEncodedText(*row.EncodedText)
+	}
+	return g
+}
+
 //NoUnderlyingSecurityAltID is a repeating group element, Tag 457
 type NoUnderlyingSecurityAltID struct {
 	*quickfix.Group
@@ -3260,6 +3881,44 @@ func (m NoUnderlyingSecurityAltIDRepeatingGroup) Get(i int) NoUnderlyingSecurity
 	return NoUnderlyingSecurityAltID{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoUnderlyingSecurityAltID of each entry in the NoUnderlyingSecurityAltIDRepeatingGroup
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) All() iter.Seq2[int, NoUnderlyingSecurityAltID] {
+	return func(yield func(int, NoUnderlyingSecurityAltID) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyingSecurityAltID entries of the NoUnderlyingSecurityAltIDRepeatingGroup as a slice
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) Slice() []NoUnderlyingSecurityAltID {
+	s := make([]NoUnderlyingSecurityAltID, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingSecurityAltIDRow holds the values of a NoUnderlyingSecurityAltID, nil fields are left unset by AddRow
+type NoUnderlyingSecurityAltIDRow struct {
+	UnderlyingSecurityAltID       *string
+	UnderlyingSecurityAltIDSource *string
+}
+
+//AddRow creates and appends a new NoUnderlyingSecurityAltID to this group, setting the fields present in row
+func (m NoUnderlyingSecurityAltIDRepeatingGroup) AddRow(row NoUnderlyingSecurityAltIDRow) NoUnderlyingSecurityAltID {
+	g := m.Add()
+	if row.UnderlyingSecurityAltID != nil {
+		g.SetUnderlyingSecurityAltID(*row.UnderlyingSecurityAltID)
+	}
+	if row.UnderlyingSecurityAltIDSource != nil {
+		g.SetUnderlyingSecurityAltIDSource(*row.UnderlyingSecurityAltIDSource)
+	}
+	return g
+}
+
 //NoUnderlyingStips is a repeating group element, Tag 887
 type NoUnderlyingStips struct {
 	*quickfix.Group
@@ -3325,3 +3984,41 @@ func (m NoUnderlyingStipsRepeatingGroup) Add() NoUnderlyingStips {
 func (m NoUnderlyingStipsRepeatingGroup) Get(i int) NoUnderlyingStips {
 	return NoUnderlyingStips{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoUnderlyingStips of each entry in the NoUnderlyingStipsRepeatingGroup
+func (m NoUnderlyingStipsRepeatingGroup) All() iter.Seq2[int, NoUnderlyingStips] {
+	return func(yield func(int, NoUnderlyingStips) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoUnderlyingStips entries of the NoUnderlyingStipsRepeatingGroup as a slice
+func (m NoUnderlyingStipsRepeatingGroup) Slice() []NoUnderlyingStips {
+	s := make([]NoUnderlyingStips, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoUnderlyingStipsRow holds the values of a NoUnderlyingStips, nil fields are left unset by AddRow
+type NoUnderlyingStipsRow struct {
+	UnderlyingStipType  *string
+	UnderlyingStipValue *string
+}
+
+//AddRow creates and appends a new NoUnderlyingStips to this group, setting the fields present in row
+func (m NoUnderlyingStipsRepeatingGroup) AddRow(row NoUnderlyingStipsRow) NoUnderlyingStips {
+	g := m.Add()
+	if row.UnderlyingStipType != nil {
+		g.SetUnderlyingStipType(*row.UnderlyingStipType)
+	}
+	if row.UnderlyingStipValue != nil {
+		g.SetUnderlyingStipValue(*row.UnderlyingStipValue)
+	}
+	return g
+}
@@ -0,0 +1,118 @@
+package dropcopy
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/tradecapturereport"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// Consumer normalizes the ExecutionReports and TradeCaptureReports of a read-only drop-copy
+// session into Fills written to a Sink. A report already seen, e.g. a PossDup or PossResend
+// copy, is dropped. The reports seen are only remembered until a restart, a Sink returning
+// ErrDuplicate, as DBSink does, drops the reports written before too.
+// The Consumer never sends. A Consumer is safe for concurrent use.
+type Consumer struct {
+	Sink Sink
+	// OnFill, if set, is called with each Fill written, without the Consumer locked
+	OnFill func(Fill)
+
+	mu         sync.Mutex
+	seen       map[string]bool
+	duplicates int
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns a Consumer writing to the sink
+func New(sink Sink) *Consumer {
+	return &Consumer{Sink: sink, seen: make(map[string]bool)}
+}
+
+// Process handles ExecutionReports and TradeCaptureReports, other messages are ignored.
+// It is meant to be called from FromApp.
+func (c *Consumer) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "8":
+		return c.OnExecutionReport(executionreport.FromMessage(msg))
+	case "AE":
+		return c.OnTradeCaptureReport(tradecapturereport.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnExecutionReport writes the Fill of a fill, correction or bust report, other reports are ignored
+func (c *Consumer) OnExecutionReport(r executionreport.ExecutionReport) error {
+	f, ok, err := FromExecutionReport(r)
+	if err != nil || !ok {
+		return err
+	}
+	return c.write([]Fill{f})
+}
+
+// OnTradeCaptureReport writes the Fills of the sides of a TradeCaptureReport
+func (c *Consumer) OnTradeCaptureReport(r tradecapturereport.TradeCaptureReport) error {
+	fills, err := FromTradeCaptureReport(r)
+	if err != nil {
+		return err
+	}
+	return c.write(fills)
+}
+
+func (c *Consumer) write(fills []Fill) error {
+	c.mu.Lock()
+	defer c.unlock()
+	for _, f := range fills {
+		key := f.Source + "|" + f.ID
+		if c.seen[key] {
+			c.duplicates++
+			continue
+		}
+		err := c.Sink.Write(f)
+		if errors.Is(err, ErrDuplicate) {
+			c.seen[key] = true
+			c.duplicates++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		c.seen[key] = true
+		if c.OnFill != nil {
+			c.callbacks = append(c.callbacks, func() { c.OnFill(f) })
+		}
+	}
+	return nil
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (c *Consumer) unlock() {
+	callbacks := c.callbacks
+	c.callbacks = nil
+	c.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// Duplicates returns the number of Fills dropped as already seen
+func (c *Consumer) Duplicates() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.duplicates
+}
+
+// Reset forgets the reports seen, e.g. at the start of a trading day
+func (c *Consumer) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seen = make(map[string]bool)
+	c.duplicates = 0
+}
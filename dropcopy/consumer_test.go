@@ -0,0 +1,193 @@
+package dropcopy
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/tradecapturereport"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+// table is a DB holding the source and id of the rows inserted
+type table struct {
+	rows map[string]bool
+	err  error
+}
+
+func (tb *table) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if tb.err != nil {
+		return nil, tb.err
+	}
+	key := args[len(args)-2].(string) + "|" + args[len(args)-1].(string)
+	if tb.rows[key] {
+		return driver.RowsAffected(0), nil
+	}
+	tb.rows[key] = true
+	return driver.RowsAffected(1), nil
+}
+
+// sinkFunc is a Sink calling the func
+type sinkFunc func(Fill) error
+
+func (f sinkFunc) Write(fill Fill) error { return f(fill) }
+
+// execution returns an ExecutionReport of the ExecType, resent if possDup
+func execution(execID string, execType enum.ExecType, possDup bool) *quickfix.Message {
+	r := executionreport.New(field.NewOrderID("O1"), field.NewExecID(execID), field.NewExecType(execType),
+		field.NewOrdStatus(enum.OrdStatus_PARTIALLY_FILLED), field.NewSide(enum.Side_BUY), field.NewLeavesQty(decimal.NewFromInt(100), 0),
+		field.NewCumQty(decimal.NewFromInt(100), 0), field.NewAvgPx(decimal.NewFromInt(10), 0))
+	r.SetSymbol("VND")
+	r.SetLastQty(decimal.NewFromInt(100), 0)
+	r.SetLastPx(decimal.NewFromInt(10), 0)
+	msg := r.ToMessage()
+	if possDup {
+		msg.Header.SetBool(tag.PossDupFlag, true)
+	}
+	return msg
+}
+
+// trade returns a TradeCaptureReport of a buy and a sell side
+func trade(tradeReportID string) *quickfix.Message {
+	r := tradecapturereport.New(field.NewTradeReportID(tradeReportID), field.NewPreviouslyReported(false),
+		field.NewLastQty(decimal.NewFromInt(5), 0), field.NewLastPx(decimal.NewFromInt(7), 0),
+		field.NewTradeDate("20261019"), field.NewTransactTime(time.Now()))
+	sides := tradecapturereport.NewNoSidesRepeatingGroup()
+	sides.Add().SetSide(enum.Side_BUY)
+	sides.Add().SetSide(enum.Side_SELL)
+	r.SetNoSides(sides)
+	return r.ToMessage()
+}
+
+func TestConsumerDuplicates(t *testing.T) {
+	tests := []struct {
+		name           string
+		msgs           []*quickfix.Message
+		want           int
+		wantDuplicates int
+	}{
+		{"fills", []*quickfix.Message{execution("E1", enum.ExecType_TRADE, false), execution("E2", enum.ExecType_TRADE, false)}, 2, 0},
+		{"resent", []*quickfix.Message{execution("E1", enum.ExecType_TRADE, false), execution("E1", enum.ExecType_TRADE, true)}, 1, 1},
+		{"not a fill", []*quickfix.Message{execution("E1", enum.ExecType_NEW, false)}, 0, 0},
+		{"correction", []*quickfix.Message{execution("E1", enum.ExecType_TRADE, false), execution("E2", enum.ExecType_TRADE_CORRECT, false)}, 2, 0},
+		{"trade sides", []*quickfix.Message{trade("T1")}, 2, 0},
+		{"trade resent", []*quickfix.Message{trade("T1"), trade("T1")}, 2, 2},
+		{"same ID of another source", []*quickfix.Message{execution("T1", enum.ExecType_TRADE, false), trade("T1")}, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var written []Fill
+			c := New(sinkFunc(func(f Fill) error {
+				written = append(written, f)
+				return nil
+			}))
+			var filled int
+			c.OnFill = func(Fill) { filled++ }
+			for _, msg := range tt.msgs {
+				if err := c.Process(msg); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(written) != tt.want || filled != tt.want || c.Duplicates() != tt.wantDuplicates {
+				t.Errorf("got %d written, %d OnFill and %d duplicates, want %d and %d duplicates",
+					len(written), filled, c.Duplicates(), tt.want, tt.wantDuplicates)
+			}
+			c.Reset()
+			if c.Duplicates() != 0 {
+				t.Errorf("got %d duplicates after Reset", c.Duplicates())
+			}
+		})
+	}
+}
+
+func TestConsumerRestart(t *testing.T) {
+	tb := &table{rows: make(map[string]bool)}
+	if err := New(NewDBSink(tb, "fills")).Process(execution("E1", enum.ExecType_TRADE, false)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the Consumer of a restart drops the fills the table already holds
+	c := New(NewDBSink(tb, "fills"))
+	var filled []string
+	c.OnFill = func(f Fill) { filled = append(filled, f.ID) }
+	for _, execID := range []string{"E1", "E2", "E1"} {
+		if err := c.Process(execution(execID, enum.ExecType_TRADE, true)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(filled, []string{"E2"}) || c.Duplicates() != 2 {
+		t.Errorf("got %v written and %d duplicates", filled, c.Duplicates())
+	}
+}
+
+func TestOnFillUnlocked(t *testing.T) {
+	c := New(sinkFunc(func(Fill) error { return nil }))
+	var duplicates []int
+	c.OnFill = func(Fill) { duplicates = append(duplicates, c.Duplicates()) }
+	for _, msg := range []*quickfix.Message{trade("T1"), trade("T1"), execution("E1", enum.ExecType_TRADE, false)} {
+		if err := c.Process(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []int{0, 0, 2}; !reflect.DeepEqual(duplicates, want) {
+		t.Errorf("got %v duplicates seen by OnFill, want %v", duplicates, want)
+	}
+}
+
+func TestDBSinkWrite(t *testing.T) {
+	errDB := errors.New("connection lost")
+	tests := []struct {
+		name string
+		db   *table
+		want error
+	}{
+		{"inserted", &table{rows: map[string]bool{}}, nil},
+		{"already inserted", &table{rows: map[string]bool{"8|E1": true}}, ErrDuplicate},
+		{"other source", &table{rows: map[string]bool{"AE|E1": true}}, nil},
+		{"failed", &table{err: errDB}, errDB},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewDBSink(tt.db, "fills")
+			if err := s.Write(Fill{Source: "8", ID: "E1"}); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// query is a DB recording the statement executed, of a driver not reporting the affected rows
+type query struct {
+	stmt string
+	args []any
+}
+
+func (q *query) ExecContext(ctx context.Context, stmt string, args ...any) (sql.Result, error) {
+	q.stmt, q.args = stmt, args
+	return driver.ResultNoRows, nil
+}
+
+func TestDBSinkPlaceholders(t *testing.T) {
+	q := &query{}
+	s := NewDBSink(q, "fills")
+	s.Placeholder = func(i int) string { return "$" + strconv.Itoa(i) }
+	if err := s.Write(Fill{Source: "8", ID: "E1"}); err != nil {
+		t.Fatalf("got %v from a driver not reporting the affected rows", err)
+	}
+	n := len(columns)
+	where := "WHERE source = $" + strconv.Itoa(n+1) + " AND id = $" + strconv.Itoa(n+2) + ")"
+	if !strings.HasSuffix(q.stmt, where) || len(q.args) != n+2 {
+		t.Errorf("got %s with %d args", q.stmt, len(q.args))
+	}
+}
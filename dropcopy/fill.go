@@ -0,0 +1,148 @@
+package dropcopy
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/tradecapturereport"
+	"github.com/shopspring/decimal"
+)
+
+// Fill is a normalized blotter record, of an ExecutionReport or of a side of a TradeCaptureReport
+type Fill struct {
+	// Source is the MsgType of the report, 8 or AE
+	Source string
+	// ID identifies the record: the ExecID of an ExecutionReport,
+	// the TradeReportID and the side index of a TradeCaptureReport
+	ID string
+	// ExecType is TRADE for a new fill, TRADE_CORRECT or TRADE_CANCEL for a
+	// correction or a bust of the fill with the RefID
+	ExecType enum.ExecType
+	RefID    string
+	ExecID   string
+	// TrdMatchID is only reported by TradeCaptureReports, FIX 4.4 ExecutionReports have none
+	TrdMatchID string
+	OrderID    string
+	ClOrdID    string
+	Account    string
+	Symbol     string
+	Side       enum.Side
+	Qty        decimal.Decimal
+	Price      decimal.Decimal
+	Currency   string
+	// Fees is the Commission plus the MiscFeeAmts
+	Fees         decimal.Decimal
+	TradeDate    string
+	TransactTime time.Time
+	ReceivedAt   time.Time
+}
+
+// Value returns the traded amount, Qty times Price
+func (f Fill) Value() decimal.Decimal {
+	return f.Qty.Mul(f.Price)
+}
+
+// isFill returns true for the ExecTypes of the ExecutionReports of fills, corrections and busts
+func isFill(execType enum.ExecType) bool {
+	switch execType {
+	case enum.ExecType_TRADE, enum.ExecType_TRADE_CORRECT, enum.ExecType_TRADE_CANCEL:
+		return true
+	}
+	return false
+}
+
+// FromExecutionReport returns the Fill of an ExecutionReport, ok is false for reports other than fills
+func FromExecutionReport(r executionreport.ExecutionReport) (f Fill, ok bool, err error) {
+	execType, rerr := r.GetExecType()
+	if rerr != nil {
+		return Fill{}, false, rerr
+	}
+	if !isFill(execType) {
+		return Fill{}, false, nil
+	}
+	f.Source, f.ExecType = "8", execType
+	if f.ExecID, rerr = r.GetExecID(); rerr != nil {
+		return Fill{}, false, rerr
+	}
+	f.ID = f.ExecID
+	f.RefID, _ = r.GetExecRefID()
+	f.OrderID, _ = r.GetOrderID()
+	f.ClOrdID, _ = r.GetClOrdID()
+	f.Account, _ = r.GetAccount()
+	f.Symbol, _ = r.GetSymbol()
+	f.Side, _ = r.GetSide()
+	f.Qty, _ = r.GetLastQty()
+	f.Price, _ = r.GetLastPx()
+	f.Currency, _ = r.GetCurrency()
+	f.Fees, _ = r.GetCommission()
+	if g, err := r.GetNoMiscFees(); err == nil {
+		for _, fee := range g.All() {
+			amt, _ := fee.GetMiscFeeAmt()
+			f.Fees = f.Fees.Add(amt)
+		}
+	}
+	f.TradeDate, _ = r.GetTradeDate()
+	f.TransactTime, _ = r.GetTransactTime()
+	f.ReceivedAt = time.Now()
+	return f, true, nil
+}
+
+// execTypeOf returns the ExecType of a TradeCaptureReport, from its TradeReportTransType if absent
+func execTypeOf(r tradecapturereport.TradeCaptureReport) enum.ExecType {
+	if execType, err := r.GetExecType(); err == nil && isFill(execType) {
+		return execType
+	}
+	transType, _ := r.GetTradeReportTransType()
+	switch transType {
+	case enum.TradeReportTransType_CANCEL:
+		return enum.ExecType_TRADE_CANCEL
+	case enum.TradeReportTransType_REPLACE:
+		return enum.ExecType_TRADE_CORRECT
+	}
+	return enum.ExecType_TRADE
+}
+
+// FromTradeCaptureReport returns the Fills of the sides of a TradeCaptureReport
+func FromTradeCaptureReport(r tradecapturereport.TradeCaptureReport) ([]Fill, error) {
+	var base Fill
+	base.Source, base.ExecType = "AE", execTypeOf(r)
+	tradeReportID, err := r.GetTradeReportID()
+	if err != nil {
+		return nil, err
+	}
+	base.RefID, _ = r.GetTradeReportRefID()
+	base.ExecID, _ = r.GetExecID()
+	base.TrdMatchID, _ = r.GetTrdMatchID()
+	base.Symbol, _ = r.GetSymbol()
+	base.Qty, _ = r.GetLastQty()
+	base.Price, _ = r.GetLastPx()
+	base.TradeDate, _ = r.GetTradeDate()
+	base.TransactTime, _ = r.GetTransactTime()
+	base.ReceivedAt = time.Now()
+
+	sides, err := r.GetNoSides()
+	if err != nil {
+		return nil, err
+	}
+	var fills []Fill
+	for i, s := range sides.All() {
+		f := base
+		f.ID = tradeReportID + "/" + strconv.Itoa(i)
+		f.Side, _ = s.GetSide()
+		f.OrderID, _ = s.GetOrderID()
+		f.ClOrdID, _ = s.GetClOrdID()
+		f.Account, _ = s.GetAccount()
+		f.Currency, _ = s.GetCurrency()
+		f.Fees, _ = s.GetCommission()
+		if g, err := s.GetNoMiscFees(); err == nil {
+			for _, fee := range g.All() {
+				amt, _ := fee.GetMiscFeeAmt()
+				f.Fees = f.Fees.Add(amt)
+			}
+		}
+		fills = append(fills, f)
+	}
+	return fills, nil
+}
@@ -0,0 +1,139 @@
+package dropcopy
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrDuplicate is returned by a Sink for a Fill with the Source and ID of one it already
+// holds, e.g. written before a restart. The Consumer drops it as a duplicate.
+var ErrDuplicate = errors.New("dropcopy: fill already written")
+
+// Sink stores the Fills of a Consumer, Write is called from one goroutine at a time
+type Sink interface {
+	Write(f Fill) error
+}
+
+// columns are the CSV header and the database columns, in the order of row
+var columns = []string{
+	"source", "id", "exec_type", "ref_id", "exec_id", "trd_match_id", "order_id", "cl_ord_id",
+	"account", "symbol", "side", "qty", "price", "currency", "fees", "trade_date",
+	"transact_time", "received_at",
+}
+
+func row(f Fill) []string {
+	return []string{
+		f.Source, f.ID, string(f.ExecType), f.RefID, f.ExecID, f.TrdMatchID, f.OrderID, f.ClOrdID,
+		f.Account, f.Symbol, string(f.Side), f.Qty.String(), f.Price.String(), f.Currency, f.Fees.String(), f.TradeDate,
+		formatTime(f.TransactTime), formatTime(f.ReceivedAt),
+	}
+}
+
+// formatTime returns t in RFC 3339 UTC, or "" if t is zero
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// CSVSink writes the Fills as CSV rows, after a header row
+type CSVSink struct {
+	w      *csv.Writer
+	header bool
+}
+
+// NewCSVSink returns a CSVSink writing to w
+func NewCSVSink(w io.Writer) *CSVSink {
+	return &CSVSink{w: csv.NewWriter(w)}
+}
+
+// Write implements Sink, each row is flushed
+func (s *CSVSink) Write(f Fill) error {
+	if !s.header {
+		if err := s.w.Write(columns); err != nil {
+			return err
+		}
+		s.header = true
+	}
+	if err := s.w.Write(row(f)); err != nil {
+		return err
+	}
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// JSONLSink writes the Fills as JSON lines
+type JSONLSink struct {
+	enc *json.Encoder
+}
+
+// NewJSONLSink returns a JSONLSink writing to w
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{enc: json.NewEncoder(w)}
+}
+
+// Write implements Sink
+func (s *JSONLSink) Write(f Fill) error {
+	return s.enc.Encode(f)
+}
+
+// DB is the part of *sql.DB or *sql.Tx used by a DBSink
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// DBSink inserts the Fills in a table with the CSV columns, all of text type. A Fill with the
+// source and id of a row of the table is not inserted again, Write returns ErrDuplicate.
+// A unique key on source and id is still needed if several Consumers share the table.
+type DBSink struct {
+	DB    DB
+	Table string
+	// Placeholder returns the ith bind parameter, from 1, "?" by default,
+	// e.g. func(i int) string { return fmt.Sprintf("$%d", i) } for PostgreSQL
+	Placeholder func(i int) string
+	// Timeout of each insert, none if zero
+	Timeout time.Duration
+}
+
+// NewDBSink returns a DBSink inserting in the table with "?" bind parameters
+func NewDBSink(db DB, table string) *DBSink {
+	return &DBSink{DB: db, Table: table, Placeholder: func(int) string { return "?" }}
+}
+
+// Write implements Sink
+func (s *DBSink) Write(f Fill) error {
+	values := row(f)
+	params := make([]string, len(values))
+	args := make([]any, len(values), len(values)+2)
+	for i, v := range values {
+		params[i] = s.Placeholder(i + 1)
+		args[i] = v
+	}
+	args = append(args, f.Source, f.ID)
+	query := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s WHERE NOT EXISTS (SELECT 1 FROM %s WHERE source = %s AND id = %s)",
+		s.Table, strings.Join(columns, ", "), strings.Join(params, ", "),
+		s.Table, s.Placeholder(len(values)+1), s.Placeholder(len(values)+2))
+	ctx := context.Background()
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	res, err := s.DB.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	// drivers not reporting the affected rows cannot tell the duplicates
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrDuplicate
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package fixfmt
+
+import (
+	"github.com/quickfixgo/fix44/hnxinfogate"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/datadictionary"
+)
+
+// Dictionary resolves field names, value descriptions and repeating group layouts.
+// A lookup that returns false is passed on to the next Dictionary of the Printer.
+type Dictionary interface {
+	FieldName(msgType string, tag quickfix.Tag) (string, bool)
+	ValueName(msgType string, tag quickfix.Tag, value string) (string, bool)
+	// GroupFields returns the member tags of the repeating group counted by tag,
+	// the first member is the delimiter of each group entry.
+	GroupFields(msgType string, tag quickfix.Tag) ([]quickfix.Tag, bool)
+}
+
+// FromDataDictionary returns a Dictionary backed by a quickfix data dictionary,
+// usually parsed from the FIX44.xml used by the session
+func FromDataDictionary(dd *datadictionary.DataDictionary) Dictionary {
+	return dataDictionary{dd}
+}
+
+type dataDictionary struct {
+	dd *datadictionary.DataDictionary
+}
+
+func (d dataDictionary) FieldName(msgType string, tag quickfix.Tag) (string, bool) {
+	f, ok := d.dd.FieldTypeByTag[int(tag)]
+	if !ok {
+		return "", false
+	}
+	return f.Name(), true
+}
+
+func (d dataDictionary) ValueName(msgType string, tag quickfix.Tag, value string) (string, bool) {
+	f, ok := d.dd.FieldTypeByTag[int(tag)]
+	if !ok {
+		return "", false
+	}
+	e, ok := f.Enums[value]
+	if !ok {
+		return "", false
+	}
+	return e.Description, true
+}
+
+func (d dataDictionary) GroupFields(msgType string, tag quickfix.Tag) ([]quickfix.Tag, bool) {
+	defs := []*datadictionary.MessageDef{d.dd.Header, d.dd.Trailer}
+	if def, ok := d.dd.Messages[msgType]; ok {
+		defs = append(defs, def)
+	}
+	for _, def := range defs {
+		if def == nil {
+			continue
+		}
+		for _, f := range def.Fields {
+			if g := findGroup(f, int(tag)); g != nil {
+				tags := make([]quickfix.Tag, len(g.Fields))
+				for i, child := range g.Fields {
+					tags[i] = quickfix.Tag(child.Tag())
+				}
+				return tags, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func findGroup(f *datadictionary.FieldDef, tag int) *datadictionary.FieldDef {
+	if !f.IsGroup() {
+		return nil
+	}
+	if f.Tag() == tag {
+		return f
+	}
+	for _, child := range f.Fields {
+		if g := findGroup(child, tag); g != nil {
+			return g
+		}
+	}
+	return nil
+}
+
+// HNXInfoGate resolves the InfoGate messages of the hnxinfogate package,
+// it only answers for the InfoGate MsgTypes so it can be put before a fix44 Dictionary
+var HNXInfoGate Dictionary = hnxDictionary{}
+
+type hnxDictionary struct{}
+
+func (hnxDictionary) FieldName(msgType string, tag quickfix.Tag) (string, bool) {
+	name, ok := hnxinfogate.FieldNames[msgType][tag]
+	return name, ok
+}
+
+func (hnxDictionary) ValueName(msgType string, tag quickfix.Tag, value string) (string, bool) {
+	desc, ok := hnxinfogate.FieldValues[msgType][tag][value]
+	return desc, ok
+}
+
+func (hnxDictionary) GroupFields(msgType string, tag quickfix.Tag) ([]quickfix.Tag, bool) {
+	tags, ok := hnxinfogate.Groups[msgType][tag]
+	return tags, ok
+}
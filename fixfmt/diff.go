@@ -0,0 +1,120 @@
+package fixfmt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// ChangeKind tells how a field differs between two messages
+type ChangeKind int
+
+const (
+	// Modified fields are present in both messages with different values
+	Modified ChangeKind = iota
+	// Added fields are only present in the second message
+	Added
+	// Removed fields are only present in the first message
+	Removed
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// Change is a field that differs between two messages
+type Change struct {
+	Kind ChangeKind
+	// Path locates the field, e.g. NoPartyIDs(453)[2].PartyID(448)
+	Path     string
+	Tag      quickfix.Tag
+	OldValue string
+	NewValue string
+}
+
+type pathValue struct {
+	path  string
+	tag   quickfix.Tag
+	value string
+}
+
+// Diff returns the fields that differ between a and b, in the order of a then b.
+// Repeating group entries are compared by position.
+func (p *Printer) Diff(a, b *quickfix.Message) []Change {
+	aType, aNodes := p.parse(a)
+	bType, bNodes := p.parse(b)
+	aFields := p.flatten(aType, aNodes, "", nil)
+	bFields := p.flatten(bType, bNodes, "", nil)
+
+	bByPath := make(map[string]pathValue, len(bFields))
+	for _, f := range bFields {
+		bByPath[f.path] = f
+	}
+	seen := make(map[string]bool, len(aFields))
+	var changes []Change
+	for _, f := range aFields {
+		seen[f.path] = true
+		g, ok := bByPath[f.path]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: Removed, Path: f.path, Tag: f.tag, OldValue: f.value})
+		case g.value != f.value:
+			changes = append(changes, Change{Kind: Modified, Path: f.path, Tag: f.tag, OldValue: f.value, NewValue: g.value})
+		}
+	}
+	for _, g := range bFields {
+		if !seen[g.path] {
+			changes = append(changes, Change{Kind: Added, Path: g.path, Tag: g.tag, NewValue: g.value})
+		}
+	}
+	return changes
+}
+
+func (p *Printer) flatten(msgType string, nodes []node, prefix string, out []pathValue) []pathValue {
+	for _, n := range nodes {
+		if p.DiffIgnore[n.tag] {
+			continue
+		}
+		path := prefix + p.tagName(msgType, n.tag)
+		out = append(out, pathValue{path, n.tag, n.value})
+		for i, entry := range n.entries {
+			out = p.flatten(msgType, entry, fmt.Sprintf("%s[%d].", path, i+1), out)
+		}
+	}
+	return out
+}
+
+// FormatDiff renders the changes between a and b one per line,
+// prefixed with ~ for modified, + for added and - for removed fields
+func (p *Printer) FormatDiff(a, b *quickfix.Message) string {
+	aType, _ := a.Header.GetString(tag.MsgType)
+	bType, _ := b.Header.GetString(tag.MsgType)
+	var sb strings.Builder
+	for _, c := range p.Diff(a, b) {
+		switch c.Kind {
+		case Added:
+			fmt.Fprintf(&sb, "%s %s=%s\n", c.Kind, c.Path, p.describe(bType, c.Tag, c.NewValue))
+		case Removed:
+			fmt.Fprintf(&sb, "%s %s=%s\n", c.Kind, c.Path, p.describe(aType, c.Tag, c.OldValue))
+		default:
+			fmt.Fprintf(&sb, "%s %s=%s -> %s\n", c.Kind, c.Path, p.describe(aType, c.Tag, c.OldValue), p.describe(bType, c.Tag, c.NewValue))
+		}
+	}
+	return sb.String()
+}
+
+func (p *Printer) describe(msgType string, t quickfix.Tag, value string) string {
+	if desc, ok := p.valueName(msgType, t, value); ok {
+		return value + " (" + desc + ")"
+	}
+	return value
+}
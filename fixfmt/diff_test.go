@@ -0,0 +1,58 @@
+package fixfmt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(msg *quickfix.Message)
+		want   []Change
+	}{
+		{"same", func(*quickfix.Message) {}, nil},
+		{"session fields ignored", func(msg *quickfix.Message) { msg.Header.SetInt(tag.MsgSeqNum, 7) }, nil},
+		{"modified", func(msg *quickfix.Message) { msg.Body.SetString(tag.ClOrdID, "C2") },
+			[]Change{{Kind: Modified, Path: "ClOrdID(11)", Tag: tag.ClOrdID, OldValue: "C1", NewValue: "C2"}}},
+		{"added", func(msg *quickfix.Message) { msg.Body.SetString(tag.Account, "ACC1") },
+			[]Change{{Kind: Added, Path: "Account(1)", Tag: tag.Account, NewValue: "ACC1"}}},
+		{"removed", func(msg *quickfix.Message) { msg.Body.Remove(tag.ClOrdID) },
+			[]Change{{Kind: Removed, Path: "ClOrdID(11)", Tag: tag.ClOrdID, OldValue: "C1"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := buy("P1"), buy("P1")
+			tt.change(b)
+			if got := NewPrinter(orders{}).Diff(a, b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffGroups(t *testing.T) {
+	got := NewPrinter(orders{}).Diff(buy("P1", "P2"), buy("P1", "P3", "P4"))
+	want := []Change{
+		{Kind: Modified, Path: "NoPartyIDs(453)", Tag: tag.NoPartyIDs, OldValue: "2", NewValue: "3"},
+		{Kind: Modified, Path: "NoPartyIDs(453)[2].PartyID(448)", Tag: tag.PartyID, OldValue: "P2", NewValue: "P3"},
+		{Kind: Added, Path: "NoPartyIDs(453)[3].PartyID(448)", Tag: tag.PartyID, NewValue: "P4"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	a, b := buy(), buy()
+	b.Body.SetString(tag.Side, "2")
+	b.Body.SetString(tag.Account, "ACC1")
+	a.Body.SetString(tag.PartyID, "P1")
+	want := "~ Side(54)=1 (BUY) -> 2 (SELL)\n- PartyID(448)=P1\n+ Account(1)=ACC1\n"
+	if got := NewPrinter(orders{}).FormatDiff(a, b); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
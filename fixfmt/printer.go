@@ -0,0 +1,173 @@
+package fixfmt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/quickfixgo/fix44/mask"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// Printer renders quickfix messages in a human readable form,
+// with field names, value descriptions and indented repeating groups
+type Printer struct {
+	// Dictionaries are queried in order, the first one that knows a tag wins
+	Dictionaries []Dictionary
+	// Indent is repeated once per repeating group level
+	Indent string
+	// DiffIgnore lists the tags skipped by Diff, by default the session level
+	// fields that differ between any two messages
+	DiffIgnore map[quickfix.Tag]bool
+	// Redact, if set, replaces the values before they are rendered or compared,
+	// e.g. mask.Masker.Redact to hide credentials
+	Redact func(t quickfix.Tag, value string) string
+}
+
+// NewPrinter returns a Printer using the given dictionaries,
+// e.g. NewPrinter(fixfmt.HNXInfoGate, fixfmt.FromDataDictionary(dd))
+func NewPrinter(dicts ...Dictionary) *Printer {
+	return &Printer{
+		Dictionaries: dicts,
+		Indent:       "  ",
+		DiffIgnore: map[quickfix.Tag]bool{
+			tag.BodyLength:  true,
+			tag.MsgSeqNum:   true,
+			tag.SendingTime: true,
+			tag.CheckSum:    true,
+		},
+	}
+}
+
+// node is a field of a message, with the entries of the repeating group it counts if any
+type node struct {
+	tag     quickfix.Tag
+	value   string
+	entries [][]node
+}
+
+// Format renders m one field per line, in wire order
+func (p *Printer) Format(m *quickfix.Message) string {
+	msgType, nodes := p.parse(m)
+	var b strings.Builder
+	p.writeNodes(&b, msgType, nodes, 0)
+	return b.String()
+}
+
+func (p *Printer) writeNodes(b *strings.Builder, msgType string, nodes []node, depth int) {
+	indent := strings.Repeat(p.Indent, depth)
+	for _, n := range nodes {
+		fmt.Fprintf(b, "%s%s\n", indent, p.field(msgType, n.tag, n.value))
+		for i, entry := range n.entries {
+			fmt.Fprintf(b, "%s%s[%d]\n", indent, p.Indent, i+1)
+			p.writeNodes(b, msgType, entry, depth+2)
+		}
+	}
+}
+
+// field renders a single tag=value pair as Name(tag)=value (description)
+func (p *Printer) field(msgType string, t quickfix.Tag, value string) string {
+	s := p.tagName(msgType, t) + "=" + value
+	if desc, ok := p.valueName(msgType, t, value); ok {
+		s += " (" + desc + ")"
+	}
+	return s
+}
+
+func (p *Printer) tagName(msgType string, t quickfix.Tag) string {
+	for _, d := range p.Dictionaries {
+		if name, ok := d.FieldName(msgType, t); ok {
+			return fmt.Sprintf("%s(%d)", name, t)
+		}
+	}
+	return strconv.Itoa(int(t))
+}
+
+func (p *Printer) valueName(msgType string, t quickfix.Tag, value string) (string, bool) {
+	for _, d := range p.Dictionaries {
+		if desc, ok := d.ValueName(msgType, t, value); ok {
+			return desc, true
+		}
+	}
+	return "", false
+}
+
+func (p *Printer) groupFields(msgType string, t quickfix.Tag) ([]quickfix.Tag, bool) {
+	for _, d := range p.Dictionaries {
+		if tags, ok := d.GroupFields(msgType, t); ok && len(tags) > 0 {
+			return tags, true
+		}
+	}
+	return nil, false
+}
+
+type tagValue struct {
+	tag   quickfix.Tag
+	value string
+}
+
+// parse splits the wire form of a copy of m into fields, nests the repeating groups and
+// redacts the values. m itself is not rendered, which would set its BodyLength and CheckSum,
+// and the data fields are read by length since their values may contain SOH.
+func (p *Printer) parse(m *quickfix.Message) (msgType string, nodes []node) {
+	var fields []tagValue
+	for _, f := range mask.Fields(mask.Wire(m)) {
+		value := string(f.Value)
+		if f.Tag == tag.MsgType {
+			msgType = value
+		}
+		fields = append(fields, tagValue{f.Tag, value})
+	}
+	nodes, _ = p.nest(msgType, fields, nil)
+	p.redact(nodes)
+	return
+}
+
+// redact replaces the values of the nodes once nested, so that a redacted NumInGroup
+// still counts its entries
+func (p *Printer) redact(nodes []node) {
+	if p.Redact == nil {
+		return
+	}
+	for i := range nodes {
+		nodes[i].value = p.Redact(nodes[i].tag, nodes[i].value)
+		for _, entry := range nodes[i].entries {
+			p.redact(entry)
+		}
+	}
+}
+
+// nest consumes fields until one does not belong to members,
+// or until the delimiter starts the next group entry.
+// A nil members consumes every field.
+func (p *Printer) nest(msgType string, fields []tagValue, members []quickfix.Tag) ([]node, []tagValue) {
+	var nodes []node
+	for len(fields) > 0 {
+		f := fields[0]
+		if members != nil && (!containsTag(members, f.tag) || (f.tag == members[0] && len(nodes) > 0)) {
+			break
+		}
+		fields = fields[1:]
+		n := node{tag: f.tag, value: f.value}
+		if children, ok := p.groupFields(msgType, f.tag); ok {
+			count, _ := strconv.Atoi(f.value)
+			for i := 0; i < count && len(fields) > 0 && fields[0].tag == children[0]; i++ {
+				var entry []node
+				entry, fields = p.nest(msgType, fields, children)
+				n.entries = append(n.entries, entry)
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, fields
+}
+
+func containsTag(tags []quickfix.Tag, t quickfix.Tag) bool {
+	for _, x := range tags {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
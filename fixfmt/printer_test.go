@@ -0,0 +1,119 @@
+package fixfmt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/newordersingle"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// orders is a Dictionary of the few NewOrderSingle fields the tests use
+type orders struct{}
+
+var orderFields = map[quickfix.Tag]string{
+	tag.MsgType:    "MsgType",
+	tag.ClOrdID:    "ClOrdID",
+	tag.Side:       "Side",
+	tag.Account:    "Account",
+	tag.NoPartyIDs: "NoPartyIDs",
+	tag.PartyID:    "PartyID",
+	tag.PartyRole:  "PartyRole",
+}
+
+func (orders) FieldName(msgType string, t quickfix.Tag) (string, bool) {
+	name, ok := orderFields[t]
+	return name, ok
+}
+
+func (orders) ValueName(msgType string, t quickfix.Tag, value string) (string, bool) {
+	desc, ok := map[string]string{"1": "BUY", "2": "SELL"}[value]
+	return desc, ok && t == tag.Side
+}
+
+func (orders) GroupFields(msgType string, t quickfix.Tag) ([]quickfix.Tag, bool) {
+	if t == tag.NoPartyIDs {
+		return []quickfix.Tag{tag.PartyID, tag.PartyRole}, true
+	}
+	return nil, false
+}
+
+// buy returns a NewOrderSingle of the PartyIDs
+func buy(partyIDs ...string) *quickfix.Message {
+	o := newordersingle.New(field.NewClOrdID("C1"), field.NewSide(enum.Side_BUY),
+		field.NewTransactTime(time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)), field.NewOrdType(enum.OrdType_LIMIT))
+	if len(partyIDs) > 0 {
+		parties := newordersingle.NewNoPartyIDsRepeatingGroup()
+		for _, id := range partyIDs {
+			parties.Add().SetPartyID(id)
+		}
+		o.SetNoPartyIDs(parties)
+	}
+	return o.ToMessage()
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		dicts  []Dictionary
+		redact func(quickfix.Tag, string) string
+		want   []string
+	}{
+		{"names and descriptions", []Dictionary{orders{}}, nil, []string{
+			"MsgType(35)=D\n",
+			"ClOrdID(11)=C1\n",
+			"Side(54)=1 (BUY)\n",
+			"NoPartyIDs(453)=2\n  [1]\n    PartyID(448)=P1\n  [2]\n    PartyID(448)=P2\n",
+		}},
+		{"no dictionary", nil, nil, []string{"35=D\n", "54=1\n", "453=2\n448=P1\n448=P2\n"}},
+		{"redacted member", []Dictionary{orders{}}, func(t quickfix.Tag, v string) string {
+			if t == tag.PartyID {
+				return "***"
+			}
+			return v
+		}, []string{"NoPartyIDs(453)=2\n  [1]\n    PartyID(448)=***\n  [2]\n    PartyID(448)=***\n"}},
+		{"redacted NumInGroup", []Dictionary{orders{}}, func(t quickfix.Tag, v string) string {
+			if t == tag.NoPartyIDs {
+				return "***"
+			}
+			return v
+		}, []string{"NoPartyIDs(453)=***\n  [1]\n    PartyID(448)=P1\n  [2]\n    PartyID(448)=P2\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPrinter(tt.dicts...)
+			p.Redact = tt.redact
+			got := p.Format(buy("P1", "P2"))
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("got\n%s\nwant it to contain\n%s", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatLeavesMessage(t *testing.T) {
+	msg := buy("P1")
+	NewPrinter(orders{}).Format(msg)
+	if msg.Header.Has(tag.BodyLength) || msg.Trailer.Has(tag.CheckSum) {
+		t.Error("got BodyLength and CheckSum set on the message")
+	}
+}
+
+func TestFormatDataField(t *testing.T) {
+	msg := buy()
+	msg.Body.SetInt(tag.RawDataLength, 6)
+	msg.Body.SetString(tag.RawData, "a\x0154=b")
+	got := NewPrinter().Format(msg)
+	if !strings.Contains(got, "96=a\x0154=b\n") {
+		t.Errorf("got\n%q\nwant RawData read by length", got)
+	}
+	if strings.Count(got, "54=") != 2 {
+		t.Errorf("got\n%q\nwant no field split out of RawData", got)
+	}
+}
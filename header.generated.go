@@ -1,6 +1,7 @@
 package fix44
 
 import (
+	"iter"
 	"time"
 
 	"github.com/quickfixgo/enum"
@@ -620,3 +621,45 @@ func (h NoHopsRepeatingGroup) Add() NoHops {
 func (h NoHopsRepeatingGroup) Get(i int) NoHops {
 	return NoHops{h.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoHops of each entry in the NoHopsRepeatingGroup
+func (h NoHopsRepeatingGroup) All() iter.Seq2[int, NoHops] {
+	return func(yield func(int, NoHops) bool) {
+		for i := 0; i < h.Len(); i++ {
+			if !yield(i, h.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoHops entries of the NoHopsRepeatingGroup as a slice
+func (h NoHopsRepeatingGroup) Slice() []NoHops {
+	s := make([]NoHops, h.Len())
+	for i := range s {
+		s[i] = h.Get(i)
+	}
+	return s
+}
+
+//NoHopsRow holds the values of a NoHops, nil fields are left unset by AddRow
+type NoHopsRow struct {
+	HopCompID      *string
+	HopSendingTime *time.Time
+	HopRefID       *int
+}
+
+//AddRow creates and appends a new NoHops to this group, setting the fields present in row
+func (h NoHopsRepeatingGroup) AddRow(row NoHopsRow) NoHops {
+	g := h.Add()
+	if row.HopCompID != nil {
+		g.SetHopCompID(*row.HopCompID)
+	}
+	if row.HopSendingTime != nil {
+		g.SetHopSendingTime(*row.HopSendingTime)
+	}
+	if row.HopRefID != nil {
+		g.SetHopRefID(*row.HopRefID)
+	}
+	return g
+}
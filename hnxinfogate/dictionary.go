@@ -0,0 +1,253 @@
+package hnxinfogate
+
+import (
+	"github.com/quickfixgo/quickfix"
+)
+
+// MsgTypes of the InfoGate messages defined in this package
+var MsgTypes = []string{"BI", "SI", "DI", "TP", "I", "EP"}
+
+// FieldNames maps each InfoGate MsgType to the names of its tags,
+// the names are the ones used by the getters of this package.
+// Tag numbers are reused with a different meaning between messages
+// (e.g. tag 31 is MatchPrice in SI but Price in EP), so lookups must be done per MsgType.
+var FieldNames = map[string]map[quickfix.Tag]string{
+	"BI": boardInfoFields,
+	"SI": stockInfoFields,
+	"DI": mergeFieldNames(stockInfoFields, derivativeInfoFields),
+	"TP": topNPriceFields,
+	"I":  indexFields,
+	"EP": auctionMatchFields,
+}
+
+// FieldValues maps InfoGate tags to the descriptions of their values
+var FieldValues = map[string]map[quickfix.Tag]map[string]string{
+	"BI": {
+		426: boardStatusValues,
+		336: tradingSessionIDValues,
+		340: tradSesStatusValues,
+	},
+	"SI": {
+		326: securityTradingStatusValues,
+		167: securityTypeValues,
+		232: referenceStatusValues,
+	},
+	"DI": {
+		326: securityTradingStatusValues,
+		167: securityTypeValues,
+		232: referenceStatusValues,
+	},
+	"EP": {
+		33: actionTypeValues,
+	},
+}
+
+// Groups maps the NumInGroup tag of each InfoGate repeating group to its member tags,
+// the first member is the delimiter of the group
+var Groups = map[string]map[quickfix.Tag][]quickfix.Tag{
+	"TP": {
+		555: {556, 132, 1321, 133, 1331},
+	},
+}
+
+var boardInfoFields = map[quickfix.Tag]string{
+	425: "BoardCode",
+	426: "BoardStatus",
+	336: "TradingSessionID",
+	340: "TradSesStatus",
+	421: "Name",
+	251: "NumSymbolAdvances",
+	252: "NumSymbolNoChange",
+	253: "NumSymbolDeclines",
+	399: "Time",
+}
+
+var stockInfoFields = map[quickfix.Tag]string{
+	55:   "Symbol",
+	425:  "BoardCode",
+	326:  "SecurityTradingStatus",
+	167:  "SecurityType",
+	225:  "IssueDate",
+	106:  "Issuer",
+	107:  "SecurityDesc",
+	132:  "BestBidPrice",
+	1321: "BestBidQtty",
+	133:  "BestOfferPrice",
+	1331: "BestOfferQtty",
+	134:  "TotalBidQtty",
+	135:  "TotalOfferQtty",
+	260:  "BasicPrice",
+	333:  "FloorPrice",
+	332:  "CeilingPrice",
+	3331: "FloorPricePT",
+	3321: "CeilingPricePT",
+	334:  "ParValue",
+	31:   "MatchPrice",
+	32:   "MatchQtty",
+	137:  "OpenPrice",
+	138:  "PriorOpenPrice",
+	139:  "ClosePrice",
+	140:  "PriorClosePrice",
+	387:  "TotalVolumeTraded",
+	3871: "TotalValueTraded",
+	631:  "MidPx",
+	388:  "TradingDate",
+	399:  "Time",
+	400:  "TradingUnit",
+	109:  "TotalListingQtty",
+	17:   "DateNo",
+	230:  "AdjustQtty",
+	232:  "ReferenceStatus",
+	255:  "CurrentPrice",
+	2551: "CurrentQtty",
+	266:  "HighestPrice",
+	2661: "LowestPrice",
+	277:  "PriorPrice",
+	310:  "MatchValue",
+	320:  "OfferCount",
+	321:  "BidCount",
+	391:  "NormalTotalTradedQtty",
+	392:  "NormalTotalTradedValue",
+	393:  "PutThroughMatchQtty",
+	3931: "PutThroughMatchPrice",
+	394:  "PutThroughTotalTradedQtty",
+	3941: "PutThroughTotalTradedValue",
+	395:  "TotalBuyTradingQtty",
+	3951: "BuyCount",
+	3952: "TotalBuyTradingValue",
+	396:  "TotalSellTradingQtty",
+	3961: "SellCount",
+	3962: "TotalSellTradingValue",
+	397:  "BuyForeignQtty",
+	3971: "BuyForeignValue",
+	398:  "SellForeignQtty",
+	3981: "SellForeignValue",
+	3301: "RemainForeignQtty",
+	541:  "MaturityDate",
+	223:  "CouponRate",
+	1341: "TotalBidQttyOdd",
+	1351: "TotalOfferQttyOdd",
+}
+
+var derivativeInfoFields = map[quickfix.Tag]string{
+	800:  "Underlying",
+	801:  "OpenInterest",
+	8011: "OpenInterestChange",
+	802:  "FirstTradingDate",
+	803:  "LastTradingDate",
+	336:  "TradingSessionID",
+	340:  "TradSesStatus",
+}
+
+var topNPriceFields = map[quickfix.Tag]string{
+	55:   "Symbol",
+	425:  "BoardCode",
+	555:  "NOTopPrice",
+	556:  "NumTopPrice",
+	132:  "BestBidPrice",
+	1321: "BestBidQtty",
+	133:  "BestOfferPrice",
+	1331: "BestOfferQtty",
+}
+
+var indexFields = map[quickfix.Tag]string{
+	2:  "IndexCode",
+	3:  "Value",
+	5:  "Change",
+	6:  "RatioChange",
+	7:  "TotalQtty",
+	14: "TotalValue",
+	23: "PriorIndexVal",
+	24: "HighestIndex",
+	25: "LowestIndex",
+}
+
+var auctionMatchFields = map[quickfix.Tag]string{
+	55: "Symbol",
+	33: "ActionType",
+	31: "Price",
+	32: "Qtty",
+}
+
+var boardStatusValues = map[string]string{
+	"A": "Đang hoạt động",
+	"C": "Ngừng hoạt động",
+	"P": "Tạm thời dừng hoạt động",
+}
+
+var tradingSessionIDValues = map[string]string{
+	"LIS_AUC_O_NML":     "Phiên mở cửa",
+	"LIS_AUC_O_NML_LOC": "Phiên mở cửa BL",
+	"LIS_CON_NML":       "Phiên liên tục",
+	"LIS_AUC_C_NML":     "Phiên đóng cửa",
+	"LIS_AUC_C_NML_LOC": "Phiên đóng cửa BL",
+	"LIS_PTH_P_NML":     "Phiên sau đóng cửa",
+	"UPC_AUC_O_NML":     "Phiên mở cửa",
+	"UPC_AUC_O_NML_LOC": "Phiên mở cửa BL",
+	"UPC_CON_NML":       "Phiên liên tục",
+	"UPC_AUC_C_NML":     "Phiên đóng cửa",
+	"UPC_AUC_C_NML_LOC": "Phiên đóng cửa BL",
+}
+
+var tradSesStatusValues = map[string]string{
+	"0":  "Chưa bắt đầu",
+	"1":  "Bình thường",
+	"2":  "Tạm dừng",
+	"3":  "Kết thúc nhận lệnh phiên hiện tại do RandomEnd",
+	"4":  "Tạm dừng do CircuitBreak",
+	"5":  "Phiên định kỳ sau CB",
+	"6":  "Chứng khoán đang Prolong",
+	"13": "Kết thúc nhận lệnh của ngày giao dịch hiện tại",
+	"90": "Thị trường đang ở trạng thái chờ nhận lệnh",
+	"97": "Đóng cửa thị trường",
+}
+
+var securityTradingStatusValues = map[string]string{
+	"0":  "Bình thường",
+	"1":  "Chứng khoán không được giao dịch trong ngày",
+	"2":  "Ngừng giao dịch",
+	"6":  "Hủy niêm yết",
+	"7":  "Niêm yết mới",
+	"8":  "Sắp hủy niêm yết",
+	"10": "Tạm ngừng giao dịch giữa phiên",
+	"25": "Giao dịch đặc biệt",
+}
+
+var securityTypeValues = map[string]string{
+	"ST": "Cổ phiếu",
+	"BO": "Trái phiếu",
+	"MF": "Chứng chỉ quỹ",
+	"EF": "Exchange-Traded Funds",
+	"FU": "Future",
+	"OP": "Option",
+}
+
+var referenceStatusValues = map[string]string{
+	"0":  "Không xảy ra",
+	"1":  "Trả CT bằng tiền",
+	"2":  "Trả cổ tức bằng CP/CP thưởng",
+	"3":  "Phát hành CP cho cổ đông hiện hữu",
+	"4":  "Trả cổ tức bằng CP/CP thưởng, phát hành CP cho cổ đông hiện hữu",
+	"5":  "Trả cổ tức bằng tiền, bằng CP/CP thưởng, phát hành CP cho cổ đông hiện hữu",
+	"6":  "Niêm yết bổ sung",
+	"7":  "Giảm vốn",
+	"8":  "Trả cổ tức bằng tiền, trả cổ tức bằng CP/CP thưởng",
+	"9":  "Trả cổ tức bằng tiền, phát hành CP cho cổ đông hiện hữu",
+	"10": "Thay đổi tỷ lệ Free Float",
+	"11": "Họp đại cổ đông",
+}
+
+var actionTypeValues = map[string]string{
+	"A": "Khớp chính",
+	"M": "Tạm khớp",
+}
+
+func mergeFieldNames(maps ...map[quickfix.Tag]string) map[quickfix.Tag]string {
+	merged := make(map[quickfix.Tag]string)
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
@@ -1,6 +1,8 @@
 package hnxinfogate
 
 import (
+	"iter"
+
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/fix44"
 	"github.com/quickfixgo/quickfix"
@@ -105,6 +107,56 @@ func (m BidAskRepeatingGroup) Get(i int) BidAskGroup {
 	return BidAskGroup{m.RepeatingGroup.Get(i)}
 }
 
+// All returns an iterator over the index and BidAskGroup of each price level
+func (m BidAskRepeatingGroup) All() iter.Seq2[int, BidAskGroup] {
+	return func(yield func(int, BidAskGroup) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the price levels as a slice
+func (m BidAskRepeatingGroup) Slice() []BidAskGroup {
+	s := make([]BidAskGroup, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+// BidAskRow holds the values of a price level, nil fields are left unset by AddRow
+type BidAskRow struct {
+	NumTopPrice    *float64
+	BestBidPrice   *float64
+	BestBidQtty    *float64
+	BestOfferPrice *float64
+	BestOfferQtty  *float64
+}
+
+// AddRow appends a new price level to the group, setting the fields present in row
+func (m BidAskRepeatingGroup) AddRow(row BidAskRow) BidAskGroup {
+	g := m.Add()
+	if row.NumTopPrice != nil {
+		g.SetField(556, quickfix.FIXFloat(*row.NumTopPrice))
+	}
+	if row.BestBidPrice != nil {
+		g.SetField(132, quickfix.FIXFloat(*row.BestBidPrice))
+	}
+	if row.BestBidQtty != nil {
+		g.SetField(1321, quickfix.FIXFloat(*row.BestBidQtty))
+	}
+	if row.BestOfferPrice != nil {
+		g.SetField(133, quickfix.FIXFloat(*row.BestOfferPrice))
+	}
+	if row.BestOfferQtty != nil {
+		g.SetField(1331, quickfix.FIXFloat(*row.BestOfferQtty))
+	}
+	return g
+}
+
 type BidAskGroup struct{ *quickfix.Group }
 
 // Tag 556
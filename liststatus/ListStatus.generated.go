@@ -2,6 +2,7 @@ package liststatus
 
 import (
 	"github.com/shopspring/decimal"
+	"iter"
 	"time"
 
 	"github.com/quickfixgo/enum"
@@ -544,3 +545,81 @@ func (m NoOrdersRepeatingGroup) Add() NoOrders {
 func (m NoOrdersRepeatingGroup) Get(i int) NoOrders {
 	return NoOrders{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoOrders of each entry in the NoOrdersRepeatingGroup
+func (m NoOrdersRepeatingGroup) All() iter.Seq2[int, NoOrders] {
+	return func(yield func(int, NoOrders) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoOrders entries of the NoOrdersRepeatingGroup as a slice
+func (m NoOrdersRepeatingGroup) Slice() []NoOrders {
+	s := make([]NoOrders, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoOrdersRow holds the values of a NoOrders, nil fields are left unset by AddRow
+type NoOrdersRow struct {
+	ClOrdID          *string
+	SecondaryClOrdID *string
+	CumQty           *quickfix.FIXDecimal
+	OrdStatus        *enum.OrdStatus
+	WorkingIndicator *bool
+	LeavesQty        *quickfix.FIXDecimal
+	CxlQty           *quickfix.FIXDecimal
+	AvgPx            *quickfix.FIXDecimal
+	OrdRejReason     *enum.OrdRejReason
+	Text             *string
+	EncodedTextLen   *int
+	EncodedText      *string
+}
+
+//AddRow creates and appends a new NoOrders to this group, setting the fields present in row
+func (m NoOrdersRepeatingGroup) AddRow(row NoOrdersRow) NoOrders {
+	g := m.Add()
+	if row.ClOrdID != nil {
+		g.SetClOrdID(*row.ClOrdID)
+	}
+	if row.SecondaryClOrdID != nil {
+		g.SetSecondaryClOrdID(*row.SecondaryClOrdID)
+	}
+	if row.CumQty != nil {
+		g.SetCumQty(row.CumQty.Decimal, row.CumQty.Scale)
+	}
+	if row.OrdStatus != nil {
+		g.SetOrdStatus(*row.OrdStatus)
+	}
+	if row.WorkingIndicator != nil {
+		g.SetWorkingIndicator(*row.WorkingIndicator)
+	}
+	if row.LeavesQty != nil {
+		g.SetLeavesQty(row.LeavesQty.Decimal, row.LeavesQty.Scale)
+	}
+	if row.CxlQty != nil {
+		g.SetCxlQty(row.CxlQty.Decimal, row.CxlQty.Scale)
+	}
+	if row.AvgPx != nil {
+		g.SetAvgPx(row.AvgPx.Decimal, row.AvgPx.Scale)
+	}
+	if row.OrdRejReason != nil {
+		g.SetOrdRejReason(*row.OrdRejReason)
+	}
+	if row.Text != nil {
+		g.SetText(*row.Text)
+	}
+	if row.EncodedTextLen != nil {
+		g.SetEncodedTextLen(*row.EncodedTextLen)
+	}
+	if row.EncodedText != nil {
+		g.SetEncodedText(*row.EncodedText)
+	}
+	return g
+}
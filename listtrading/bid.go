@@ -0,0 +1,148 @@
+package listtrading
+
+import (
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/bidrequest"
+	"github.com/quickfixgo/fix44/bidresponse"
+	"github.com/shopspring/decimal"
+)
+
+// BidRequest asks for the pricing of lists submitted with Wait
+type BidRequest struct {
+	ListIDs      []string
+	ListName     string
+	BidTradeType enum.BidTradeType
+	BasisPxType  enum.BasisPxType
+	Currency     string
+	// TradeDate is YYYYMMDD, left out if empty
+	TradeDate string
+}
+
+// BidComponent is the pricing of a list in a BidResponse
+type BidComponent struct {
+	ListID     string
+	Side       enum.Side
+	Price      decimal.Decimal
+	PriceType  enum.PriceType
+	Commission decimal.Decimal
+	CommType   enum.CommType
+	Text       string
+}
+
+// Bid is a BidRequest and its BidResponse
+type Bid struct {
+	ClientBidID string
+	// BidID is set by the BidResponse
+	BidID      string
+	ListIDs    []string
+	Components []BidComponent
+	UpdatedAt  time.Time
+}
+
+// clone returns a copy of b that does not share slices with it
+func (b *Bid) clone() Bid {
+	c := *b
+	c.ListIDs = append([]string(nil), b.ListIDs...)
+	c.Components = append([]BidComponent(nil), b.Components...)
+	return c
+}
+
+// RequestBid sends a BidRequest for the lists, with the BidType of the first one.
+// It returns the ClientBidID.
+func (m *Manager) RequestBid(r BidRequest) (string, error) {
+	if len(r.ListIDs) == 0 {
+		return "", ErrNoOrders
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var bidType enum.BidType
+	totNoRelatedSym := 0
+	for _, id := range r.ListIDs {
+		l, err := m.open(id)
+		if err != nil {
+			return "", err
+		}
+		if bidType == "" {
+			bidType = l.BidType
+		}
+		totNoRelatedSym += len(l.Orders)
+	}
+
+	clientBidID := m.newID()
+	msg := bidrequest.New(field.NewClientBidID(clientBidID), field.NewBidRequestTransType(enum.BidRequestTransType_NEW),
+		field.NewTotNoRelatedSym(totNoRelatedSym), field.NewBidType(bidType),
+		field.NewBidTradeType(r.BidTradeType), field.NewBasisPxType(r.BasisPxType))
+	if r.ListName != "" {
+		msg.SetListName(r.ListName)
+	}
+	if r.Currency != "" {
+		msg.SetCurrency(r.Currency)
+	}
+	if r.TradeDate != "" {
+		msg.SetTradeDate(r.TradeDate)
+	}
+	components := bidrequest.NewNoBidComponentsRepeatingGroup()
+	for _, id := range r.ListIDs {
+		components.Add().SetListID(id)
+	}
+	msg.SetNoBidComponents(components)
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+	m.bids[clientBidID] = &Bid{ClientBidID: clientBidID, ListIDs: append([]string(nil), r.ListIDs...), UpdatedAt: time.Now()}
+	return clientBidID, nil
+}
+
+// OnBidResponse records the pricing of a BidRequest sent by the Manager,
+// its BidID is then sent by Execute on the lists priced
+func (m *Manager) OnBidResponse(r bidresponse.BidResponse) error {
+	clientBidID, _ := r.GetClientBidID()
+	bidID, _ := r.GetBidID()
+	var components []BidComponent
+	if g, err := r.GetNoBidComponents(); err == nil {
+		for _, row := range g.All() {
+			var c BidComponent
+			c.ListID, _ = row.GetListID()
+			c.Side, _ = row.GetSide()
+			c.Price, _ = row.GetPrice()
+			c.PriceType, _ = row.GetPriceType()
+			c.Commission, _ = row.GetCommission()
+			c.CommType, _ = row.GetCommType()
+			c.Text, _ = row.GetText()
+			components = append(components, c)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.unlock()
+	b, ok := m.bids[clientBidID]
+	if !ok {
+		return ErrUnknownBid
+	}
+	b.BidID, b.Components, b.UpdatedAt = bidID, components, time.Now()
+	for _, c := range components {
+		if l, ok := m.lists[c.ListID]; ok {
+			l.BidID, l.ClientBidID, l.UpdatedAt = bidID, clientBidID, time.Now()
+			m.changed(l)
+		}
+	}
+	if m.OnBid != nil {
+		c := b.clone()
+		m.callbacks = append(m.callbacks, func() { m.OnBid(c) })
+	}
+	return nil
+}
+
+// Bid returns a copy of the bid with the ClientBidID
+func (m *Manager) Bid(clientBidID string) (Bid, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.bids[clientBidID]
+	if !ok {
+		return Bid{}, false
+	}
+	return b.clone(), true
+}
@@ -0,0 +1,131 @@
+package listtrading
+
+import (
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+// Order is an order of a basket
+type Order struct {
+	// ClOrdID is generated by Submit if empty
+	ClOrdID  string
+	Account  string
+	Symbol   string
+	Side     enum.Side
+	OrdType  enum.OrdType
+	OrderQty decimal.Decimal
+	// Price is left out if zero
+	Price decimal.Decimal
+}
+
+// Basket is a list of orders traded together
+type Basket struct {
+	Orders []Order
+	// BidType defaults to no bidding process, disclosed and non disclosed lists
+	// are answered by a BidResponse before Execute
+	BidType enum.BidType
+	// Wait, if true, asks the counterparty to wait for Execute before working the orders
+	Wait bool
+	// ListExecInst is a free format instruction for the whole list
+	ListExecInst string
+}
+
+// ListOrder is the state of an order of a list
+type ListOrder struct {
+	Order
+	ListSeqNo int
+
+	OrdStatus enum.OrdStatus
+	CumQty    decimal.Decimal
+	LeavesQty decimal.Decimal
+	AvgPx     decimal.Decimal
+	Text      string
+}
+
+// Done returns true if the order cannot be filled anymore
+func (o ListOrder) Done() bool {
+	switch o.OrdStatus {
+	case enum.OrdStatus_FILLED, enum.OrdStatus_CANCELED, enum.OrdStatus_REJECTED, enum.OrdStatus_EXPIRED:
+		return true
+	}
+	return false
+}
+
+// List is the state of a NewOrderList as reported by ListStatus and ExecutionReports
+type List struct {
+	ListID  string
+	BidType enum.BidType
+	// BidID and ClientBidID are set once a BidResponse priced the list
+	BidID       string
+	ClientBidID string
+	// Orders are sorted by ListSeqNo
+	Orders []ListOrder
+
+	// ListStatusType and ListOrderStatus are those of the last ListStatus
+	ListStatusType  enum.ListStatusType
+	ListOrderStatus enum.ListOrderStatus
+	Text            string
+	// Executed and Canceled are set once Execute and Cancel were sent
+	Executed  bool
+	Canceled  bool
+	UpdatedAt time.Time
+}
+
+// OrderQty returns the total quantity of the orders
+func (l List) OrderQty() decimal.Decimal {
+	qty := decimal.Zero
+	for _, o := range l.Orders {
+		qty = qty.Add(o.OrderQty)
+	}
+	return qty
+}
+
+// CumQty returns the total executed quantity of the orders
+func (l List) CumQty() decimal.Decimal {
+	qty := decimal.Zero
+	for _, o := range l.Orders {
+		qty = qty.Add(o.CumQty)
+	}
+	return qty
+}
+
+// Value returns the total executed value, CumQty times AvgPx of the orders
+func (l List) Value() decimal.Decimal {
+	v := decimal.Zero
+	for _, o := range l.Orders {
+		v = v.Add(o.CumQty.Mul(o.AvgPx))
+	}
+	return v
+}
+
+// Done returns true once all the orders are done or the list was reported all done or rejected
+func (l List) Done() bool {
+	if l.ListOrderStatus == enum.ListOrderStatus_ALL_DONE || l.ListOrderStatus == enum.ListOrderStatus_REJECT {
+		return true
+	}
+	for _, o := range l.Orders {
+		if !o.Done() {
+			return false
+		}
+	}
+	return true
+}
+
+// clone returns a copy of l that does not share the Orders with it
+func (l *List) clone() List {
+	c := *l
+	c.Orders = append([]ListOrder(nil), l.Orders...)
+	return c
+}
+
+// scale returns the number of decimal places of v without its trailing zeros,
+// so that a quantity or a price is sent unrounded
+func scale(v decimal.Decimal) int32 {
+	var s int32
+	for !v.Truncate(s).Equal(v) {
+		s++
+	}
+	return s
+}
@@ -0,0 +1,396 @@
+package listtrading
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/bidresponse"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/listcancelrequest"
+	"github.com/quickfixgo/fix44/listexecute"
+	"github.com/quickfixgo/fix44/liststatus"
+	"github.com/quickfixgo/fix44/liststatusrequest"
+	"github.com/quickfixgo/fix44/neworderlist"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+var (
+	// ErrNoOrders is returned when submitting an empty basket
+	ErrNoOrders = errors.New("listtrading: no orders")
+	// ErrDuplicateClOrdID is returned for a basket reusing a ClOrdID already submitted
+	ErrDuplicateClOrdID = errors.New("listtrading: duplicate ClOrdID")
+	// ErrUnknownList is returned for a ListID not submitted by the Manager
+	ErrUnknownList = errors.New("listtrading: unknown ListID")
+	// ErrUnknownBid is returned for a BidResponse to a ClientBidID not sent by the Manager
+	ErrUnknownBid = errors.New("listtrading: unknown ClientBidID")
+	// ErrListDone is returned when executing or canceling a list that is done
+	ErrListDone = errors.New("listtrading: list is done")
+	// ErrStaleReport is returned for an ExecutionReport with a lower CumQty than the order
+	ErrStaleReport = errors.New("listtrading: stale execution report")
+	// ErrInvalidFragment is returned when submitting with a MaxOrdersPerMessage below 1
+	ErrInvalidFragment = errors.New("listtrading: MaxOrdersPerMessage must be positive")
+)
+
+// Manager submits baskets as NewOrderLists and aggregates their ListStatus and
+// ExecutionReports per list. A Manager is safe for concurrent use.
+type Manager struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// MaxOrdersPerMessage is the size of the NewOrderList fragments, it must be positive,
+	// New sets it to 100
+	MaxOrdersPerMessage int
+	// OnList, if set, is called with a copy of a list each time it changes
+	OnList func(List)
+	// OnBid, if set, is called with a copy of a bid answered by a BidResponse.
+	// OnList and OnBid are called once the Manager is unlocked, so they can call the Manager.
+	OnBid func(Bid)
+
+	mu       sync.Mutex
+	nextID   int
+	idPrefix string
+	lists    map[string]*List
+	// orders maps the ClOrdIDs to their list
+	orders  map[string]*List
+	execIDs map[string]bool
+	bids    map[string]*Bid
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns a Manager for the session
+func New(sessionID quickfix.SessionID) *Manager {
+	return &Manager{
+		SessionID:           sessionID,
+		Send:                quickfix.SendToTarget,
+		MaxOrdersPerMessage: 100,
+		idPrefix:            time.Now().Format("150405") + "-",
+		lists:               make(map[string]*List),
+		orders:              make(map[string]*List),
+		execIDs:             make(map[string]bool),
+		bids:                make(map[string]*Bid),
+	}
+}
+
+// newID must be called with mu held
+func (m *Manager) newID() string {
+	m.nextID++
+	return fmt.Sprintf("%s%d", m.idPrefix, m.nextID)
+}
+
+// changed queues the call of OnList with a copy of l, it must be called with mu held
+func (m *Manager) changed(l *List) {
+	if m.OnList != nil {
+		c := l.clone()
+		m.callbacks = append(m.callbacks, func() { m.OnList(c) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (m *Manager) unlock() {
+	callbacks := m.callbacks
+	m.callbacks = nil
+	m.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// Submit sends the basket as a NewOrderList, in fragments of MaxOrdersPerMessage orders
+// sharing the ListID and TotNoOrders. It returns the ListID. If a fragment but the first
+// fails to be sent, the list is canceled and its ListID is returned with the error.
+func (m *Manager) Submit(b Basket) (string, error) {
+	if len(b.Orders) == 0 {
+		return "", ErrNoOrders
+	}
+	if m.MaxOrdersPerMessage < 1 {
+		return "", ErrInvalidFragment
+	}
+	if b.BidType == "" {
+		b.BidType = enum.BidType_NO_BIDDING_PROCESS
+	}
+
+	m.mu.Lock()
+	defer m.unlock()
+	l := &List{ListID: m.newID(), BidType: b.BidType, UpdatedAt: time.Now()}
+	seen := make(map[string]bool, len(b.Orders))
+	for i, o := range b.Orders {
+		if o.ClOrdID == "" {
+			o.ClOrdID = m.newID()
+		}
+		if _, ok := m.orders[o.ClOrdID]; ok || seen[o.ClOrdID] {
+			return "", ErrDuplicateClOrdID
+		}
+		seen[o.ClOrdID] = true
+		l.Orders = append(l.Orders, ListOrder{Order: o, ListSeqNo: i + 1,
+			OrdStatus: enum.OrdStatus_PENDING_NEW, LeavesQty: o.OrderQty})
+	}
+
+	fragment := m.MaxOrdersPerMessage
+	for i := 0; i < len(l.Orders); i += fragment {
+		end := i + fragment
+		if end > len(l.Orders) {
+			end = len(l.Orders)
+		}
+		msg := neworderlist.New(field.NewListID(l.ListID), field.NewBidType(b.BidType), field.NewTotNoOrders(len(l.Orders)))
+		msg.SetListExecInstType(enum.ListExecInstType_IMMEDIATE)
+		if b.Wait {
+			msg.SetListExecInstType(enum.ListExecInstType_WAIT_FOR_EXECUTE_INSTRUCTION)
+		}
+		if b.ListExecInst != "" {
+			msg.SetListExecInst(b.ListExecInst)
+		}
+		if len(l.Orders) > fragment {
+			msg.SetLastFragment(end == len(l.Orders))
+		}
+		msg.SetNoOrders(noOrders(l.Orders[i:end]))
+		if err := m.Send(msg, m.SessionID); err != nil {
+			if i == 0 {
+				return "", err
+			}
+			// the fragments already sent are live, cancel them
+			m.add(l)
+			m.cancel(l)
+			return l.ListID, err
+		}
+	}
+	m.add(l)
+	return l.ListID, nil
+}
+
+// add must be called with mu held
+func (m *Manager) add(l *List) {
+	m.lists[l.ListID] = l
+	for _, o := range l.Orders {
+		m.orders[o.ClOrdID] = l
+	}
+	m.changed(l)
+}
+
+func noOrders(orders []ListOrder) neworderlist.NoOrdersRepeatingGroup {
+	g := neworderlist.NewNoOrdersRepeatingGroup()
+	now := time.Now()
+	for _, o := range orders {
+		row := g.Add()
+		row.SetClOrdID(o.ClOrdID)
+		row.SetListSeqNo(o.ListSeqNo)
+		if o.Account != "" {
+			row.SetAccount(o.Account)
+		}
+		row.SetSymbol(o.Symbol)
+		row.SetSide(o.Side)
+		row.SetTransactTime(now)
+		row.SetOrderQty(o.OrderQty, scale(o.OrderQty))
+		row.SetOrdType(o.OrdType)
+		if !o.Price.IsZero() {
+			row.SetPrice(o.Price, scale(o.Price))
+		}
+	}
+	return g
+}
+
+// open must be called with mu held
+func (m *Manager) open(listID string) (*List, error) {
+	l, ok := m.lists[listID]
+	if !ok {
+		return nil, ErrUnknownList
+	}
+	if l.Done() {
+		return nil, ErrListDone
+	}
+	return l, nil
+}
+
+// Execute sends a ListExecute for a list submitted with Wait,
+// with the BidID of its BidResponse if it was priced
+func (m *Manager) Execute(listID string) error {
+	m.mu.Lock()
+	defer m.unlock()
+	l, err := m.open(listID)
+	if err != nil {
+		return err
+	}
+	msg := listexecute.New(field.NewListID(listID), field.NewTransactTime(time.Now()))
+	if l.BidID != "" {
+		msg.SetBidID(l.BidID)
+	}
+	if l.ClientBidID != "" {
+		msg.SetClientBidID(l.ClientBidID)
+	}
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return err
+	}
+	l.Executed = true
+	l.UpdatedAt = time.Now()
+	m.changed(l)
+	return nil
+}
+
+// Cancel sends a ListCancelRequest for the orders of a list not done yet
+func (m *Manager) Cancel(listID string) error {
+	m.mu.Lock()
+	defer m.unlock()
+	l, err := m.open(listID)
+	if err != nil {
+		return err
+	}
+	return m.cancel(l)
+}
+
+// cancel must be called with mu held
+func (m *Manager) cancel(l *List) error {
+	msg := listcancelrequest.New(field.NewListID(l.ListID), field.NewTransactTime(time.Now()))
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return err
+	}
+	l.Canceled = true
+	l.UpdatedAt = time.Now()
+	m.changed(l)
+	return nil
+}
+
+// Status sends a ListStatusRequest, the answer is applied by Process
+func (m *Manager) Status(listID string) error {
+	m.mu.Lock()
+	_, ok := m.lists[listID]
+	m.mu.Unlock()
+	if !ok {
+		return ErrUnknownList
+	}
+	return m.Send(liststatusrequest.New(field.NewListID(listID)), m.SessionID)
+}
+
+// Process handles ListStatus, BidResponse and the ExecutionReports of the list orders,
+// other messages are ignored. It is meant to be called from FromApp.
+func (m *Manager) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "N":
+		return m.OnListStatus(liststatus.FromMessage(msg))
+	case "8":
+		return m.OnExecutionReport(executionreport.FromMessage(msg))
+	case "l":
+		return m.OnBidResponse(bidresponse.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnListStatus applies the list and order statuses of a ListStatus,
+// each report of a multi-report ListStatus updates the orders it lists
+func (m *Manager) OnListStatus(s liststatus.ListStatus) error {
+	listID, err := s.GetListID()
+	if err != nil {
+		return err
+	}
+	statusType, _ := s.GetListStatusType()
+	orderStatus, _ := s.GetListOrderStatus()
+	text, _ := s.GetListStatusText()
+	var rows []liststatus.NoOrders
+	if g, err := s.GetNoOrders(); err == nil {
+		rows = g.Slice()
+	}
+
+	m.mu.Lock()
+	defer m.unlock()
+	l, ok := m.lists[listID]
+	if !ok {
+		return ErrUnknownList
+	}
+	l.ListStatusType, l.ListOrderStatus, l.Text = statusType, orderStatus, text
+	for _, row := range rows {
+		clOrdID, _ := row.GetClOrdID()
+		o := l.order(clOrdID)
+		if o == nil {
+			continue
+		}
+		if v, err := row.GetOrdStatus(); err == nil {
+			o.OrdStatus = v
+		}
+		if v, err := row.GetCumQty(); err == nil {
+			o.CumQty = v
+		}
+		if v, err := row.GetLeavesQty(); err == nil {
+			o.LeavesQty = v
+		}
+		if v, err := row.GetAvgPx(); err == nil {
+			o.AvgPx = v
+		}
+		o.Text, _ = row.GetText()
+	}
+	l.UpdatedAt = time.Now()
+	m.changed(l)
+	return nil
+}
+
+// order returns the order of the list with the ClOrdID, or nil
+func (l *List) order(clOrdID string) *ListOrder {
+	for i := range l.Orders {
+		if l.Orders[i].ClOrdID == clOrdID {
+			return &l.Orders[i]
+		}
+	}
+	return nil
+}
+
+// OnExecutionReport applies an ExecutionReport to its list order,
+// the reports of orders outside the lists are ignored
+func (m *Manager) OnExecutionReport(r executionreport.ExecutionReport) error {
+	execID, err := r.GetExecID()
+	if err != nil {
+		return err
+	}
+	clOrdID, _ := r.GetClOrdID()
+	ordStatus, _ := r.GetOrdStatus()
+	cumQty, _ := r.GetCumQty()
+	leavesQty, _ := r.GetLeavesQty()
+	avgPx, _ := r.GetAvgPx()
+	text, _ := r.GetText()
+
+	m.mu.Lock()
+	defer m.unlock()
+	l, ok := m.orders[clOrdID]
+	if !ok || m.execIDs[execID] {
+		return nil
+	}
+	o := l.order(clOrdID)
+	if cumQty.LessThan(o.CumQty) {
+		return ErrStaleReport
+	}
+	m.execIDs[execID] = true
+	o.OrdStatus, o.CumQty, o.LeavesQty, o.AvgPx, o.Text = ordStatus, cumQty, leavesQty, avgPx, text
+	l.UpdatedAt = time.Now()
+	m.changed(l)
+	return nil
+}
+
+// List returns a copy of the list with the ListID
+func (m *Manager) List(listID string) (List, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.lists[listID]
+	if !ok {
+		return List{}, false
+	}
+	return l.clone(), true
+}
+
+// Lists returns copies of all the lists, sorted by ListID
+func (m *Manager) Lists() []List {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ls := make([]List, 0, len(m.lists))
+	for _, l := range m.lists {
+		ls = append(ls, l.clone())
+	}
+	sort.Slice(ls, func(i, j int) bool { return ls[i].ListID < ls[j].ListID })
+	return ls
+}
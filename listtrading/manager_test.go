@@ -0,0 +1,151 @@
+package listtrading
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/neworderlist"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var errSend = errors.New("send failed")
+
+// fragment is a message sent: its MsgType, and for a NewOrderList its number of
+// orders and LastFragment
+type fragment struct {
+	msgType string
+	orders  int
+	last    string
+}
+
+// fragmentOf returns the fragment of a message sent
+func fragmentOf(msg *quickfix.Message) fragment {
+	var f fragment
+	f.msgType, _ = msg.Header.GetString(tag.MsgType)
+	if f.msgType == "E" {
+		g, _ := neworderlist.FromMessage(msg).GetNoOrders()
+		f.orders = g.Len()
+		f.last, _ = msg.Body.GetString(tag.LastFragment)
+	}
+	return f
+}
+
+func basket(n int) Basket {
+	var b Basket
+	for i := 0; i < n; i++ {
+		b.Orders = append(b.Orders, Order{Symbol: "VND", Side: enum.Side_BUY, OrdType: enum.OrdType_LIMIT,
+			OrderQty: decimal.NewFromInt(100), Price: decimal.NewFromInt(10)})
+	}
+	return b
+}
+
+func TestSubmitFragmentation(t *testing.T) {
+	tests := []struct {
+		name    string
+		orders  int
+		size    int
+		want    []fragment
+		wantErr error
+	}{
+		{"one message", 3, 5, []fragment{{"E", 3, ""}}, nil},
+		{"exact", 4, 4, []fragment{{"E", 4, ""}}, nil},
+		{"fragments", 5, 2, []fragment{{"E", 2, "N"}, {"E", 2, "N"}, {"E", 1, "Y"}}, nil},
+		{"no orders", 0, 2, nil, ErrNoOrders},
+		{"zero size", 3, 0, nil, ErrInvalidFragment},
+		{"negative size", 3, -1, nil, ErrInvalidFragment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(quickfix.SessionID{})
+			m.MaxOrdersPerMessage = tt.size
+			var sent []fragment
+			m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+				sent = append(sent, fragmentOf(msg.ToMessage()))
+				return nil
+			}
+			listID, err := m.Submit(basket(tt.orders))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(sent, tt.want) {
+				t.Errorf("got %v, want %v", sent, tt.want)
+			}
+			if l, ok := m.List(listID); tt.wantErr == nil && (!ok || len(l.Orders) != tt.orders) {
+				t.Errorf("got list %+v", l)
+			}
+		})
+	}
+}
+
+func TestSubmitSendFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		// fail is the number of the message failing to be sent, from 1
+		fail       int
+		want       []fragment
+		wantListID bool
+	}{
+		{"first fragment", 1, nil, false},
+		{"later fragment", 2, []fragment{{"E", 2, "N"}, {"K", 0, ""}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(quickfix.SessionID{})
+			m.MaxOrdersPerMessage = 2
+			var sent []fragment
+			calls := 0
+			m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+				if calls++; calls == tt.fail {
+					return errSend
+				}
+				sent = append(sent, fragmentOf(msg.ToMessage()))
+				return nil
+			}
+			listID, err := m.Submit(basket(5))
+			if !errors.Is(err, errSend) {
+				t.Fatalf("got error %v, want %v", err, errSend)
+			}
+			if !reflect.DeepEqual(sent, tt.want) {
+				t.Errorf("got %v, want %v", sent, tt.want)
+			}
+			if (listID != "") != tt.wantListID {
+				t.Fatalf("got ListID %q", listID)
+			}
+			l, ok := m.List(listID)
+			if ok != tt.wantListID || ok && !l.Canceled {
+				t.Errorf("got list %+v, registered %v", l, ok)
+			}
+		})
+	}
+}
+
+func TestSubmitScales(t *testing.T) {
+	m := New(quickfix.SessionID{})
+	var sent *quickfix.Message
+	m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+		sent = msg.ToMessage()
+		return nil
+	}
+	b := basket(3)
+	b.Orders[0].OrderQty, b.Orders[0].Price = decimal.RequireFromString("12.5"), decimal.RequireFromString("18.125")
+	b.Orders[1].OrderQty = decimal.RequireFromString("0.001")
+	if _, err := m.Submit(b); err != nil {
+		t.Fatal(err)
+	}
+	g, err := neworderlist.FromMessage(sent).GetNoOrders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]string{{"12.5", "18.125"}, {"0.001", "10"}, {"100", "10"}}
+	for i, w := range want {
+		qty, _ := g.Get(i).GetString(tag.OrderQty)
+		px, _ := g.Get(i).GetString(tag.Price)
+		if qty != w[0] || px != w[1] {
+			t.Errorf("order %d: got %s@%s, want %s@%s", i, qty, px, w[0], w[1])
+		}
+	}
+}
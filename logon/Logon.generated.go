@@ -1,6 +1,8 @@
 package logon
 
 import (
+	"iter"
+
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/fix44"
@@ -328,3 +330,41 @@ func (m NoMsgTypesRepeatingGroup) Add() NoMsgTypes {
 func (m NoMsgTypesRepeatingGroup) Get(i int) NoMsgTypes {
 	return NoMsgTypes{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoMsgTypes of each entry in the NoMsgTypesRepeatingGroup
+func (m NoMsgTypesRepeatingGroup) All() iter.Seq2[int, NoMsgTypes] {
+	return func(yield func(int, NoMsgTypes) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoMsgTypes entries of the NoMsgTypesRepeatingGroup as a slice
+func (m NoMsgTypesRepeatingGroup) Slice() []NoMsgTypes {
+	s := make([]NoMsgTypes, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoMsgTypesRow holds the values of a NoMsgTypes, nil fields are left unset by AddRow
+type NoMsgTypesRow struct {
+	RefMsgType   *string
+	MsgDirection *enum.MsgDirection
+}
+
+//AddRow creates and appends a new NoMsgTypes to this group, setting the fields present in row
+func (m NoMsgTypesRepeatingGroup) AddRow(row NoMsgTypesRow) NoMsgTypes {
+	g := m.Add()
+	if row.RefMsgType != nil {
+		g.SetRefMsgType(*row.RefMsgType)
+	}
+	if row.MsgDirection != nil {
+		g.SetMsgDirection(*row.MsgDirection)
+	}
+	return g
+}
@@ -1,6 +1,8 @@
 package marketdatarequestreject
 
 import (
+	"iter"
+
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/fix44"
@@ -213,3 +215,37 @@ func (m NoAltMDSourceRepeatingGroup) Add() NoAltMDSource {
 func (m NoAltMDSourceRepeatingGroup) Get(i int) NoAltMDSource {
 	return NoAltMDSource{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoAltMDSource of each entry in the NoAltMDSourceRepeatingGroup
+func (m NoAltMDSourceRepeatingGroup) All() iter.Seq2[int, NoAltMDSource] {
+	return func(yield func(int, NoAltMDSource) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoAltMDSource entries of the NoAltMDSourceRepeatingGroup as a slice
+func (m NoAltMDSourceRepeatingGroup) Slice() []NoAltMDSource {
+	s := make([]NoAltMDSource, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoAltMDSourceRow holds the values of a NoAltMDSource, nil fields are left unset by AddRow
+type NoAltMDSourceRow struct {
+	AltMDSourceID *string
+}
+
+//AddRow creates and appends a new NoAltMDSource to this group, setting the fields present in row
+func (m NoAltMDSourceRepeatingGroup) AddRow(row NoAltMDSourceRow) NoAltMDSource {
+	g := m.Add()
+	if row.AltMDSourceID != nil {
+		g.SetAltMDSourceID(*row.AltMDSourceID)
+	}
+	return g
+}
@@ -0,0 +1,48 @@
+package mask
+
+import (
+	"github.com/quickfixgo/quickfix"
+)
+
+// LogFactory wraps a quickfix.LogFactory, e.g. a file or a screen log factory,
+// so that the messages logged have their masked values replaced
+type LogFactory struct {
+	Factory quickfix.LogFactory
+	Masker  *Masker
+}
+
+// NewLogFactory returns a LogFactory masking the messages logged by f
+func NewLogFactory(f quickfix.LogFactory, m *Masker) LogFactory {
+	return LogFactory{Factory: f, Masker: m}
+}
+
+// Create implements quickfix.LogFactory
+func (f LogFactory) Create() (quickfix.Log, error) {
+	l, err := f.Factory.Create()
+	if err != nil {
+		return nil, err
+	}
+	return maskedLog{l, f.Masker}, nil
+}
+
+// CreateSessionLog implements quickfix.LogFactory
+func (f LogFactory) CreateSessionLog(sessionID quickfix.SessionID) (quickfix.Log, error) {
+	l, err := f.Factory.CreateSessionLog(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return maskedLog{l, f.Masker}, nil
+}
+
+type maskedLog struct {
+	quickfix.Log
+	masker *Masker
+}
+
+func (l maskedLog) OnIncoming(b []byte) {
+	l.Log.OnIncoming(l.masker.Bytes(b))
+}
+
+func (l maskedLog) OnOutgoing(b []byte) {
+	l.Log.OnOutgoing(l.masker.Bytes(b))
+}
@@ -0,0 +1,146 @@
+package mask
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// DefaultReplacement replaces the masked values
+const DefaultReplacement = "***"
+
+// DefaultTags are the credentials of Logon and UserRequest, and Account
+var DefaultTags = []quickfix.Tag{tag.Password, tag.NewPassword, tag.RawData, tag.Account}
+
+// dataTags are the data fields by their length field, the values of data fields
+// may contain SOH and are read by length
+var dataTags = map[quickfix.Tag]quickfix.Tag{
+	tag.RawDataLength:   tag.RawData,
+	tag.SecureDataLen:   tag.SecureData,
+	tag.SignatureLength: tag.Signature,
+	tag.XmlDataLen:      tag.XmlData,
+	tag.EncodedTextLen:  tag.EncodedText,
+}
+
+// Masker redacts the values of sensitive tags in the renderings of messages,
+// the messages themselves are left untouched
+type Masker struct {
+	Tags        map[quickfix.Tag]bool
+	Replacement string
+}
+
+// New returns a Masker of the tags, of DefaultTags if none is given
+func New(tags ...quickfix.Tag) *Masker {
+	if len(tags) == 0 {
+		tags = DefaultTags
+	}
+	m := &Masker{Tags: make(map[quickfix.Tag]bool), Replacement: DefaultReplacement}
+	for _, t := range tags {
+		m.Tags[t] = true
+	}
+	return m
+}
+
+// Redact returns the Replacement if t is masked, value otherwise.
+// It can be used as the Redact hook of a fixfmt.Printer.
+func (m *Masker) Redact(t quickfix.Tag, value string) string {
+	if m.Tags[t] {
+		return m.Replacement
+	}
+	return value
+}
+
+// Field is a field of the wire form of a message
+type Field struct {
+	Tag   quickfix.Tag
+	Value []byte
+}
+
+// Fields splits the wire form of a message in wire order, reading the data fields by length
+// since their values may contain SOH
+func Fields(raw []byte) []Field {
+	var out []Field
+	var dataTag quickfix.Tag
+	dataLen := -1
+	for len(raw) > 0 {
+		eq := bytes.IndexByte(raw, '=')
+		if eq <= 0 {
+			break
+		}
+		t, err := strconv.Atoi(string(raw[:eq]))
+		if err != nil {
+			break
+		}
+		raw = raw[eq+1:]
+		end := bytes.IndexByte(raw, '\x01')
+		if quickfix.Tag(t) == dataTag && dataLen >= 0 && dataLen <= len(raw) {
+			end = dataLen
+		}
+		if end < 0 {
+			end = len(raw)
+		}
+		f := Field{Tag: quickfix.Tag(t), Value: raw[:end]}
+		out = append(out, f)
+		raw = raw[end:]
+		if len(raw) > 0 {
+			raw = raw[1:]
+		}
+		dataTag, dataLen = 0, -1
+		if t, ok := dataTags[f.Tag]; ok {
+			if n, err := strconv.Atoi(string(f.Value)); err == nil {
+				dataTag, dataLen = t, n
+			}
+		}
+	}
+	return out
+}
+
+// Bytes returns a copy of the wire form of a message with the masked values replaced,
+// e.g. of the messages logged. The BodyLength and CheckSum are not recomputed.
+func (m *Masker) Bytes(raw []byte) []byte {
+	var b bytes.Buffer
+	for _, f := range Fields(raw) {
+		b.WriteString(strconv.Itoa(int(f.Tag)))
+		b.WriteByte('=')
+		if m.Tags[f.Tag] {
+			b.WriteString(m.Replacement)
+		} else {
+			b.Write(f.Value)
+		}
+		b.WriteByte('\x01')
+	}
+	return b.Bytes()
+}
+
+// Wire returns the wire form of a copy of msg, since rendering msg itself
+// sets its BodyLength and CheckSum
+func Wire(msg *quickfix.Message) []byte {
+	cp := quickfix.NewMessage()
+	msg.CopyInto(cp)
+	return []byte(cp.String())
+}
+
+// String returns the wire form of msg with the masked values replaced, msg is left untouched
+func (m *Masker) String(msg *quickfix.Message) string {
+	return string(m.Bytes(Wire(msg)))
+}
+
+// JSONField is a field of the JSON dump of a message
+type JSONField struct {
+	Tag   int    `json:"tag"`
+	Value string `json:"value"`
+}
+
+// JSON returns the fields of msg in wire order as a JSON array, with the masked values replaced,
+// msg is left untouched
+func (m *Masker) JSON(msg *quickfix.Message) ([]byte, error) {
+	fs := Fields(Wire(msg))
+	out := make([]JSONField, len(fs))
+	for i, f := range fs {
+		out[i] = JSONField{Tag: int(f.Tag), Value: m.Redact(f.Tag, string(f.Value))}
+	}
+	return json.Marshal(out)
+}
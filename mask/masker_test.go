@@ -0,0 +1,67 @@
+package mask
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []quickfix.Tag
+		raw  string
+		want string
+	}{
+		{"masked", nil, "35=A\x01553=u\x01554=pw\x01", "35=A\x01553=u\x01554=***\x01"},
+		{"nothing to mask", nil, "35=D\x0111=C1\x01", "35=D\x0111=C1\x01"},
+		{"data field with SOH", nil, "35=A\x0195=5\x0196=a\x01b=c\x0198=0\x01", "35=A\x0195=5\x0196=***\x0198=0\x01"},
+		{"data field not masked", []quickfix.Tag{tag.Password}, "35=A\x0195=5\x0196=a\x01b=c\x01", "35=A\x0195=5\x0196=a\x01b=c\x01"},
+		{"length past the end", nil, "35=A\x0195=9\x0196=ab\x01", "35=A\x0195=9\x0196=***\x01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(New(tt.tags...).Bytes([]byte(tt.raw))); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringJSON(t *testing.T) {
+	msg := quickfix.NewMessage()
+	msg.Header.SetString(tag.MsgType, "A")
+	msg.Body.SetString(tag.Username, "u")
+	msg.Body.SetString(tag.Password, "pw")
+	m := New()
+
+	if s := m.String(msg); !strings.Contains(s, "\x01553=u\x01554=***\x01") {
+		t.Errorf("got %q", s)
+	}
+	b, err := m.JSON(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fs []JSONField
+	if err := json.Unmarshal(b, &fs); err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[int]string)
+	for _, f := range fs {
+		values[f.Tag] = f.Value
+	}
+	if values[int(tag.Username)] != "u" || values[int(tag.Password)] != "***" {
+		t.Errorf("got %s", b)
+	}
+
+	// the message itself is left untouched
+	if pw, _ := msg.Body.GetString(tag.Password); pw != "pw" {
+		t.Errorf("got Password %q", pw)
+	}
+	if msg.Header.Has(tag.BodyLength) || msg.Trailer.Has(tag.CheckSum) {
+		t.Error("got BodyLength and CheckSum set on the message")
+	}
+}
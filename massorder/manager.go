@@ -0,0 +1,344 @@
+package massorder
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/ordermasscancelreport"
+	"github.com/quickfixgo/fix44/ordermasscancelrequest"
+	"github.com/quickfixgo/fix44/ordermassstatusrequest"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// ErrUnknownRequest is returned for a report of a mass request not sent by the Manager
+var ErrUnknownRequest = errors.New("massorder: unknown mass request")
+
+// Manager sends OrderMassCancelRequests and OrderMassStatusRequests and collects
+// their OrderMassCancelReports and ExecutionReports. The ExecutionReports should
+// also be passed to the order state, e.g. an ordertracker.Tracker.
+// A Manager is safe for concurrent use.
+type Manager struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// OnCancel, if set, is called with a copy of a mass cancel each time it changes
+	OnCancel func(MassCancel)
+	// OnStatus, if set, is called with a copy of a mass status request once done.
+	// OnCancel and OnStatus are called once the Manager is unlocked, so they can call the Manager.
+	OnStatus func(MassStatus)
+
+	mu       sync.Mutex
+	nextID   int
+	idPrefix string
+	cancels  map[string]*MassCancel
+	statuses map[string]*MassStatus
+	// early maps the OrderIDs of the cancel ExecutionReports received while a mass cancel
+	// waits for its OrderMassCancelReport to their OrigClOrdID
+	early map[string]string
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns a Manager for the session
+func New(sessionID quickfix.SessionID) *Manager {
+	return &Manager{
+		SessionID: sessionID,
+		Send:      quickfix.SendToTarget,
+		idPrefix:  time.Now().Format("150405") + "-",
+		cancels:   make(map[string]*MassCancel),
+		statuses:  make(map[string]*MassStatus),
+		early:     make(map[string]string),
+	}
+}
+
+// newID must be called with mu held
+func (m *Manager) newID() string {
+	m.nextID++
+	return fmt.Sprintf("%s%d", m.idPrefix, m.nextID)
+}
+
+// changed queues the call of OnCancel with a copy of c, it must be called with mu held
+func (m *Manager) changed(c *MassCancel) {
+	if m.OnCancel != nil {
+		cp := c.clone()
+		m.callbacks = append(m.callbacks, func() { m.OnCancel(cp) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (m *Manager) unlock() {
+	callbacks := m.callbacks
+	m.callbacks = nil
+	m.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// MassCancel sends an OrderMassCancelRequest of the type for the orders in scope,
+// it returns the ClOrdID of the request
+func (m *Manager) MassCancel(requestType enum.MassCancelRequestType, s Scope) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := m.newID()
+	msg := ordermasscancelrequest.New(field.NewClOrdID(id), field.NewMassCancelRequestType(requestType), field.NewTransactTime(time.Now()))
+	if s.Symbol != "" {
+		msg.SetSymbol(s.Symbol)
+	}
+	if s.SecurityID != "" {
+		msg.SetSecurityID(s.SecurityID)
+	}
+	if s.SecurityType != "" {
+		msg.SetSecurityType(s.SecurityType)
+	}
+	if s.Side != "" {
+		msg.SetSide(s.Side)
+	}
+	if s.TradingSessionID != "" {
+		msg.SetTradingSessionID(s.TradingSessionID)
+	}
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+	m.cancels[id] = &MassCancel{ClOrdID: id, RequestType: requestType, Scope: s, UpdatedAt: time.Now()}
+	return id, nil
+}
+
+// CancelSymbol cancels the orders of an instrument, of one side if side is not empty
+func (m *Manager) CancelSymbol(symbol string, side enum.Side) (string, error) {
+	return m.MassCancel(enum.MassCancelRequestType_CANCEL_ORDERS_FOR_A_SECURITY, Scope{Symbol: symbol, Side: side})
+}
+
+// CancelSession cancels the orders of a trading session
+func (m *Manager) CancelSession(tradingSessionID enum.TradingSessionID) (string, error) {
+	return m.MassCancel(enum.MassCancelRequestType_CANCEL_ORDERS_FOR_A_TRADING_SESSION, Scope{TradingSessionID: tradingSessionID})
+}
+
+// CancelAll cancels all the orders
+func (m *Manager) CancelAll() (string, error) {
+	return m.MassCancel(enum.MassCancelRequestType_CANCEL_ALL_ORDERS, Scope{})
+}
+
+// MassStatus sends an OrderMassStatusRequest of the type for the orders in scope,
+// it returns the MassStatusReqID
+func (m *Manager) MassStatus(requestType enum.MassStatusReqType, s Scope) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := m.newID()
+	msg := ordermassstatusrequest.New(field.NewMassStatusReqID(id), field.NewMassStatusReqType(requestType))
+	if s.Account != "" {
+		msg.SetAccount(s.Account)
+	}
+	if s.Symbol != "" {
+		msg.SetSymbol(s.Symbol)
+	}
+	if s.SecurityID != "" {
+		msg.SetSecurityID(s.SecurityID)
+	}
+	if s.SecurityType != "" {
+		msg.SetSecurityType(s.SecurityType)
+	}
+	if s.Side != "" {
+		msg.SetSide(s.Side)
+	}
+	if s.TradingSessionID != "" {
+		msg.SetTradingSessionID(s.TradingSessionID)
+	}
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+	m.statuses[id] = &MassStatus{MassStatusReqID: id, RequestType: requestType, Scope: s, UpdatedAt: time.Now()}
+	return id, nil
+}
+
+// StatusSymbol requests the status of the orders of an instrument, of an account if not empty
+func (m *Manager) StatusSymbol(symbol, account string) (string, error) {
+	return m.MassStatus(enum.MassStatusReqType_STATUS_FOR_ORDERS_FOR_A_SECURITY, Scope{Symbol: symbol, Account: account})
+}
+
+// StatusSession requests the status of the orders of a trading session
+func (m *Manager) StatusSession(tradingSessionID enum.TradingSessionID) (string, error) {
+	return m.MassStatus(enum.MassStatusReqType_STATUS_FOR_ORDERS_FOR_A_TRADING_SESSION, Scope{TradingSessionID: tradingSessionID})
+}
+
+// StatusAll requests the status of all the orders, of an account if not empty,
+// e.g. to resynchronize the order states after a reconnect
+func (m *Manager) StatusAll(account string) (string, error) {
+	return m.MassStatus(enum.MassStatusReqType_STATUS_FOR_ALL_ORDERS, Scope{Account: account})
+}
+
+// Process handles OrderMassCancelReports and ExecutionReports, other messages are ignored.
+// It is meant to be called from FromApp.
+func (m *Manager) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "r":
+		return m.OnOrderMassCancelReport(ordermasscancelreport.FromMessage(msg))
+	case "8":
+		return m.OnExecutionReport(executionreport.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnOrderMassCancelReport records the response and the affected orders of a mass cancel
+func (m *Manager) OnOrderMassCancelReport(r ordermasscancelreport.OrderMassCancelReport) error {
+	response, err := r.GetMassCancelResponse()
+	if err != nil {
+		return err
+	}
+	clOrdID, _ := r.GetClOrdID()
+	reason, _ := r.GetMassCancelRejectReason()
+	text, _ := r.GetText()
+	total, _ := r.GetTotalAffectedOrders()
+	var affected []AffectedOrder
+	if g, err := r.GetNoAffectedOrders(); err == nil {
+		for _, row := range g.All() {
+			var a AffectedOrder
+			a.OrigClOrdID, _ = row.GetOrigClOrdID()
+			a.OrderID, _ = row.GetAffectedOrderID()
+			affected = append(affected, a)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.unlock()
+	c, ok := m.cancels[clOrdID]
+	if !ok {
+		return ErrUnknownRequest
+	}
+	c.Done, c.Response, c.RejectReason, c.Text = true, response, reason, text
+	c.TotalAffected = total
+	if total < len(affected) {
+		c.TotalAffected = len(affected)
+	}
+	c.Affected = append(c.Affected, affected...)
+	c.UpdatedAt = time.Now()
+	for orderID, origClOrdID := range m.early {
+		if c.cancel(orderID, origClOrdID) {
+			delete(m.early, orderID)
+		}
+	}
+	if !m.waiting() {
+		m.early = make(map[string]string)
+	}
+	m.changed(c)
+	return nil
+}
+
+// waiting must be called with mu held, it tells if a mass cancel waits for its OrderMassCancelReport
+func (m *Manager) waiting() bool {
+	for _, c := range m.cancels {
+		if !c.Done {
+			return true
+		}
+	}
+	return false
+}
+
+// OnExecutionReport collects the status reports of the mass status requests
+// and the cancels of the orders affected by mass cancels, other reports are ignored
+func (m *Manager) OnExecutionReport(r executionreport.ExecutionReport) error {
+	execType, err := r.GetExecType()
+	if err != nil {
+		return err
+	}
+	switch execType {
+	case enum.ExecType_ORDER_STATUS:
+		return m.onStatus(r)
+	case enum.ExecType_CANCELED:
+		m.onCanceled(r)
+	}
+	return nil
+}
+
+func (m *Manager) onStatus(r executionreport.ExecutionReport) error {
+	reqID, _ := r.GetMassStatusReqID()
+	if reqID == "" {
+		return nil
+	}
+	var o OrderStatus
+	o.ClOrdID, _ = r.GetClOrdID()
+	o.OrderID, _ = r.GetOrderID()
+	o.Account, _ = r.GetAccount()
+	o.Symbol, _ = r.GetSymbol()
+	o.Side, _ = r.GetSide()
+	o.OrdStatus, _ = r.GetOrdStatus()
+	o.OrderQty, _ = r.GetOrderQty()
+	o.CumQty, _ = r.GetCumQty()
+	o.LeavesQty, _ = r.GetLeavesQty()
+	o.AvgPx, _ = r.GetAvgPx()
+	o.Text, _ = r.GetText()
+	total, _ := r.GetTotNumReports()
+	last, _ := r.GetLastRptRequested()
+
+	m.mu.Lock()
+	defer m.unlock()
+	s, ok := m.statuses[reqID]
+	if !ok {
+		return ErrUnknownRequest
+	}
+	if s.Done {
+		return nil
+	}
+	s.Orders = append(s.Orders, o)
+	if total > 0 {
+		s.TotNumReports = total
+	}
+	s.Done = last || (s.TotNumReports > 0 && len(s.Orders) >= s.TotNumReports)
+	s.UpdatedAt = time.Now()
+	if s.Done && m.OnStatus != nil {
+		cp := s.clone()
+		m.callbacks = append(m.callbacks, func() { m.OnStatus(cp) })
+	}
+	return nil
+}
+
+// onCanceled marks canceled the affected order of a cancel ExecutionReport. A report received
+// before the OrderMassCancelReport listing its order is kept until the report arrives.
+func (m *Manager) onCanceled(r executionreport.ExecutionReport) {
+	origClOrdID, _ := r.GetOrigClOrdID()
+	orderID, _ := r.GetOrderID()
+
+	m.mu.Lock()
+	defer m.unlock()
+	for _, c := range m.cancels {
+		if c.cancel(orderID, origClOrdID) {
+			m.changed(c)
+			return
+		}
+	}
+	if orderID != "" && m.waiting() {
+		m.early[orderID] = origClOrdID
+	}
+}
+
+// Cancel returns a copy of the mass cancel with the ClOrdID
+func (m *Manager) Cancel(clOrdID string) (MassCancel, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.cancels[clOrdID]
+	if !ok {
+		return MassCancel{}, false
+	}
+	return c.clone(), true
+}
+
+// Status returns a copy of the mass status request with the MassStatusReqID
+func (m *Manager) Status(massStatusReqID string) (MassStatus, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.statuses[massStatusReqID]
+	if !ok {
+		return MassStatus{}, false
+	}
+	return s.clone(), true
+}
@@ -0,0 +1,173 @@
+package massorder
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/ordermasscancelreport"
+	"github.com/quickfixgo/quickfix"
+	"github.com/shopspring/decimal"
+)
+
+// venue returns a Manager whose sends are appended to sent
+func venue(sent *[]*quickfix.Message) *Manager {
+	m := New(quickfix.SessionID{SenderCompID: "FIRM", TargetCompID: "HNX"})
+	m.idPrefix = "m"
+	m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+		*sent = append(*sent, msg.ToMessage())
+		return nil
+	}
+	return m
+}
+
+// cancelReport returns the OrderMassCancelReport of a mass cancel affecting the orders,
+// given as OrigClOrdID and OrderID pairs
+func cancelReport(clOrdID string, response enum.MassCancelResponse, orders ...[2]string) *quickfix.Message {
+	r := ordermasscancelreport.New(field.NewOrderID("mc-"+clOrdID),
+		field.NewMassCancelRequestType(enum.MassCancelRequestType_CANCEL_ALL_ORDERS), field.NewMassCancelResponse(response))
+	r.SetClOrdID(clOrdID)
+	r.SetTotalAffectedOrders(len(orders))
+	g := ordermasscancelreport.NewNoAffectedOrdersRepeatingGroup()
+	for _, o := range orders {
+		row := g.Add()
+		row.SetOrigClOrdID(o[0])
+		row.SetAffectedOrderID(o[1])
+	}
+	r.SetNoAffectedOrders(g)
+	return r.ToMessage()
+}
+
+func execReport(execType enum.ExecType, clOrdID, orderID string) executionreport.ExecutionReport {
+	r := executionreport.New(field.NewOrderID(orderID), field.NewExecID("e-"+orderID), field.NewExecType(execType),
+		field.NewOrdStatus(enum.OrdStatus_CANCELED), field.NewSide(enum.Side_BUY), field.NewLeavesQty(decimal.Zero, 0),
+		field.NewCumQty(decimal.Zero, 0), field.NewAvgPx(decimal.Zero, 0))
+	r.SetOrigClOrdID(clOrdID)
+	return r
+}
+
+func TestMassCancel(t *testing.T) {
+	affected := [][2]string{{"c1", "o1"}, {"c2", "o2"}, {"c3", "o3"}}
+	tests := []struct {
+		name string
+		// before and after are the OrderIDs canceled before and after the OrderMassCancelReport
+		before, after []string
+		want          []bool
+	}{
+		{"canceled after the report", nil, []string{"o1", "o3"}, []bool{true, false, true}},
+		{"canceled before the report", []string{"o2", "o3"}, nil, []bool{false, true, true}},
+		{"both", []string{"o1"}, []string{"o2", "o3"}, []bool{true, true, true}},
+		{"not affected", []string{"o9"}, []string{"o8"}, []bool{false, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []*quickfix.Message
+			m := venue(&sent)
+			id, err := m.CancelAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			cancel := func(orderIDs []string) {
+				for _, orderID := range orderIDs {
+					if err := m.Process(execReport(enum.ExecType_CANCELED, "", orderID).ToMessage()); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			cancel(tt.before)
+			if err := m.Process(cancelReport(id, enum.MassCancelResponse_CANCEL_ALL_ORDERS, affected...)); err != nil {
+				t.Fatal(err)
+			}
+			cancel(tt.after)
+			c, _ := m.Cancel(id)
+			var got []bool
+			for _, a := range c.Affected {
+				got = append(got, a.Canceled)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if len(m.early) != 0 {
+				t.Errorf("got %d cancels kept once no mass cancel waits", len(m.early))
+			}
+		})
+	}
+}
+
+func TestFailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		response enum.MassCancelResponse
+		want     []string
+	}{
+		{"accepted", enum.MassCancelResponse_CANCEL_ALL_ORDERS, []string{"c3"}},
+		{"rejected", enum.MassCancelResponse_CANCEL_REQUEST_REJECTED, []string{"c1", "c2", "c3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []*quickfix.Message
+			m := venue(&sent)
+			id, _ := m.CancelAll()
+			if err := m.Process(cancelReport(id, tt.response, [2]string{"c1", "o1"}, [2]string{"c2", "o2"})); err != nil {
+				t.Fatal(err)
+			}
+			c, _ := m.Cancel(id)
+			if got := c.Failed([]string{"c1", "c2", "c3"}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	m := New(quickfix.SessionID{})
+	if err := m.Process(cancelReport("unknown", enum.MassCancelResponse_CANCEL_ALL_ORDERS)); err != ErrUnknownRequest {
+		t.Errorf("got %v, want %v", err, ErrUnknownRequest)
+	}
+}
+
+func TestMassStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int
+		last    int
+		reports int
+		want    bool
+	}{
+		{"TotNumReports", 2, 0, 2, true},
+		{"waiting", 3, 0, 2, false},
+		{"LastRptRequested", 0, 2, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []*quickfix.Message
+			m := venue(&sent)
+			var done []MassStatus
+			m.OnStatus = func(s MassStatus) { done = append(done, s) }
+			id, err := m.StatusAll("ACC1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 1; i <= tt.reports; i++ {
+				r := execReport(enum.ExecType_ORDER_STATUS, "", fmt.Sprint("o", i))
+				r.SetMassStatusReqID(id)
+				if tt.total > 0 {
+					r.SetTotNumReports(tt.total)
+				}
+				if i == tt.last {
+					r.SetLastRptRequested(true)
+				}
+				if err := m.Process(r.ToMessage()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			s, _ := m.Status(id)
+			if s.Done != tt.want || len(s.Orders) != tt.reports {
+				t.Errorf("got done %v with %d orders, want %v with %d", s.Done, len(s.Orders), tt.want, tt.reports)
+			}
+			if (len(done) == 1) != tt.want {
+				t.Errorf("got OnStatus called %d times", len(done))
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package massorder
+
+import (
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+// Scope selects the orders of a mass request, empty fields are left out
+type Scope struct {
+	Symbol           string
+	SecurityID       string
+	SecurityType     enum.SecurityType
+	Side             enum.Side
+	TradingSessionID enum.TradingSessionID
+	// Account is only sent in OrderMassStatusRequests, FIX 4.4 mass cancels have no Account
+	Account string
+}
+
+// AffectedOrder is an order canceled by a mass cancel
+type AffectedOrder struct {
+	OrigClOrdID string
+	OrderID     string
+	// Canceled is set once the ExecutionReport of the cancel is received
+	Canceled bool
+}
+
+// MassCancel is an OrderMassCancelRequest and its OrderMassCancelReport
+type MassCancel struct {
+	ClOrdID     string
+	RequestType enum.MassCancelRequestType
+	Scope       Scope
+	// Done is set by the OrderMassCancelReport
+	Done         bool
+	Response     enum.MassCancelResponse
+	RejectReason enum.MassCancelRejectReason
+	Text         string
+	// TotalAffected is the TotalAffectedOrders of the report, it may exceed len(Affected)
+	TotalAffected int
+	Affected      []AffectedOrder
+	UpdatedAt     time.Time
+}
+
+// Rejected returns true if the venue rejected the mass cancel
+func (c MassCancel) Rejected() bool {
+	return c.Done && c.Response == enum.MassCancelResponse_CANCEL_REQUEST_REJECTED
+}
+
+// Failed returns the ClOrdIDs of open orders that the mass cancel did not affect,
+// all of them if it was rejected
+func (c MassCancel) Failed(open []string) []string {
+	affected := make(map[string]bool, len(c.Affected))
+	for _, a := range c.Affected {
+		affected[a.OrigClOrdID] = true
+	}
+	var failed []string
+	for _, id := range open {
+		if c.Rejected() || !affected[id] {
+			failed = append(failed, id)
+		}
+	}
+	return failed
+}
+
+// cancel marks canceled the affected order with the OrderID or the OrigClOrdID,
+// it returns false if there is none
+func (c *MassCancel) cancel(orderID, origClOrdID string) bool {
+	for i := range c.Affected {
+		a := &c.Affected[i]
+		if a.Canceled || !(a.OrderID != "" && a.OrderID == orderID || a.OrigClOrdID != "" && a.OrigClOrdID == origClOrdID) {
+			continue
+		}
+		a.Canceled = true
+		c.UpdatedAt = time.Now()
+		return true
+	}
+	return false
+}
+
+func (c *MassCancel) clone() MassCancel {
+	r := *c
+	r.Affected = append([]AffectedOrder(nil), c.Affected...)
+	return r
+}
+
+// OrderStatus is an order state reported for a mass status request, by an ExecutionReport with ExecType I
+type OrderStatus struct {
+	ClOrdID   string
+	OrderID   string
+	Account   string
+	Symbol    string
+	Side      enum.Side
+	OrdStatus enum.OrdStatus
+	OrderQty  decimal.Decimal
+	CumQty    decimal.Decimal
+	LeavesQty decimal.Decimal
+	AvgPx     decimal.Decimal
+	Text      string
+}
+
+// MassStatus is an OrderMassStatusRequest and the status reports it received
+type MassStatus struct {
+	MassStatusReqID string
+	RequestType     enum.MassStatusReqType
+	Scope           Scope
+	// TotNumReports is the number of reports announced, if the venue sends it
+	TotNumReports int
+	Orders        []OrderStatus
+	// Done is set by the last report requested, or once TotNumReports reports are received
+	Done      bool
+	UpdatedAt time.Time
+}
+
+func (s *MassStatus) clone() MassStatus {
+	r := *s
+	r.Orders = append([]OrderStatus(nil), s.Orders...)
+	return r
+}
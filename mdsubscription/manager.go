@@ -0,0 +1,351 @@
+package mdsubscription
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/marketdataincrementalrefresh"
+	"github.com/quickfixgo/fix44/marketdatarequest"
+	"github.com/quickfixgo/fix44/marketdatarequestreject"
+	"github.com/quickfixgo/fix44/marketdatasnapshotfullrefresh"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+var (
+	// ErrUnknownMDReqID is returned for a market data message whose MDReqID is not managed
+	ErrUnknownMDReqID = errors.New("mdsubscription: unknown MDReqID")
+	// ErrDuplicateMDReqID is returned when subscribing with an MDReqID already in use
+	ErrDuplicateMDReqID = errors.New("mdsubscription: duplicate MDReqID")
+	// ErrNoSymbols is returned when subscribing without any symbol
+	ErrNoSymbols = errors.New("mdsubscription: subscription without symbols")
+)
+
+// Subscription describes a MarketDataRequest and the callbacks of its responses
+type Subscription struct {
+	// MDReqID is generated by the Manager if empty
+	MDReqID string
+	Symbols []string
+	// EntryTypes defaults to bid and offer
+	EntryTypes []enum.MDEntryType
+	// MarketDepth is 0 for the full book, 1 for the top of book
+	MarketDepth int
+	// Incremental asks for MarketDataIncrementalRefresh updates instead of full refreshes
+	Incremental bool
+	// SnapshotOnly sends a snapshot request, the subscription ends after a
+	// snapshot was received for each symbol
+	SnapshotOnly bool
+
+	OnSnapshot    func(marketdatasnapshotfullrefresh.MarketDataSnapshotFullRefresh)
+	OnIncremental func(marketdataincrementalrefresh.MarketDataIncrementalRefresh)
+	// OnReject is called when the counterparty rejects the request,
+	// the subscription is then removed from the Manager
+	OnReject func(*RejectError)
+}
+
+// RejectError is a MarketDataRequestReject received for a subscription
+type RejectError struct {
+	MDReqID string
+	Reason  enum.MDReqRejReason
+	Text    string
+}
+
+// rejReasons describes the MDReqRejReason values of FIX 4.4
+var rejReasons = map[enum.MDReqRejReason]string{
+	enum.MDReqRejReason_UNKNOWN_SYMBOL:                      "unknown symbol",
+	enum.MDReqRejReason_DUPLICATE_MDREQID:                   "duplicate MDReqID",
+	enum.MDReqRejReason_INSUFFICIENT_BANDWIDTH:              "insufficient bandwidth",
+	enum.MDReqRejReason_INSUFFICIENT_PERMISSIONS:            "insufficient permissions",
+	enum.MDReqRejReason_UNSUPPORTED_SUBSCRIPTIONREQUESTTYPE: "unsupported SubscriptionRequestType",
+	enum.MDReqRejReason_UNSUPPORTED_MARKETDEPTH:             "unsupported MarketDepth",
+	enum.MDReqRejReason_UNSUPPORTED_MDUPDATETYPE:            "unsupported MDUpdateType",
+	enum.MDReqRejReason_UNSUPPORTED_AGGREGATEDBOOK:          "unsupported AggregatedBook",
+	enum.MDReqRejReason_UNSUPPORTED_MDENTRYTYPE:             "unsupported MDEntryType",
+	enum.MDReqRejReason_UNSUPPORTED_TRADINGSESSIONID:        "unsupported TradingSessionID",
+	enum.MDReqRejReason_UNSUPPORTED_SCOPE:                   "unsupported Scope",
+	enum.MDReqRejReason_UNSUPPORTED_OPENCLOSESETTLEFLAG:     "unsupported OpenCloseSettlFlag",
+	enum.MDReqRejReason_UNSUPPORTED_MDIMPLICITDELETE:        "unsupported MDImplicitDelete",
+}
+
+func (e *RejectError) Error() string {
+	s := fmt.Sprintf("mdsubscription: request %s rejected", e.MDReqID)
+	if desc, ok := rejReasons[e.Reason]; ok {
+		s += ": " + desc
+	} else if e.Reason != "" {
+		s += ": MDReqRejReason " + string(e.Reason)
+	}
+	if e.Text != "" {
+		s += ": " + e.Text
+	}
+	return s
+}
+
+type subscription struct {
+	Subscription
+	// waiting are the symbols of a snapshot only request without snapshot yet
+	waiting map[string]bool
+}
+
+// Manager sends the MarketDataRequest of its subscriptions on a session, sends them
+// again after each logon and routes the responses to the subscription callbacks.
+// A Manager is safe for concurrent use.
+type Manager struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// IDPrefix prefixes the generated MDReqIDs, New sets it from the current time
+	// so that MDReqIDs are not reused across restarts
+	IDPrefix string
+
+	mu       sync.Mutex
+	loggedOn bool
+	nextID   int
+	subs     map[string]*subscription
+}
+
+// New returns a Manager for the session, to be told about logons and logouts
+// with OnLogon and OnLogout
+func New(sessionID quickfix.SessionID) *Manager {
+	return &Manager{
+		SessionID: sessionID,
+		Send:      quickfix.SendToTarget,
+		IDPrefix:  time.Now().Format("150405") + "-",
+		subs:      make(map[string]*subscription),
+	}
+}
+
+// Subscribe registers s and sends its request if the session is logged on.
+// It returns the MDReqID of the subscription.
+func (m *Manager) Subscribe(s Subscription) (string, error) {
+	if len(s.Symbols) == 0 {
+		return "", ErrNoSymbols
+	}
+	if len(s.EntryTypes) == 0 {
+		s.EntryTypes = []enum.MDEntryType{enum.MDEntryType_BID, enum.MDEntryType_OFFER}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s.MDReqID == "" {
+		m.nextID++
+		s.MDReqID = fmt.Sprintf("%s%d", m.IDPrefix, m.nextID)
+	}
+	if _, ok := m.subs[s.MDReqID]; ok {
+		return "", ErrDuplicateMDReqID
+	}
+	sub := &subscription{Subscription: s}
+	if s.SnapshotOnly {
+		sub.waiting = make(map[string]bool, len(s.Symbols))
+		for _, symbol := range s.Symbols {
+			sub.waiting[symbol] = true
+		}
+	}
+	m.subs[s.MDReqID] = sub
+	if !m.loggedOn {
+		return s.MDReqID, nil
+	}
+	if err := m.send(sub, requestType(s)); err != nil {
+		delete(m.subs, s.MDReqID)
+		return "", err
+	}
+	return s.MDReqID, nil
+}
+
+// Unsubscribe removes the subscription and, if the session is logged on,
+// asks the counterparty to stop its updates
+func (m *Manager) Unsubscribe(mdReqID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.subs[mdReqID]
+	if !ok {
+		return ErrUnknownMDReqID
+	}
+	delete(m.subs, mdReqID)
+	if !m.loggedOn || sub.SnapshotOnly {
+		return nil
+	}
+	return m.send(sub, enum.SubscriptionRequestType_DISABLE_PREVIOUS_SNAPSHOT_PLUS_UPDATE_REQUEST)
+}
+
+// Resubscribe sends the request of a subscription again,
+// e.g. to get a new snapshot after a gap in the incremental refreshes
+func (m *Manager) Resubscribe(mdReqID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.subs[mdReqID]
+	if !ok {
+		return ErrUnknownMDReqID
+	}
+	if !m.loggedOn {
+		return nil
+	}
+	return m.send(sub, requestType(sub.Subscription))
+}
+
+// Subscriptions returns the active subscriptions, sorted by MDReqID
+func (m *Manager) Subscriptions() []Subscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subs := make([]Subscription, 0, len(m.subs))
+	for _, sub := range m.subs {
+		subs = append(subs, sub.Subscription)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].MDReqID < subs[j].MDReqID })
+	return subs
+}
+
+// OnLogon sends the requests of all the subscriptions,
+// to be called from the OnLogon of the quickfix.Application
+func (m *Manager) OnLogon(sessionID quickfix.SessionID) error {
+	if sessionID != m.SessionID {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loggedOn = true
+	ids := make([]string, 0, len(m.subs))
+	for id := range m.subs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var errs []error
+	for _, id := range ids {
+		sub := m.subs[id]
+		if err := m.send(sub, requestType(sub.Subscription)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// OnLogout keeps the subscriptions to be sent again on the next logon,
+// to be called from the OnLogout of the quickfix.Application
+func (m *Manager) OnLogout(sessionID quickfix.SessionID) {
+	if sessionID != m.SessionID {
+		return
+	}
+	m.mu.Lock()
+	m.loggedOn = false
+	m.mu.Unlock()
+}
+
+func requestType(s Subscription) enum.SubscriptionRequestType {
+	if s.SnapshotOnly {
+		return enum.SubscriptionRequestType_SNAPSHOT
+	}
+	return enum.SubscriptionRequestType_SNAPSHOT_PLUS_UPDATES
+}
+
+// send must be called with mu held
+func (m *Manager) send(sub *subscription, t enum.SubscriptionRequestType) error {
+	req := marketdatarequest.New(field.NewMDReqID(sub.MDReqID), field.NewSubscriptionRequestType(t), field.NewMarketDepth(sub.MarketDepth))
+	if t == enum.SubscriptionRequestType_SNAPSHOT_PLUS_UPDATES {
+		if sub.Incremental {
+			req.SetMDUpdateType(enum.MDUpdateType_INCREMENTAL_REFRESH)
+		} else {
+			req.SetMDUpdateType(enum.MDUpdateType_FULL_REFRESH)
+		}
+	}
+	entryTypes := marketdatarequest.NewNoMDEntryTypesRepeatingGroup()
+	for _, t := range sub.EntryTypes {
+		entryTypes.Add().SetMDEntryType(t)
+	}
+	req.SetNoMDEntryTypes(entryTypes)
+	symbols := marketdatarequest.NewNoRelatedSymRepeatingGroup()
+	for _, s := range sub.Symbols {
+		symbols.Add().SetSymbol(s)
+	}
+	req.SetNoRelatedSym(symbols)
+	return m.Send(req, m.SessionID)
+}
+
+// Process routes a snapshot, an incremental refresh or a reject to its subscription,
+// other messages are ignored. It is meant to be called from FromApp.
+func (m *Manager) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "W":
+		return m.OnSnapshot(marketdatasnapshotfullrefresh.FromMessage(msg))
+	case "X":
+		return m.OnIncremental(marketdataincrementalrefresh.FromMessage(msg))
+	case "Y":
+		return m.OnMarketDataRequestReject(marketdatarequestreject.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnSnapshot calls the OnSnapshot of the subscription with the MDReqID of s
+func (m *Manager) OnSnapshot(s marketdatasnapshotfullrefresh.MarketDataSnapshotFullRefresh) error {
+	mdReqID, err := s.GetMDReqID()
+	if err != nil {
+		return err
+	}
+	symbol, _ := s.GetSymbol()
+
+	m.mu.Lock()
+	sub, ok := m.subs[mdReqID]
+	if ok && sub.SnapshotOnly {
+		delete(sub.waiting, symbol)
+		if len(sub.waiting) == 0 {
+			delete(m.subs, mdReqID)
+		}
+	}
+	m.mu.Unlock()
+	if !ok {
+		return ErrUnknownMDReqID
+	}
+	if sub.OnSnapshot != nil {
+		sub.OnSnapshot(s)
+	}
+	return nil
+}
+
+// OnIncremental calls the OnIncremental of the subscription with the MDReqID of i
+func (m *Manager) OnIncremental(i marketdataincrementalrefresh.MarketDataIncrementalRefresh) error {
+	mdReqID, err := i.GetMDReqID()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	sub, ok := m.subs[mdReqID]
+	m.mu.Unlock()
+	if !ok {
+		return ErrUnknownMDReqID
+	}
+	if sub.OnIncremental != nil {
+		sub.OnIncremental(i)
+	}
+	return nil
+}
+
+// OnMarketDataRequestReject removes the rejected subscription and calls its OnReject
+func (m *Manager) OnMarketDataRequestReject(r marketdatarequestreject.MarketDataRequestReject) error {
+	mdReqID, err := r.GetMDReqID()
+	if err != nil {
+		return err
+	}
+	rejErr := &RejectError{MDReqID: mdReqID}
+	rejErr.Reason, _ = r.GetMDReqRejReason()
+	rejErr.Text, _ = r.GetText()
+
+	m.mu.Lock()
+	sub, ok := m.subs[mdReqID]
+	delete(m.subs, mdReqID)
+	m.mu.Unlock()
+	if !ok {
+		return ErrUnknownMDReqID
+	}
+	if sub.OnReject != nil {
+		sub.OnReject(rejErr)
+	}
+	return nil
+}
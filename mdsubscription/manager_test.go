@@ -0,0 +1,161 @@
+package mdsubscription
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/marketdatarequest"
+	"github.com/quickfixgo/fix44/marketdatarequestreject"
+	"github.com/quickfixgo/fix44/marketdatasnapshotfullrefresh"
+	"github.com/quickfixgo/quickfix"
+)
+
+var session = quickfix.SessionID{SenderCompID: "FIRM", TargetCompID: "HNX"}
+
+// requests returns a Manager noting the MDReqID and SubscriptionRequestType of each
+// MarketDataRequest it sends, e.g. "md1 1"
+func requests(sent *[]string) *Manager {
+	m := New(session)
+	m.IDPrefix = "md"
+	m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+		r := marketdatarequest.FromMessage(msg.ToMessage())
+		id, _ := r.GetMDReqID()
+		t, _ := r.GetSubscriptionRequestType()
+		*sent = append(*sent, id+" "+string(t))
+		return nil
+	}
+	return m
+}
+
+func snapshot(mdReqID, symbol string) *quickfix.Message {
+	s := marketdatasnapshotfullrefresh.New()
+	s.SetMDReqID(mdReqID)
+	s.SetSymbol(symbol)
+	return s.ToMessage()
+}
+
+func TestSubscribe(t *testing.T) {
+	tests := []struct {
+		name     string
+		loggedOn bool
+		subs     []Subscription
+		want     []string
+		wantErr  error
+	}{
+		{"logged on", true, []Subscription{{Symbols: []string{"VND"}}}, []string{"md1 1"}, nil},
+		{"snapshot", true, []Subscription{{Symbols: []string{"VND"}, SnapshotOnly: true}}, []string{"md1 0"}, nil},
+		{"logged out", false, []Subscription{{Symbols: []string{"VND"}}}, nil, nil},
+		{"no symbols", true, []Subscription{{}}, nil, ErrNoSymbols},
+		{"duplicate MDReqID", true, []Subscription{{MDReqID: "x", Symbols: []string{"VND"}}, {MDReqID: "x", Symbols: []string{"SHB"}}},
+			[]string{"x 1"}, ErrDuplicateMDReqID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			m := requests(&sent)
+			if tt.loggedOn {
+				m.OnLogon(session)
+			}
+			var err error
+			for _, s := range tt.subs {
+				_, err = m.Subscribe(s)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(sent, tt.want) {
+				t.Errorf("got %v sent, want %v", sent, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogonResubscribes(t *testing.T) {
+	var sent []string
+	m := requests(&sent)
+	m.Subscribe(Subscription{Symbols: []string{"VND"}})
+	m.Subscribe(Subscription{Symbols: []string{"SHB"}, SnapshotOnly: true})
+	if err := m.OnLogon(session); err != nil {
+		t.Fatal(err)
+	}
+	m.OnLogout(session)
+	m.OnLogon(quickfix.SessionID{TargetCompID: "OTHER"})
+	if err := m.OnLogon(session); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"md1 1", "md2 0", "md1 1", "md2 0"}
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("got %v sent, want %v", sent, want)
+	}
+	if err := m.Unsubscribe("md1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Unsubscribe("md1"); !errors.Is(err, ErrUnknownMDReqID) {
+		t.Errorf("got %v, want %v", err, ErrUnknownMDReqID)
+	}
+	if got := sent[len(sent)-1]; got != "md1 2" {
+		t.Errorf("got %s, want the updates disabled", got)
+	}
+}
+
+func TestSnapshotOnly(t *testing.T) {
+	var sent []string
+	m := requests(&sent)
+	var symbols []string
+	id, _ := m.Subscribe(Subscription{Symbols: []string{"VND", "SHB"}, SnapshotOnly: true,
+		OnSnapshot: func(s marketdatasnapshotfullrefresh.MarketDataSnapshotFullRefresh) {
+			symbol, _ := s.GetSymbol()
+			symbols = append(symbols, symbol)
+		}})
+	for _, symbol := range []string{"VND", "VND", "SHB"} {
+		if err := m.Process(snapshot(id, symbol)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.Process(snapshot(id, "VND")); !errors.Is(err, ErrUnknownMDReqID) {
+		t.Errorf("got %v after the last snapshot, want %v", err, ErrUnknownMDReqID)
+	}
+	if !reflect.DeepEqual(symbols, []string{"VND", "VND", "SHB"}) || len(m.Subscriptions()) != 0 {
+		t.Errorf("got snapshots of %v and subscriptions %v", symbols, m.Subscriptions())
+	}
+}
+
+func TestReject(t *testing.T) {
+	tests := []struct {
+		reason enum.MDReqRejReason
+		text   string
+		want   string
+	}{
+		{enum.MDReqRejReason_UNKNOWN_SYMBOL, "", "mdsubscription: request md1 rejected: unknown symbol"},
+		{enum.MDReqRejReason_UNSUPPORTED_MARKETDEPTH, "depth 5", "mdsubscription: request md1 rejected: unsupported MarketDepth: depth 5"},
+		{"Z", "", "mdsubscription: request md1 rejected: MDReqRejReason Z"},
+		{"", "busy", "mdsubscription: request md1 rejected: busy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			var sent []string
+			m := requests(&sent)
+			var rejected *RejectError
+			m.Subscribe(Subscription{Symbols: []string{"VND"}, OnReject: func(e *RejectError) { rejected = e }})
+			r := marketdatarequestreject.New(field.NewMDReqID("md1"))
+			if tt.reason != "" {
+				r.SetMDReqRejReason(tt.reason)
+			}
+			if tt.text != "" {
+				r.SetText(tt.text)
+			}
+			if err := m.Process(r.ToMessage()); err != nil {
+				t.Fatal(err)
+			}
+			if rejected == nil || rejected.Error() != tt.want {
+				t.Errorf("got %v, want %s", rejected, tt.want)
+			}
+			if len(m.Subscriptions()) != 0 {
+				t.Error("the rejected subscription was kept")
+			}
+		})
+	}
+}
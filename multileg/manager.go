@@ -0,0 +1,469 @@
+package multileg
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/multilegordercancelreplace"
+	"github.com/quickfixgo/fix44/newordermultileg"
+	"github.com/quickfixgo/fix44/ordercancelreject"
+	"github.com/quickfixgo/fix44/ordercancelrequest"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrUnknownOrder is returned for a ClOrdID not sent by the Manager
+	ErrUnknownOrder = errors.New("multileg: unknown order")
+	// ErrDuplicateClOrdID is returned for a spread reusing a ClOrdID already sent
+	ErrDuplicateClOrdID = errors.New("multileg: duplicate ClOrdID")
+	// ErrOrderClosed is returned when replacing or canceling an order that is done
+	ErrOrderClosed = errors.New("multileg: order is not open")
+)
+
+// LegFill is the execution state of a leg of an order
+type LegFill struct {
+	Leg
+	CumQty decimal.Decimal
+	AvgPx  decimal.Decimal
+}
+
+// Order is the state of a spread order
+type Order struct {
+	Spread
+	// ClOrdIDs is the chain of accepted ClOrdIDs, Spread.ClOrdID being the last one
+	ClOrdIDs []string
+	OrderID  string
+	// Pending is the ClOrdID of a replace or cancel waiting for its ExecutionReport
+	Pending string
+
+	OrdStatus enum.OrdStatus
+	CumQty    decimal.Decimal
+	LeavesQty decimal.Decimal
+	AvgPx     decimal.Decimal
+	// LegFills are in the order of the Legs
+	LegFills  []LegFill
+	Text      string
+	UpdatedAt time.Time
+}
+
+// IsOpen returns true if the order can still be filled
+func (o Order) IsOpen() bool {
+	switch o.OrdStatus {
+	case enum.OrdStatus_FILLED, enum.OrdStatus_CANCELED, enum.OrdStatus_REJECTED, enum.OrdStatus_EXPIRED:
+		return false
+	}
+	return true
+}
+
+// clone returns a copy of o that does not share slices with it
+func (o *Order) clone() Order {
+	c := *o
+	c.Legs = append([]Leg(nil), o.Legs...)
+	c.ClOrdIDs = append([]string(nil), o.ClOrdIDs...)
+	c.LegFills = append([]LegFill(nil), o.LegFills...)
+	return c
+}
+
+// fill adds an execution of qty at px to the leg
+func (f *LegFill) fill(qty, px decimal.Decimal) {
+	cumQty := f.CumQty.Add(qty)
+	if cumQty.IsPositive() {
+		f.AvgPx = f.AvgPx.Mul(f.CumQty).Add(px.Mul(qty)).Div(cumQty)
+	}
+	f.CumQty = cumQty
+}
+
+// Manager sends spreads as NewOrderMultileg and tracks them with their leg fills
+// from ExecutionReports. A Manager is safe for concurrent use.
+type Manager struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// ReportType is the MultiLegRptTypeReq of the orders, New sets it to report by
+	// multileg security only. The leg fills then come from the NoLegs of the multileg
+	// reports, otherwise from the individual leg reports.
+	ReportType enum.MultiLegRptTypeReq
+	// OnChange, if set, is called with a copy of an order each time it changes.
+	// It is called once the Manager is unlocked, so it can call the Manager.
+	OnChange func(Order)
+
+	mu       sync.Mutex
+	nextID   int
+	idPrefix string
+	orders   []*Order
+	// byClOrdID maps all the ClOrdIDs of the orders, including the pending ones
+	byClOrdID map[string]*Order
+	execIDs   map[string]bool
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns a Manager for the session
+func New(sessionID quickfix.SessionID) *Manager {
+	return &Manager{
+		SessionID:  sessionID,
+		Send:       quickfix.SendToTarget,
+		ReportType: enum.MultiLegRptTypeReq_REPORT_BY_MULITLEG_SECURITY_ONLY,
+		idPrefix:   time.Now().Format("150405") + "-",
+		byClOrdID:  make(map[string]*Order),
+		execIDs:    make(map[string]bool),
+	}
+}
+
+// newID must be called with mu held
+func (m *Manager) newID() string {
+	m.nextID++
+	return fmt.Sprintf("%s%d", m.idPrefix, m.nextID)
+}
+
+// changed queues the call of OnChange with a copy of o, it must be called with mu held
+func (m *Manager) changed(o *Order) {
+	if m.OnChange != nil {
+		c := o.clone()
+		m.callbacks = append(m.callbacks, func() { m.OnChange(c) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (m *Manager) unlock() {
+	callbacks := m.callbacks
+	m.callbacks = nil
+	m.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// Submit validates the spread and sends it as a NewOrderMultileg, it returns the ClOrdID
+func (m *Manager) Submit(s Spread) (string, error) {
+	s.Legs = append([]Leg(nil), s.Legs...)
+	if err := s.Validate(); err != nil {
+		return "", err
+	}
+	if s.Price.IsZero() {
+		if p, err := SpreadPrice(s.Legs); err == nil {
+			s.Price = p
+		}
+	}
+
+	m.mu.Lock()
+	defer m.unlock()
+	if s.ClOrdID == "" {
+		s.ClOrdID = m.newID()
+	}
+	if _, ok := m.byClOrdID[s.ClOrdID]; ok {
+		return "", ErrDuplicateClOrdID
+	}
+	now := time.Now()
+	msg := newordermultileg.New(field.NewClOrdID(s.ClOrdID), field.NewSide(s.Side), field.NewTransactTime(now), field.NewOrdType(s.OrdType))
+	if s.Account != "" {
+		msg.SetAccount(s.Account)
+	}
+	if s.Symbol != "" {
+		msg.SetSymbol(s.Symbol)
+	}
+	msg.SetOrderQty(s.OrderQty, scale(s.OrderQty))
+	if !s.Price.IsZero() {
+		msg.SetPrice(s.Price, scale(s.Price))
+	}
+	msg.SetMultiLegRptTypeReq(m.ReportType)
+	legs := newordermultileg.NewNoLegsRepeatingGroup()
+	for _, l := range s.Legs {
+		row := legs.Add()
+		row.SetLegSymbol(l.Symbol)
+		row.SetLegSide(string(l.Side))
+		row.SetLegRatioQty(l.RatioQty, 0)
+		row.SetLegRefID(l.RefID)
+		if !l.Price.IsZero() {
+			row.SetLegPrice(l.Price, scale(l.Price))
+		}
+	}
+	msg.SetNoLegs(legs)
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+
+	o := &Order{Spread: s, ClOrdIDs: []string{s.ClOrdID}, OrdStatus: enum.OrdStatus_PENDING_NEW,
+		LeavesQty: s.OrderQty, UpdatedAt: now}
+	for _, l := range s.Legs {
+		o.LegFills = append(o.LegFills, LegFill{Leg: l})
+	}
+	m.orders = append(m.orders, o)
+	m.byClOrdID[s.ClOrdID] = o
+	m.changed(o)
+	return s.ClOrdID, nil
+}
+
+// open must be called with mu held
+func (m *Manager) open(clOrdID string) (*Order, error) {
+	o, ok := m.byClOrdID[clOrdID]
+	if !ok {
+		return nil, ErrUnknownOrder
+	}
+	if !o.IsOpen() {
+		return nil, ErrOrderClosed
+	}
+	return o, nil
+}
+
+// Replace sends a MultilegOrderCancelReplace changing the quantity and net price of an order,
+// with the same legs. It returns the new ClOrdID.
+func (m *Manager) Replace(clOrdID string, orderQty, price decimal.Decimal) (string, error) {
+	m.mu.Lock()
+	defer m.unlock()
+	o, err := m.open(clOrdID)
+	if err != nil {
+		return "", err
+	}
+	newID := m.newID()
+	msg := multilegordercancelreplace.New(field.NewOrigClOrdID(o.ClOrdID), field.NewClOrdID(newID),
+		field.NewSide(o.Side), field.NewTransactTime(time.Now()), field.NewOrdType(o.OrdType))
+	if o.OrderID != "" {
+		msg.SetOrderID(o.OrderID)
+	}
+	if o.Account != "" {
+		msg.SetAccount(o.Account)
+	}
+	if o.Symbol != "" {
+		msg.SetSymbol(o.Symbol)
+	}
+	msg.SetOrderQty(orderQty, scale(orderQty))
+	if !price.IsZero() {
+		msg.SetPrice(price, scale(price))
+	}
+	msg.SetMultiLegRptTypeReq(m.ReportType)
+	legs := multilegordercancelreplace.NewNoLegsRepeatingGroup()
+	for _, l := range o.Legs {
+		row := legs.Add()
+		row.SetLegSymbol(l.Symbol)
+		row.SetLegSide(string(l.Side))
+		row.SetLegRatioQty(l.RatioQty, 0)
+		row.SetLegRefID(l.RefID)
+	}
+	msg.SetNoLegs(legs)
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+	o.Pending = newID
+	m.byClOrdID[newID] = o
+	m.changed(o)
+	return newID, nil
+}
+
+// Cancel sends an OrderCancelRequest for a spread order, it returns the ClOrdID of the request
+func (m *Manager) Cancel(clOrdID string) (string, error) {
+	m.mu.Lock()
+	defer m.unlock()
+	o, err := m.open(clOrdID)
+	if err != nil {
+		return "", err
+	}
+	newID := m.newID()
+	msg := ordercancelrequest.New(field.NewOrigClOrdID(o.ClOrdID), field.NewClOrdID(newID),
+		field.NewSide(o.Side), field.NewTransactTime(time.Now()))
+	if o.OrderID != "" {
+		msg.SetOrderID(o.OrderID)
+	}
+	if o.Symbol != "" {
+		msg.SetSymbol(o.Symbol)
+	}
+	msg.SetOrderQty(o.OrderQty, scale(o.OrderQty))
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+	o.Pending = newID
+	m.byClOrdID[newID] = o
+	m.changed(o)
+	return newID, nil
+}
+
+// Process handles the ExecutionReports and OrderCancelRejects of the spread orders,
+// other messages are ignored. It is meant to be called from FromApp.
+func (m *Manager) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "8":
+		return m.OnExecutionReport(executionreport.FromMessage(msg))
+	case "9":
+		return m.OnOrderCancelReject(ordercancelreject.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnExecutionReport applies a multileg or individual leg ExecutionReport to its order.
+// Reports of orders not sent by the Manager and duplicate ExecIDs are ignored.
+func (m *Manager) OnExecutionReport(r executionreport.ExecutionReport) error {
+	execID, err := r.GetExecID()
+	if err != nil {
+		return err
+	}
+	execType, _ := r.GetExecType()
+	clOrdID, _ := r.GetClOrdID()
+	origClOrdID, _ := r.GetOrigClOrdID()
+	rptType, _ := r.GetMultiLegReportingType()
+	lastQty, _ := r.GetLastQty()
+	lastPx, _ := r.GetLastPx()
+
+	m.mu.Lock()
+	defer m.unlock()
+	o, ok := m.byClOrdID[clOrdID]
+	if !ok {
+		o, ok = m.byClOrdID[origClOrdID]
+	}
+	if !ok || m.execIDs[execID] {
+		return nil
+	}
+	m.execIDs[execID] = true
+	o.UpdatedAt = time.Now()
+
+	if rptType == enum.MultiLegReportingType_INDIVIDUAL_LEG_OF_A_MULTI_LEG_SECURITY {
+		if execType == enum.ExecType_TRADE && m.ReportType != enum.MultiLegRptTypeReq_REPORT_BY_MULITLEG_SECURITY_ONLY {
+			symbol, _ := r.GetSymbol()
+			for i := range o.LegFills {
+				if o.LegFills[i].Symbol == symbol {
+					o.LegFills[i].fill(lastQty, lastPx)
+				}
+			}
+		}
+		m.changed(o)
+		return nil
+	}
+
+	switch execType {
+	case enum.ExecType_TRADE:
+		if m.ReportType == enum.MultiLegRptTypeReq_REPORT_BY_MULITLEG_SECURITY_ONLY {
+			m.fillLegs(o, r, lastQty)
+		}
+	case enum.ExecType_REPLACED:
+		if o.Pending == clOrdID {
+			if v, err := r.GetOrderQty(); err == nil {
+				o.OrderQty = v
+			}
+			if v, err := r.GetPrice(); err == nil {
+				o.Price = v
+			}
+		}
+		m.advance(o, clOrdID)
+	case enum.ExecType_CANCELED:
+		m.advance(o, clOrdID)
+	}
+	if orderID, err := r.GetOrderID(); err == nil {
+		o.OrderID = orderID
+	}
+	if v, err := r.GetOrdStatus(); err == nil {
+		o.OrdStatus = v
+	}
+	o.CumQty, _ = r.GetCumQty()
+	o.LeavesQty, _ = r.GetLeavesQty()
+	o.AvgPx, _ = r.GetAvgPx()
+	o.Text, _ = r.GetText()
+	m.changed(o)
+	return nil
+}
+
+// OnOrderCancelReject clears the pending replace or cancel rejected by r,
+// rejects of requests not sent by the Manager are ignored
+func (m *Manager) OnOrderCancelReject(r ordercancelreject.OrderCancelReject) error {
+	clOrdID, err := r.GetClOrdID()
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.unlock()
+	o, ok := m.byClOrdID[clOrdID]
+	if !ok || o.Pending != clOrdID {
+		return nil
+	}
+	o.Pending = ""
+	delete(m.byClOrdID, clOrdID)
+	o.Text, _ = r.GetText()
+	o.UpdatedAt = time.Now()
+	m.changed(o)
+	return nil
+}
+
+// fillLegs applies a multileg fill of lastQty spreads to the legs, at the LegLastPx
+// of the NoLegs matched by LegRefID or LegSymbol. The legs without LegLastPx are
+// left unfilled rather than filled at a zero price.
+func (m *Manager) fillLegs(o *Order, r executionreport.ExecutionReport, lastQty decimal.Decimal) {
+	legPx := make(map[string]decimal.Decimal)
+	if g, err := r.GetNoLegs(); err == nil {
+		for _, row := range g.All() {
+			px, err := row.GetLegLastPx()
+			if err != nil {
+				continue
+			}
+			if refID, err := row.GetLegRefID(); err == nil {
+				legPx[refID] = px
+			}
+			if symbol, err := row.GetLegSymbol(); err == nil {
+				legPx[symbol] = px
+			}
+		}
+	}
+	for i := range o.LegFills {
+		f := &o.LegFills[i]
+		px, ok := legPx[f.RefID]
+		if !ok {
+			if px, ok = legPx[f.Symbol]; !ok {
+				continue
+			}
+		}
+		f.fill(lastQty.Mul(f.RatioQty), px)
+	}
+}
+
+// advance must be called with mu held, it moves the order to the ClOrdID of an accepted request
+func (m *Manager) advance(o *Order, clOrdID string) {
+	if o.Pending == clOrdID {
+		o.Pending = ""
+	}
+	if clOrdID == "" || clOrdID == o.ClOrdID {
+		return
+	}
+	o.ClOrdID = clOrdID
+	o.ClOrdIDs = append(o.ClOrdIDs, clOrdID)
+}
+
+// Order returns a copy of the order with any of its ClOrdIDs
+func (m *Manager) Order(clOrdID string) (Order, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.byClOrdID[clOrdID]
+	if !ok {
+		return Order{}, false
+	}
+	return o.clone(), true
+}
+
+// Orders returns copies of all the orders, in the order they were submitted
+func (m *Manager) Orders() []Order {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	os := make([]Order, 0, len(m.orders))
+	for _, o := range m.orders {
+		os = append(os, o.clone())
+	}
+	return os
+}
+
+// OpenOrders returns copies of the orders that can still be filled, in the order they were submitted
+func (m *Manager) OpenOrders() []Order {
+	var os []Order
+	for _, o := range m.Orders() {
+		if o.IsOpen() {
+			os = append(os, o)
+		}
+	}
+	return os
+}
@@ -0,0 +1,114 @@
+package multileg
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+// exchange returns a Manager whose sends are appended to sent
+func exchange(sent *[]*quickfix.Message) *Manager {
+	m := New(quickfix.SessionID{SenderCompID: "FIRM", TargetCompID: "HNX"})
+	m.idPrefix = "s"
+	m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+		*sent = append(*sent, msg.ToMessage())
+		return nil
+	}
+	return m
+}
+
+func calendar(qty string) Spread {
+	return Spread{Side: enum.Side_BUY, OrdType: enum.OrdType_LIMIT, OrderQty: decimal.RequireFromString(qty),
+		Legs: []Leg{leg("F1", enum.Side_BUY, 1, "1250.5"), leg("F2", enum.Side_SELL, 1, "1248.25")}}
+}
+
+// trade returns a multileg fill of lastQty spreads with the LegLastPx of the legs having one
+func trade(execID string, clOrdID string, lastQty string, legPx map[string]string) *quickfix.Message {
+	r := executionreport.New(field.NewOrderID("o1"), field.NewExecID(execID), field.NewExecType(enum.ExecType_TRADE),
+		field.NewOrdStatus(enum.OrdStatus_PARTIALLY_FILLED), field.NewSide(enum.Side_BUY),
+		field.NewLeavesQty(decimal.Zero, 0), field.NewCumQty(decimal.RequireFromString(lastQty), 0), field.NewAvgPx(decimal.Zero, 0))
+	r.SetClOrdID(clOrdID)
+	r.SetLastQty(decimal.RequireFromString(lastQty), 0)
+	g := executionreport.NewNoLegsRepeatingGroup()
+	for _, symbol := range []string{"F1", "F2"} {
+		row := g.Add()
+		row.SetLegSymbol(symbol)
+		if px, ok := legPx[symbol]; ok {
+			row.SetLegLastPx(decimal.RequireFromString(px), 2)
+		}
+	}
+	r.SetNoLegs(g)
+	return r.ToMessage()
+}
+
+func TestOrderQtyScale(t *testing.T) {
+	var sent []*quickfix.Message
+	m := exchange(&sent)
+	id, err := m.Submit(calendar("2.5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newID, err := m.Replace(id, decimal.RequireFromString("3.75"), decimal.RequireFromString("2.125"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Cancel(id); err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ qty, px string }{{"2.5", "2.25"}, {"3.75", "2.125"}, {"2.5", ""}}
+	for i, w := range want {
+		qty, _ := sent[i].Body.GetString(tag.OrderQty)
+		px, _ := sent[i].Body.GetString(tag.Price)
+		if qty != w.qty || px != w.px {
+			t.Errorf("message %d: got %s@%s, want %s@%s", i, qty, px, w.qty, w.px)
+		}
+	}
+	if o, _ := m.Order(newID); o.Pending == "" {
+		t.Error("got no pending request")
+	}
+}
+
+func TestFillLegs(t *testing.T) {
+	tests := []struct {
+		name   string
+		legPx  map[string]string
+		wantF1 string
+		wantF2 string
+	}{
+		{"both legs", map[string]string{"F1": "1250.5", "F2": "1248.25"}, "4", "4"},
+		{"missing LegLastPx", map[string]string{"F1": "1250.5"}, "4", "0"},
+		{"no LegLastPx", nil, "0", "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []*quickfix.Message
+			m := exchange(&sent)
+			id, err := m.Submit(calendar("10"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := m.Process(trade("e1", id, "4", tt.legPx)); err != nil {
+				t.Fatal(err)
+			}
+			// the duplicate ExecID is ignored
+			if err := m.Process(trade("e1", id, "4", tt.legPx)); err != nil {
+				t.Fatal(err)
+			}
+			o, _ := m.Order(id)
+			for i, want := range []string{tt.wantF1, tt.wantF2} {
+				f := o.LegFills[i]
+				if !f.CumQty.Equal(decimal.RequireFromString(want)) {
+					t.Errorf("leg %s: got CumQty %v, want %s", f.Symbol, f.CumQty, want)
+				}
+				if px, ok := tt.legPx[f.Symbol]; ok && !f.AvgPx.Equal(decimal.RequireFromString(px)) {
+					t.Errorf("leg %s: got AvgPx %v, want %s", f.Symbol, f.AvgPx, px)
+				}
+			}
+		})
+	}
+}
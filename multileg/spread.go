@@ -0,0 +1,126 @@
+package multileg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrTooFewLegs is returned for a spread with less than two legs
+	ErrTooFewLegs = errors.New("multileg: a spread needs at least two legs")
+	// ErrMissingLegPrice is returned when computing the price of a spread with a leg without price
+	ErrMissingLegPrice = errors.New("multileg: missing leg price")
+)
+
+// Leg is an instrument leg of a spread
+type Leg struct {
+	Symbol string
+	Side   enum.Side
+	// RatioQty is the quantity of the leg for one unit of the spread, a positive integer
+	RatioQty decimal.Decimal
+	// Price is optional, it is only needed to compute the spread price
+	Price decimal.Decimal
+	// RefID is the LegRefID, Validate numbers the legs from 1 when empty
+	RefID string
+}
+
+// Spread is a multileg order, e.g. a calendar spread of two futures
+type Spread struct {
+	// ClOrdID is generated by Submit if empty
+	ClOrdID string
+	Account string
+	// Symbol is the symbol of the strategy, if the exchange lists one
+	Symbol   string
+	Side     enum.Side
+	OrdType  enum.OrdType
+	OrderQty decimal.Decimal
+	// Price is the net price of the spread, Submit computes it from the leg prices
+	// when zero and all legs have a price
+	Price decimal.Decimal
+	Legs  []Leg
+}
+
+// LegError is returned by Validate for an invalid leg
+type LegError struct {
+	Index  int
+	Symbol string
+	Reason string
+}
+
+func (e *LegError) Error() string {
+	return fmt.Sprintf("multileg: invalid leg %d %s: %s", e.Index+1, e.Symbol, e.Reason)
+}
+
+// Validate checks the legs of the spread: at least two legs on distinct instruments,
+// with a side and positive integer ratios without common divisor
+func (s *Spread) Validate() error {
+	if len(s.Legs) < 2 {
+		return ErrTooFewLegs
+	}
+	seen := make(map[string]bool, len(s.Legs))
+	gcd := int64(0)
+	for i := range s.Legs {
+		l := &s.Legs[i]
+		switch {
+		case l.Symbol == "":
+			return &LegError{Index: i, Reason: "missing LegSymbol"}
+		case seen[l.Symbol]:
+			return &LegError{Index: i, Symbol: l.Symbol, Reason: "duplicate LegSymbol"}
+		case l.Side == "":
+			return &LegError{Index: i, Symbol: l.Symbol, Reason: "missing LegSide"}
+		case !l.RatioQty.IsPositive() || !l.RatioQty.Equal(l.RatioQty.Truncate(0)):
+			return &LegError{Index: i, Symbol: l.Symbol, Reason: "LegRatioQty must be a positive integer"}
+		}
+		seen[l.Symbol] = true
+		gcd = greatestCommonDivisor(gcd, l.RatioQty.IntPart())
+		if l.RefID == "" {
+			l.RefID = fmt.Sprint(i + 1)
+		}
+	}
+	if gcd != 1 {
+		return &LegError{Index: 0, Symbol: s.Legs[0].Symbol, Reason: fmt.Sprintf("LegRatioQty have the common divisor %d", gcd)}
+	}
+	return nil
+}
+
+func greatestCommonDivisor(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// SpreadPrice returns the net price of buying one unit of the spread:
+// the sum of the leg prices times their ratio, counted positive for bought legs
+// and negative for sold legs
+func SpreadPrice(legs []Leg) (decimal.Decimal, error) {
+	price := decimal.Zero
+	for _, l := range legs {
+		if l.Price.IsZero() {
+			return decimal.Zero, ErrMissingLegPrice
+		}
+		p := l.Price.Mul(l.RatioQty)
+		if isSell(l.Side) {
+			p = p.Neg()
+		}
+		price = price.Add(p)
+	}
+	return price, nil
+}
+
+func isSell(side enum.Side) bool {
+	return side == enum.Side_SELL || side == enum.Side_SELL_SHORT || side == enum.Side_SELL_SHORT_EXEMPT
+}
+
+// scale returns the number of decimal places of v without its trailing zeros,
+// so that a quantity or a price is sent unrounded
+func scale(v decimal.Decimal) int32 {
+	var s int32
+	for !v.Truncate(s).Equal(v) {
+		s++
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package multileg
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+func leg(symbol string, side enum.Side, ratio int64, px string) Leg {
+	l := Leg{Symbol: symbol, Side: side, RatioQty: decimal.NewFromInt(ratio)}
+	if px != "" {
+		l.Price = decimal.RequireFromString(px)
+	}
+	return l
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		legs    []Leg
+		wantErr string
+	}{
+		{"calendar", []Leg{leg("F1", enum.Side_BUY, 1, ""), leg("F2", enum.Side_SELL, 1, "")}, ""},
+		{"ratio", []Leg{leg("F1", enum.Side_BUY, 2, ""), leg("F2", enum.Side_SELL, 3, "")}, ""},
+		{"one leg", []Leg{leg("F1", enum.Side_BUY, 1, "")}, ErrTooFewLegs.Error()},
+		{"no symbol", []Leg{leg("", enum.Side_BUY, 1, ""), leg("F2", enum.Side_SELL, 1, "")},
+			"multileg: invalid leg 1 : missing LegSymbol"},
+		{"duplicate", []Leg{leg("F1", enum.Side_BUY, 1, ""), leg("F1", enum.Side_SELL, 1, "")},
+			"multileg: invalid leg 2 F1: duplicate LegSymbol"},
+		{"no side", []Leg{leg("F1", enum.Side_BUY, 1, ""), leg("F2", "", 1, "")},
+			"multileg: invalid leg 2 F2: missing LegSide"},
+		{"zero ratio", []Leg{leg("F1", enum.Side_BUY, 1, ""), leg("F2", enum.Side_SELL, 0, "")},
+			"multileg: invalid leg 2 F2: LegRatioQty must be a positive integer"},
+		{"common divisor", []Leg{leg("F1", enum.Side_BUY, 2, ""), leg("F2", enum.Side_SELL, 4, "")},
+			"multileg: invalid leg 1 F1: LegRatioQty have the common divisor 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Spread{Legs: tt.legs}
+			err := s.Validate()
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Fatalf("got %q, want %q", got, tt.wantErr)
+			}
+			if err == nil && (s.Legs[0].RefID != "1" || s.Legs[1].RefID != "2") {
+				t.Errorf("got LegRefIDs %q %q, want 1 2", s.Legs[0].RefID, s.Legs[1].RefID)
+			}
+		})
+	}
+}
+
+func TestSpreadPrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		legs    []Leg
+		want    string
+		wantErr error
+	}{
+		{"calendar", []Leg{leg("F1", enum.Side_BUY, 1, "1250.5"), leg("F2", enum.Side_SELL, 1, "1248")}, "2.5", nil},
+		{"ratio", []Leg{leg("F1", enum.Side_SELL, 2, "10.25"), leg("F2", enum.Side_BUY, 3, "7")}, "0.5", nil},
+		{"missing price", []Leg{leg("F1", enum.Side_BUY, 1, "1250.5"), leg("F2", enum.Side_SELL, 1, "")}, "0", ErrMissingLegPrice},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SpreadPrice(tt.legs)
+			if err != tt.wantErr {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("got %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package networkcounterpartysystemstatusresponse
 
 import (
+	"iter"
+
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/fix44"
@@ -290,3 +292,57 @@ func (m NoCompIDsRepeatingGroup) Add() NoCompIDs {
 func (m NoCompIDsRepeatingGroup) Get(i int) NoCompIDs {
 	return NoCompIDs{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoCompIDs of each entry in the NoCompIDsRepeatingGroup
+func (m NoCompIDsRepeatingGroup) All() iter.Seq2[int, NoCompIDs] {
+	return func(yield func(int, NoCompIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoCompIDs entries of the NoCompIDsRepeatingGroup as a slice
+func (m NoCompIDsRepeatingGroup) Slice() []NoCompIDs {
+	s := make([]NoCompIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoCompIDsRow holds the values of a NoCompIDs, nil fields are left unset by AddRow
+type NoCompIDsRow struct {
+	RefCompID   *string
+	RefSubID    *string
+	LocationID  *string
+	DeskID      *string
+	StatusValue *enum.StatusValue
+	StatusText  *string
+}
+
+//AddRow creates and appends a new NoCompIDs to this group, setting the fields present in row
+func (m NoCompIDsRepeatingGroup) AddRow(row NoCompIDsRow) NoCompIDs {
+	g := m.Add()
+	if row.RefCompID != nil {
+		g.SetRefCompID(*row.RefCompID)
+	}
+	if row.RefSubID != nil {
+		g.SetRefSubID(*row.RefSubID)
+	}
+	if row.LocationID != nil {
+		g.SetLocationID(*row.LocationID)
+	}
+	if row.DeskID != nil {
+		g.SetDeskID(*row.DeskID)
+	}
+	if row.StatusValue != nil {
+		g.SetStatusValue(*row.StatusValue)
+	}
+	if row.StatusText != nil {
+		g.SetStatusText(*row.StatusText)
+	}
+	return g
+}
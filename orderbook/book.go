@@ -0,0 +1,210 @@
+package orderbook
+
+import (
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+// Level is a price level of one side of a Book
+type Level struct {
+	Price          decimal.Decimal
+	Size           decimal.Decimal
+	NumberOfOrders int
+}
+
+// Entry is the last value received for an MDEntryType that is not a bid or an offer,
+// e.g. a trade, the opening price or the trade volume
+type Entry struct {
+	Type  enum.MDEntryType
+	Price decimal.Decimal
+	Size  decimal.Decimal
+	// Date and Time are MDEntryDate and MDEntryTime as sent by the counterparty
+	Date string
+	Time string
+}
+
+// Book is the market data of a symbol as built by a Builder
+type Book struct {
+	Symbol string
+	// Bids are sorted from the highest price, Offers from the lowest
+	Bids   []Level
+	Offers []Level
+	// Stats holds the last Entry of each other MDEntryType, including trades
+	Stats map[enum.MDEntryType]Entry
+
+	// RptSeq is the sequence number of the last applied message, 0 if the feed is not sequenced
+	RptSeq int
+	// Synced is false until a snapshot is applied and after a gap in RptSeq,
+	// until the next snapshot
+	Synced    bool
+	UpdatedAt time.Time
+}
+
+// BestBid returns the highest bid, false if there is no bid
+func (b Book) BestBid() (Level, bool) {
+	if len(b.Bids) == 0 {
+		return Level{}, false
+	}
+	return b.Bids[0], true
+}
+
+// BestOffer returns the lowest offer, false if there is no offer
+func (b Book) BestOffer() (Level, bool) {
+	if len(b.Offers) == 0 {
+		return Level{}, false
+	}
+	return b.Offers[0], true
+}
+
+// Spread returns the best offer price minus the best bid price, false if a side is empty
+func (b Book) Spread() (decimal.Decimal, bool) {
+	bid, ok := b.BestBid()
+	if !ok {
+		return decimal.Zero, false
+	}
+	offer, ok := b.BestOffer()
+	if !ok {
+		return decimal.Zero, false
+	}
+	return offer.Price.Sub(bid.Price), true
+}
+
+// LastTrade returns the last trade entry, false if no trade was received
+func (b Book) LastTrade() (Entry, bool) {
+	e, ok := b.Stats[enum.MDEntryType_TRADE]
+	return e, ok
+}
+
+// Depth returns at most n levels of each side, all of them if n <= 0
+func (b Book) Depth(n int) (bids, offers []Level) {
+	return head(b.Bids, n), head(b.Offers, n)
+}
+
+func head(levels []Level, n int) []Level {
+	if n <= 0 || n > len(levels) {
+		n = len(levels)
+	}
+	return append([]Level(nil), levels[:n]...)
+}
+
+func (b *Book) clone() Book {
+	c := *b
+	c.Bids = append([]Level(nil), b.Bids...)
+	c.Offers = append([]Level(nil), b.Offers...)
+	c.Stats = make(map[enum.MDEntryType]Entry, len(b.Stats))
+	for k, v := range b.Stats {
+		c.Stats[k] = v
+	}
+	return c
+}
+
+// update is a market data entry of a snapshot or an incremental refresh
+type update struct {
+	action enum.MDUpdateAction
+	entry  Entry
+	// numberOfOrders and positionNo are 0 when absent
+	numberOfOrders int
+	positionNo     int
+}
+
+func (b *Book) side(t enum.MDEntryType) *[]Level {
+	switch t {
+	case enum.MDEntryType_BID:
+		return &b.Bids
+	case enum.MDEntryType_OFFER:
+		return &b.Offers
+	}
+	return nil
+}
+
+// apply changes the book with u. Entries with an MDEntryPositionNo are applied
+// by position, shifting the levels below, the others by price.
+func (b *Book) apply(u update) error {
+	levels := b.side(u.entry.Type)
+	if levels == nil {
+		if u.action == enum.MDUpdateAction_DELETE {
+			delete(b.Stats, u.entry.Type)
+		} else {
+			b.Stats[u.entry.Type] = u.entry
+		}
+		return nil
+	}
+	l := Level{Price: u.entry.Price, Size: u.entry.Size, NumberOfOrders: u.numberOfOrders}
+	if u.positionNo > 0 {
+		return applyPosition(levels, u.action, u.positionNo-1, l)
+	}
+	applyPrice(levels, u.action, l, u.entry.Type == enum.MDEntryType_BID)
+	return nil
+}
+
+func applyPosition(levels *[]Level, action enum.MDUpdateAction, i int, l Level) error {
+	s := *levels
+	switch action {
+	case enum.MDUpdateAction_NEW:
+		if i > len(s) {
+			return ErrInvalidPosition
+		}
+		s = append(s, Level{})
+		copy(s[i+1:], s[i:])
+		s[i] = l
+	case enum.MDUpdateAction_CHANGE:
+		if i >= len(s) {
+			return ErrInvalidPosition
+		}
+		s[i] = l
+	case enum.MDUpdateAction_DELETE:
+		if i >= len(s) {
+			return ErrInvalidPosition
+		}
+		s = append(s[:i], s[i+1:]...)
+	default:
+		return ErrUnknownUpdateAction
+	}
+	*levels = s
+	return nil
+}
+
+func applyPrice(levels *[]Level, action enum.MDUpdateAction, l Level, descending bool) {
+	s := *levels
+	i := 0
+	for i < len(s) && better(s[i].Price, l.Price, descending) {
+		i++
+	}
+	found := i < len(s) && s[i].Price.Equal(l.Price)
+	switch {
+	case action == enum.MDUpdateAction_DELETE || l.Size.IsZero():
+		if found {
+			s = append(s[:i], s[i+1:]...)
+		}
+	case found:
+		s[i] = l
+	default:
+		s = append(s, Level{})
+		copy(s[i+1:], s[i:])
+		s[i] = l
+	}
+	*levels = s
+}
+
+// better tells if price a comes before price b on a side
+func better(a, b decimal.Decimal, descending bool) bool {
+	if descending {
+		return a.GreaterThan(b)
+	}
+	return a.LessThan(b)
+}
+
+// truncate drops the levels beyond depth, if depth > 0
+func (b *Book) truncate(depth int) {
+	if depth <= 0 {
+		return
+	}
+	if len(b.Bids) > depth {
+		b.Bids = b.Bids[:depth]
+	}
+	if len(b.Offers) > depth {
+		b.Offers = b.Offers[:depth]
+	}
+}
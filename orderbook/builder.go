@@ -0,0 +1,303 @@
+package orderbook
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/marketdataincrementalrefresh"
+	"github.com/quickfixgo/fix44/marketdatasnapshotfullrefresh"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+var (
+	// ErrUnknownSymbol is returned for an incremental entry without Symbol
+	// whose MDReqID was not seen on a snapshot
+	ErrUnknownSymbol = errors.New("orderbook: cannot tell the symbol of an incremental entry")
+	// ErrInvalidPosition is returned for an MDEntryPositionNo outside of the book
+	ErrInvalidPosition = errors.New("orderbook: MDEntryPositionNo out of the book")
+	// ErrUnknownUpdateAction is returned for an MDUpdateAction other than New, Change or Delete
+	ErrUnknownUpdateAction = errors.New("orderbook: unknown MDUpdateAction")
+)
+
+// DefaultMaxPending is the default Builder.MaxPending
+const DefaultMaxPending = 10000
+
+// Builder maintains a Book per symbol from MarketDataSnapshotFullRefresh and
+// MarketDataIncrementalRefresh messages.
+//
+// FIX 4.4 does not define RptSeq on the market data messages, when the counterparty
+// sends it (tag 83) in the message body it is used to detect gaps: incremental refreshes
+// received before the first snapshot or after a gap are queued until the next snapshot,
+// then the ones more recent than the snapshot are replayed.
+// Without RptSeq every incremental refresh is applied as it comes.
+// A Builder is safe for concurrent use.
+type Builder struct {
+	// Depth is the number of price levels kept on each side, 0 keeps all of them
+	Depth int
+	// MaxPending is the number of incremental refreshes queued per symbol while waiting
+	// for a snapshot, older ones are dropped
+	MaxPending int
+	// OnUpdate, if set, is called with a copy of a book each time it changes
+	OnUpdate func(Book)
+	// OnGap, if set, is called when an RptSeq is missing on a symbol,
+	// the usual answer is to request a new snapshot.
+	// OnUpdate and OnGap are called once the Builder is unlocked, so they can call the Builder.
+	OnGap func(symbol string, expected, received int)
+
+	mu       sync.Mutex
+	books    map[string]*Book
+	pending  map[string][]incremental
+	mdReqIDs map[string]string
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// incremental is the part of an incremental refresh about one symbol
+type incremental struct {
+	rptSeq  int
+	updates []update
+}
+
+// New returns a Builder without any book
+func New() *Builder {
+	return &Builder{
+		MaxPending: DefaultMaxPending,
+		books:      make(map[string]*Book),
+		pending:    make(map[string][]incremental),
+		mdReqIDs:   make(map[string]string),
+	}
+}
+
+// Process applies a snapshot or an incremental refresh, other messages are ignored
+func (b *Builder) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "W":
+		return b.OnSnapshot(marketdatasnapshotfullrefresh.FromMessage(msg))
+	case "X":
+		return b.OnIncremental(marketdataincrementalrefresh.FromMessage(msg))
+	}
+	return nil
+}
+
+// rptSeq returns the RptSeq of a message body, 0 if absent
+func rptSeq(body *quickfix.Body) int {
+	seq, err := body.GetInt(tag.RptSeq)
+	if err != nil {
+		return 0
+	}
+	return seq
+}
+
+// OnSnapshot replaces the book of the snapshot symbol
+func (b *Builder) OnSnapshot(m marketdatasnapshotfullrefresh.MarketDataSnapshotFullRefresh) error {
+	symbol, err := m.GetSymbol()
+	if err != nil {
+		return err
+	}
+	mdReqID, _ := m.GetMDReqID()
+	seq := rptSeq(m.Body)
+	var updates []update
+	if entries, err := m.GetNoMDEntries(); err == nil {
+		for _, e := range entries.All() {
+			u := update{action: enum.MDUpdateAction_NEW}
+			if u.entry.Type, err = e.GetMDEntryType(); err != nil {
+				return err
+			}
+			u.entry.Price, _ = e.GetMDEntryPx()
+			u.entry.Size, _ = e.GetMDEntrySize()
+			u.entry.Date, _ = e.GetMDEntryDate()
+			u.entry.Time, _ = e.GetMDEntryTime()
+			u.numberOfOrders, _ = e.GetNumberOfOrders()
+			u.positionNo, _ = e.GetMDEntryPositionNo()
+			updates = append(updates, u)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.unlock()
+	if mdReqID != "" {
+		b.mdReqIDs[mdReqID] = symbol
+	}
+	book := &Book{Symbol: symbol, Stats: make(map[enum.MDEntryType]Entry), RptSeq: seq, Synced: true}
+	b.books[symbol] = book
+	for _, u := range updates {
+		if err := book.apply(u); err != nil {
+			book.Synced = false
+			return err
+		}
+	}
+	pending := b.pending[symbol]
+	delete(b.pending, symbol)
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].rptSeq < pending[j].rptSeq })
+	for i, inc := range pending {
+		if inc.rptSeq <= book.RptSeq {
+			continue
+		}
+		if book.RptSeq > 0 && inc.rptSeq > book.RptSeq+1 {
+			book.Synced = false
+			b.pending[symbol] = pending[i:]
+			b.gap(symbol, book.RptSeq+1, inc.rptSeq)
+			break
+		}
+		if err := b.applyIncremental(book, inc); err != nil {
+			return err
+		}
+	}
+	book.truncate(b.Depth)
+	book.UpdatedAt = time.Now()
+	b.changed(book)
+	return nil
+}
+
+// OnIncremental applies the entries of an incremental refresh to the books of their symbols.
+// Entries without Symbol belong to the symbol of the snapshot with the same MDReqID.
+func (b *Builder) OnIncremental(m marketdataincrementalrefresh.MarketDataIncrementalRefresh) error {
+	entries, err := m.GetNoMDEntries()
+	if err != nil {
+		return err
+	}
+	mdReqID, _ := m.GetMDReqID()
+	seq := rptSeq(m.Body)
+
+	b.mu.Lock()
+	defer b.unlock()
+	bySymbol := make(map[string]*incremental)
+	var symbols []string
+	for _, e := range entries.All() {
+		symbol, _ := e.GetSymbol()
+		if symbol == "" {
+			if symbol = b.mdReqIDs[mdReqID]; symbol == "" {
+				return ErrUnknownSymbol
+			}
+		}
+		var u update
+		if u.action, err = e.GetMDUpdateAction(); err != nil {
+			return err
+		}
+		if u.entry.Type, err = e.GetMDEntryType(); err != nil {
+			return err
+		}
+		u.entry.Price, _ = e.GetMDEntryPx()
+		u.entry.Size, _ = e.GetMDEntrySize()
+		u.entry.Date, _ = e.GetMDEntryDate()
+		u.entry.Time, _ = e.GetMDEntryTime()
+		u.numberOfOrders, _ = e.GetNumberOfOrders()
+		u.positionNo, _ = e.GetMDEntryPositionNo()
+		inc, ok := bySymbol[symbol]
+		if !ok {
+			inc = &incremental{rptSeq: seq}
+			bySymbol[symbol] = inc
+			symbols = append(symbols, symbol)
+		}
+		inc.updates = append(inc.updates, u)
+	}
+
+	sort.Strings(symbols)
+	for _, symbol := range symbols {
+		inc := *bySymbol[symbol]
+		book, ok := b.books[symbol]
+		switch {
+		case inc.rptSeq == 0:
+			if !ok {
+				book = &Book{Symbol: symbol, Stats: make(map[enum.MDEntryType]Entry)}
+				b.books[symbol] = book
+			}
+		case !ok || !book.Synced:
+			b.queue(symbol, inc)
+			continue
+		case inc.rptSeq <= book.RptSeq:
+			// already applied, e.g. a resend
+			continue
+		case inc.rptSeq > book.RptSeq+1:
+			book.Synced = false
+			b.queue(symbol, inc)
+			b.gap(symbol, book.RptSeq+1, inc.rptSeq)
+			b.changed(book)
+			continue
+		}
+		if err := b.applyIncremental(book, inc); err != nil {
+			return err
+		}
+		book.truncate(b.Depth)
+		book.UpdatedAt = time.Now()
+		b.changed(book)
+	}
+	return nil
+}
+
+func (b *Builder) applyIncremental(book *Book, inc incremental) error {
+	for _, u := range inc.updates {
+		if err := book.apply(u); err != nil {
+			book.Synced = false
+			return err
+		}
+	}
+	if inc.rptSeq > 0 {
+		book.RptSeq = inc.rptSeq
+	}
+	return nil
+}
+
+func (b *Builder) queue(symbol string, inc incremental) {
+	q := append(b.pending[symbol], inc)
+	if b.MaxPending > 0 && len(q) > b.MaxPending {
+		q = q[len(q)-b.MaxPending:]
+	}
+	b.pending[symbol] = q
+}
+
+// changed queues the call of OnUpdate with a copy of book, it must be called with mu held
+func (b *Builder) changed(book *Book) {
+	if b.OnUpdate != nil {
+		c := book.clone()
+		b.callbacks = append(b.callbacks, func() { b.OnUpdate(c) })
+	}
+}
+
+// gap queues the call of OnGap, it must be called with mu held
+func (b *Builder) gap(symbol string, expected, received int) {
+	if b.OnGap != nil {
+		b.callbacks = append(b.callbacks, func() { b.OnGap(symbol, expected, received) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (b *Builder) unlock() {
+	callbacks := b.callbacks
+	b.callbacks = nil
+	b.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// Book returns a copy of the book of symbol, false if nothing was received for it
+func (b *Builder) Book(symbol string) (Book, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	book, ok := b.books[symbol]
+	if !ok {
+		return Book{}, false
+	}
+	return book.clone(), true
+}
+
+// Symbols returns the symbols with a book, sorted
+func (b *Builder) Symbols() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	symbols := make([]string, 0, len(b.books))
+	for s := range b.books {
+		symbols = append(symbols, s)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
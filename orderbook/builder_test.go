@@ -0,0 +1,128 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/marketdataincrementalrefresh"
+	"github.com/quickfixgo/fix44/marketdatasnapshotfullrefresh"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+func snapshot(seq int) marketdatasnapshotfullrefresh.MarketDataSnapshotFullRefresh {
+	m := marketdatasnapshotfullrefresh.New()
+	m.SetSymbol("VND")
+	m.SetMDReqID("R1")
+	m.Body.SetInt(tag.RptSeq, seq)
+	g := marketdatasnapshotfullrefresh.NewNoMDEntriesRepeatingGroup()
+	for _, e := range []struct {
+		entryType enum.MDEntryType
+		px        string
+	}{{enum.MDEntryType_BID, "10"}, {enum.MDEntryType_BID, "10.5"}, {enum.MDEntryType_OFFER, "11"}} {
+		row := g.Add()
+		row.SetMDEntryType(e.entryType)
+		row.SetMDEntryPx(decimal.RequireFromString(e.px), 2)
+		row.SetMDEntrySize(decimal.NewFromInt(100), 0)
+	}
+	m.SetNoMDEntries(g)
+	return m
+}
+
+func newBid(seq int, px string) marketdataincrementalrefresh.MarketDataIncrementalRefresh {
+	m := marketdataincrementalrefresh.New()
+	m.SetMDReqID("R1")
+	m.Body.SetInt(tag.RptSeq, seq)
+	g := marketdataincrementalrefresh.NewNoMDEntriesRepeatingGroup()
+	row := g.Add()
+	row.SetMDUpdateAction(enum.MDUpdateAction_NEW)
+	row.SetSymbol("VND")
+	row.SetMDEntryType(enum.MDEntryType_BID)
+	row.SetMDEntryPx(decimal.RequireFromString(px), 2)
+	row.SetMDEntrySize(decimal.NewFromInt(50), 0)
+	m.SetNoMDEntries(g)
+	return m
+}
+
+func TestBuilderSequence(t *testing.T) {
+	tests := []struct {
+		name       string
+		seq        int
+		wantBid    string
+		wantRptSeq int
+		wantSynced bool
+		wantGap    bool
+	}{
+		{name: "next", seq: 6, wantBid: "10.8", wantRptSeq: 6, wantSynced: true},
+		{name: "already applied", seq: 5, wantBid: "10.5", wantRptSeq: 5, wantSynced: true},
+		{name: "gap", seq: 7, wantBid: "10.5", wantRptSeq: 5, wantSynced: false, wantGap: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New()
+			var gap bool
+			b.OnGap = func(symbol string, expected, received int) {
+				gap = symbol == "VND" && expected == 6 && received == tt.seq
+			}
+			if err := b.OnSnapshot(snapshot(5)); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.OnIncremental(newBid(tt.seq, "10.8")); err != nil {
+				t.Fatal(err)
+			}
+			book, _ := b.Book("VND")
+			if bid, _ := book.BestBid(); bid.Price.String() != tt.wantBid {
+				t.Errorf("got best bid %s, want %s", bid.Price, tt.wantBid)
+			}
+			if book.RptSeq != tt.wantRptSeq || book.Synced != tt.wantSynced || gap != tt.wantGap {
+				t.Errorf("got RptSeq %d, Synced %v, gap %v", book.RptSeq, book.Synced, gap)
+			}
+		})
+	}
+}
+
+func TestBuilderReplay(t *testing.T) {
+	b := New()
+	var books int
+	b.OnGap = func(symbol string, expected, received int) {
+		// the Builder is unlocked when OnGap is called
+		if _, ok := b.Book(symbol); ok {
+			books++
+		}
+	}
+	// incremental refreshes before the first snapshot are queued
+	if err := b.OnIncremental(newBid(4, "10.1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.OnIncremental(newBid(6, "10.6")); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.OnSnapshot(snapshot(5)); err != nil {
+		t.Fatal(err)
+	}
+	book, _ := b.Book("VND")
+	if bid, _ := book.BestBid(); book.RptSeq != 6 || !book.Synced || bid.Price.String() != "10.6" {
+		t.Fatalf("got RptSeq %d, Synced %v, best bid %s after the replay", book.RptSeq, book.Synced, bid.Price)
+	}
+
+	// after a gap the refreshes are queued until the next snapshot
+	if err := b.OnIncremental(newBid(8, "10.8")); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.OnIncremental(newBid(9, "10.9")); err != nil {
+		t.Fatal(err)
+	}
+	if books != 1 {
+		t.Errorf("got %d calls of OnGap, want 1", books)
+	}
+	if err := b.OnSnapshot(snapshot(7)); err != nil {
+		t.Fatal(err)
+	}
+	book, _ = b.Book("VND")
+	if bid, _ := book.BestBid(); book.RptSeq != 9 || !book.Synced || bid.Price.String() != "10.9" {
+		t.Errorf("got RptSeq %d, Synced %v, best bid %s after the replay", book.RptSeq, book.Synced, bid.Price)
+	}
+	if len(book.Bids) != 4 {
+		t.Errorf("got %d bid levels, want 4", len(book.Bids))
+	}
+}
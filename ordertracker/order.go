@@ -0,0 +1,107 @@
+package ordertracker
+
+import (
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+// Order is the state of an order as reconstructed from the messages seen by a Tracker
+type Order struct {
+	// ClOrdID is the ClOrdID of the last accepted request on the order
+	ClOrdID string
+	// ClOrdIDs is the chain of accepted ClOrdIDs, from the NewOrderSingle to ClOrdID
+	ClOrdIDs []string
+	OrderID  string
+	Account  string
+	Symbol   string
+	Side     enum.Side
+	OrdType  enum.OrdType
+	OrderQty decimal.Decimal
+	Price    decimal.Decimal
+
+	OrdStatus enum.OrdStatus
+	CumQty    decimal.Decimal
+	LeavesQty decimal.Decimal
+	AvgPx     decimal.Decimal
+	Fills     []Fill
+
+	// Pending is the cancel or replace request waiting for an answer, if any
+	Pending *Request
+	// Text of the last ExecutionReport or OrderCancelReject
+	Text      string
+	UpdatedAt time.Time
+}
+
+// Request is a cancel or replace request sent on an order
+type Request struct {
+	// MsgType is F for OrderCancelRequest and G for OrderCancelReplaceRequest
+	MsgType     string
+	ClOrdID     string
+	OrigClOrdID string
+	OrdType     enum.OrdType
+	OrderQty    decimal.Decimal
+	Price       decimal.Decimal
+}
+
+// Fill is an execution on an order, from an ExecutionReport with ExecType F
+type Fill struct {
+	ExecID       string
+	ClOrdID      string
+	LastQty      decimal.Decimal
+	LastPx       decimal.Decimal
+	TransactTime time.Time
+}
+
+// IsOpen returns true if the order can still be filled
+func (o Order) IsOpen() bool {
+	return !isTerminal(o.OrdStatus)
+}
+
+// clone returns a copy of o that does not share slices with it
+func (o *Order) clone() Order {
+	c := *o
+	c.ClOrdIDs = append([]string(nil), o.ClOrdIDs...)
+	c.Fills = append([]Fill(nil), o.Fills...)
+	if o.Pending != nil {
+		p := *o.Pending
+		c.Pending = &p
+	}
+	return c
+}
+
+// isTerminal returns true for the OrdStatus after which an order does not change anymore,
+// except for trade corrections and cancels
+func isTerminal(s enum.OrdStatus) bool {
+	switch s {
+	case enum.OrdStatus_FILLED, enum.OrdStatus_CANCELED, enum.OrdStatus_REJECTED, enum.OrdStatus_EXPIRED:
+		return true
+	}
+	return false
+}
+
+// validTransition tells if an order may move from OrdStatus from to OrdStatus to,
+// following the order state change matrices of FIX 4.4 Volume 4 Appendix D
+func validTransition(from, to enum.OrdStatus) bool {
+	if from == to {
+		return true
+	}
+	switch from {
+	case enum.OrdStatus_PENDING_NEW:
+		return true
+	case enum.OrdStatus_FILLED, enum.OrdStatus_CANCELED, enum.OrdStatus_REJECTED, enum.OrdStatus_EXPIRED:
+		return false
+	}
+	switch to {
+	case enum.OrdStatus_PENDING_NEW:
+		return false
+	case enum.OrdStatus_REJECTED:
+		// rejected cancel and replace requests are answered with an OrderCancelReject
+		return false
+	case enum.OrdStatus_NEW:
+		// an order with fills can only go back to New on a trade cancel
+		return from != enum.OrdStatus_PARTIALLY_FILLED
+	}
+	return true
+}
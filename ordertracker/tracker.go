@@ -0,0 +1,414 @@
+package ordertracker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/newordersingle"
+	"github.com/quickfixgo/fix44/ordercancelreject"
+	"github.com/quickfixgo/fix44/ordercancelreplacerequest"
+	"github.com/quickfixgo/fix44/ordercancelrequest"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrUnknownOrder is returned for a message that does not refer to a tracked order
+	ErrUnknownOrder = errors.New("ordertracker: unknown order")
+	// ErrDuplicateClOrdID is returned for a request reusing a ClOrdID already seen
+	ErrDuplicateClOrdID = errors.New("ordertracker: duplicate ClOrdID")
+	// ErrOrderClosed is returned for a cancel or replace request on an order that is no longer open
+	ErrOrderClosed = errors.New("ordertracker: order is not open")
+	// ErrRequestPending is returned for a cancel or replace request sent while another one is pending
+	ErrRequestPending = errors.New("ordertracker: a cancel or replace request is already pending")
+	// ErrDuplicateExecID is returned for an ExecutionReport already applied, e.g. a PossDup resend
+	ErrDuplicateExecID = errors.New("ordertracker: duplicate ExecID")
+	// ErrStaleReport is returned for an ExecutionReport older than the state of the order,
+	// e.g. a report with a lower CumQty received out of order
+	ErrStaleReport = errors.New("ordertracker: stale execution report")
+)
+
+// TransitionError is returned for an ExecutionReport that would move an order
+// to an OrdStatus it cannot reach from its current one
+type TransitionError struct {
+	ClOrdID  string
+	ExecType enum.ExecType
+	From, To enum.OrdStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("ordertracker: invalid transition of order %s from OrdStatus %s to %s on ExecType %s",
+		e.ClOrdID, e.From, e.To, e.ExecType)
+}
+
+// Tracker reconstructs the lifecycle of orders from the NewOrderSingle, OrderCancelRequest
+// and OrderCancelReplaceRequest sent and the ExecutionReport and OrderCancelReject received.
+// Messages that cannot be applied are left out of the state and reported with an error.
+// A Tracker is safe for concurrent use.
+type Tracker struct {
+	// OnChange, if set, is called with a copy of an order each time it changes.
+	// It is called once the Tracker is unlocked, so it can call the Tracker.
+	OnChange func(Order)
+
+	mu        sync.Mutex
+	orders    []*Order
+	byClOrdID map[string]*Order
+	byOrderID map[string]*Order
+	execIDs   map[string]bool
+	fills     []Fill
+	// prevStatus is the OrdStatus to restore when a pending request is rejected
+	prevStatus map[*Order]enum.OrdStatus
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns an empty Tracker
+func New() *Tracker {
+	return &Tracker{
+		byClOrdID:  make(map[string]*Order),
+		byOrderID:  make(map[string]*Order),
+		execIDs:    make(map[string]bool),
+		prevStatus: make(map[*Order]enum.OrdStatus),
+	}
+}
+
+// Process applies any of the tracked message types, other messages are ignored.
+// It can be called from both ToApp and FromApp of a quickfix.Application.
+func (t *Tracker) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "D":
+		return t.OnNewOrderSingle(newordersingle.FromMessage(msg))
+	case "F":
+		return t.OnOrderCancelRequest(ordercancelrequest.FromMessage(msg))
+	case "G":
+		return t.OnOrderCancelReplaceRequest(ordercancelreplacerequest.FromMessage(msg))
+	case "8":
+		return t.OnExecutionReport(executionreport.FromMessage(msg))
+	case "9":
+		return t.OnOrderCancelReject(ordercancelreject.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnNewOrderSingle starts tracking a new order in OrdStatus PendingNew
+func (t *Tracker) OnNewOrderSingle(m newordersingle.NewOrderSingle) error {
+	clOrdID, err := m.GetClOrdID()
+	if err != nil {
+		return err
+	}
+	o := &Order{ClOrdID: clOrdID, ClOrdIDs: []string{clOrdID}, OrdStatus: enum.OrdStatus_PENDING_NEW}
+	o.Account, _ = m.GetAccount()
+	o.Symbol, _ = m.GetSymbol()
+	o.Side, _ = m.GetSide()
+	o.OrdType, _ = m.GetOrdType()
+	o.OrderQty, _ = m.GetOrderQty()
+	o.Price, _ = m.GetPrice()
+	o.LeavesQty = o.OrderQty
+	o.UpdatedAt, _ = m.GetTransactTime()
+
+	t.mu.Lock()
+	defer t.unlock()
+	if _, ok := t.byClOrdID[clOrdID]; ok {
+		return ErrDuplicateClOrdID
+	}
+	t.orders = append(t.orders, o)
+	t.byClOrdID[clOrdID] = o
+	t.changed(o)
+	return nil
+}
+
+// OnOrderCancelRequest records a pending cancel on the order referred by OrigClOrdID
+func (t *Tracker) OnOrderCancelRequest(m ordercancelrequest.OrderCancelRequest) error {
+	r := Request{MsgType: "F"}
+	var err quickfix.MessageRejectError
+	if r.ClOrdID, err = m.GetClOrdID(); err != nil {
+		return err
+	}
+	if r.OrigClOrdID, err = m.GetOrigClOrdID(); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.unlock()
+	return t.addRequest(r)
+}
+
+// OnOrderCancelReplaceRequest records a pending replace on the order referred by OrigClOrdID
+func (t *Tracker) OnOrderCancelReplaceRequest(m ordercancelreplacerequest.OrderCancelReplaceRequest) error {
+	r := Request{MsgType: "G"}
+	var err quickfix.MessageRejectError
+	if r.ClOrdID, err = m.GetClOrdID(); err != nil {
+		return err
+	}
+	if r.OrigClOrdID, err = m.GetOrigClOrdID(); err != nil {
+		return err
+	}
+	r.OrdType, _ = m.GetOrdType()
+	r.OrderQty, _ = m.GetOrderQty()
+	r.Price, _ = m.GetPrice()
+
+	t.mu.Lock()
+	defer t.unlock()
+	return t.addRequest(r)
+}
+
+func (t *Tracker) addRequest(r Request) error {
+	o, ok := t.byClOrdID[r.OrigClOrdID]
+	if !ok {
+		return ErrUnknownOrder
+	}
+	if _, ok := t.byClOrdID[r.ClOrdID]; ok {
+		return ErrDuplicateClOrdID
+	}
+	if !o.IsOpen() {
+		return ErrOrderClosed
+	}
+	if o.Pending != nil {
+		return ErrRequestPending
+	}
+	o.Pending = &r
+	t.byClOrdID[r.ClOrdID] = o
+	t.changed(o)
+	return nil
+}
+
+// OnExecutionReport applies an ExecutionReport to the order it refers to,
+// found by ClOrdID, OrigClOrdID or OrderID in that order
+func (t *Tracker) OnExecutionReport(m executionreport.ExecutionReport) error {
+	execType, err := m.GetExecType()
+	if err != nil {
+		return err
+	}
+	ordStatus, err := m.GetOrdStatus()
+	if err != nil {
+		return err
+	}
+	execID, err := m.GetExecID()
+	if err != nil {
+		return err
+	}
+	clOrdID, _ := m.GetClOrdID()
+	origClOrdID, _ := m.GetOrigClOrdID()
+	orderID, _ := m.GetOrderID()
+	cumQty, _ := m.GetCumQty()
+	leavesQty, _ := m.GetLeavesQty()
+	avgPx, _ := m.GetAvgPx()
+	transactTime, _ := m.GetTransactTime()
+	if transactTime.IsZero() {
+		transactTime = time.Now()
+	}
+
+	t.mu.Lock()
+	defer t.unlock()
+	o := t.find(clOrdID, origClOrdID, orderID)
+	if o == nil {
+		return ErrUnknownOrder
+	}
+	if t.execIDs[execID] {
+		return ErrDuplicateExecID
+	}
+
+	switch execType {
+	case enum.ExecType_ORDER_STATUS, enum.ExecType_RESTATED:
+		// status and restatement reports give the current state of the order as is
+	case enum.ExecType_TRADE_CANCEL, enum.ExecType_TRADE_CORRECT:
+		execRefID, _ := m.GetExecRefID()
+		lastQty, _ := m.GetLastQty()
+		lastPx, _ := m.GetLastPx()
+		t.correctFill(o, execType, execRefID, lastQty, lastPx)
+	default:
+		if !validTransition(o.OrdStatus, ordStatus) {
+			return &TransitionError{ClOrdID: o.ClOrdID, ExecType: execType, From: o.OrdStatus, To: ordStatus}
+		}
+		if cumQty.LessThan(o.CumQty) {
+			return ErrStaleReport
+		}
+	}
+
+	switch execType {
+	case enum.ExecType_TRADE:
+		f := Fill{ExecID: execID, ClOrdID: clOrdID, TransactTime: transactTime}
+		f.LastQty, _ = m.GetLastQty()
+		f.LastPx, _ = m.GetLastPx()
+		o.Fills = append(o.Fills, f)
+		t.fills = append(t.fills, f)
+	case enum.ExecType_PENDING_CANCEL, enum.ExecType_PENDING_REPLACE:
+		if _, ok := t.prevStatus[o]; !ok {
+			t.prevStatus[o] = o.OrdStatus
+		}
+	case enum.ExecType_REPLACED:
+		if p := o.Pending; p != nil && p.ClOrdID == clOrdID {
+			o.OrdType, o.OrderQty, o.Price = p.OrdType, p.OrderQty, p.Price
+		}
+		t.advance(o, clOrdID)
+	case enum.ExecType_CANCELED:
+		t.advance(o, clOrdID)
+	}
+
+	if v, err := m.GetOrderQty(); err == nil {
+		o.OrderQty = v
+	}
+	if v, err := m.GetPrice(); err == nil {
+		o.Price = v
+	}
+	if orderID != "" {
+		o.OrderID = orderID
+		t.byOrderID[orderID] = o
+	}
+	o.OrdStatus = ordStatus
+	o.CumQty, o.LeavesQty, o.AvgPx = cumQty, leavesQty, avgPx
+	o.Text, _ = m.GetText()
+	o.UpdatedAt = transactTime
+	t.execIDs[execID] = true
+	t.changed(o)
+	return nil
+}
+
+// OnOrderCancelReject clears the pending request rejected by m
+func (t *Tracker) OnOrderCancelReject(m ordercancelreject.OrderCancelReject) error {
+	clOrdID, err := m.GetClOrdID()
+	if err != nil {
+		return err
+	}
+	origClOrdID, _ := m.GetOrigClOrdID()
+	orderID, _ := m.GetOrderID()
+	ordStatus, _ := m.GetOrdStatus()
+
+	t.mu.Lock()
+	defer t.unlock()
+	o := t.find(clOrdID, origClOrdID, orderID)
+	if o == nil {
+		return ErrUnknownOrder
+	}
+	if o.Pending != nil && o.Pending.ClOrdID == clOrdID {
+		o.Pending = nil
+	}
+	prev, wasPending := t.prevStatus[o]
+	delete(t.prevStatus, o)
+	switch {
+	case ordStatus != "" && ordStatus != enum.OrdStatus_REJECTED && validTransition(o.OrdStatus, ordStatus):
+		// OrdStatus is the current status of the order, except when it is unknown to the counterparty
+		o.OrdStatus = ordStatus
+	case wasPending:
+		o.OrdStatus = prev
+	}
+	o.Text, _ = m.GetText()
+	o.UpdatedAt, _ = m.GetTransactTime()
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = time.Now()
+	}
+	t.changed(o)
+	return nil
+}
+
+// advance moves the order to the ClOrdID of an accepted cancel or replace
+func (t *Tracker) advance(o *Order, clOrdID string) {
+	if o.Pending != nil && o.Pending.ClOrdID == clOrdID {
+		o.Pending = nil
+	}
+	delete(t.prevStatus, o)
+	if clOrdID == "" || clOrdID == o.ClOrdID {
+		return
+	}
+	o.ClOrdID = clOrdID
+	o.ClOrdIDs = append(o.ClOrdIDs, clOrdID)
+	t.byClOrdID[clOrdID] = o
+}
+
+// correctFill applies a trade cancel or correct to the fill with ExecID execRefID
+func (t *Tracker) correctFill(o *Order, execType enum.ExecType, execRefID string, lastQty, lastPx decimal.Decimal) {
+	update := func(fills []Fill) []Fill {
+		for i := range fills {
+			if fills[i].ExecID != execRefID {
+				continue
+			}
+			if execType == enum.ExecType_TRADE_CANCEL {
+				return append(fills[:i], fills[i+1:]...)
+			}
+			fills[i].LastQty, fills[i].LastPx = lastQty, lastPx
+		}
+		return fills
+	}
+	o.Fills = update(o.Fills)
+	t.fills = update(t.fills)
+}
+
+func (t *Tracker) find(clOrdID, origClOrdID, orderID string) *Order {
+	if o, ok := t.byClOrdID[clOrdID]; ok && clOrdID != "" {
+		return o
+	}
+	if o, ok := t.byClOrdID[origClOrdID]; ok && origClOrdID != "" {
+		return o
+	}
+	if o, ok := t.byOrderID[orderID]; ok && orderID != "" {
+		return o
+	}
+	return nil
+}
+
+// changed queues the call of OnChange with a copy of o, it must be called with mu held
+func (t *Tracker) changed(o *Order) {
+	if t.OnChange != nil {
+		c := o.clone()
+		t.callbacks = append(t.callbacks, func() { t.OnChange(c) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (t *Tracker) unlock() {
+	callbacks := t.callbacks
+	t.callbacks = nil
+	t.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// Order returns the order having clOrdID in its ClOrdID chain or as pending request
+func (t *Tracker) Order(clOrdID string) (Order, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o, ok := t.byClOrdID[clOrdID]
+	if !ok {
+		return Order{}, false
+	}
+	return o.clone(), true
+}
+
+// Orders returns all tracked orders, in the order they were sent
+func (t *Tracker) Orders() []Order {
+	return t.list(func(*Order) bool { return true })
+}
+
+// OpenOrders returns the orders that can still be filled, in the order they were sent
+func (t *Tracker) OpenOrders() []Order {
+	return t.list(func(o *Order) bool { return o.IsOpen() })
+}
+
+func (t *Tracker) list(keep func(*Order) bool) []Order {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var orders []Order
+	for _, o := range t.orders {
+		if keep(o) {
+			orders = append(orders, o.clone())
+		}
+	}
+	return orders
+}
+
+// Fills returns the fills of all orders, in the order they were received
+func (t *Tracker) Fills() []Fill {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Fill(nil), t.fills...)
+}
@@ -0,0 +1,207 @@
+package ordertracker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/executionreport"
+	"github.com/quickfixgo/fix44/newordersingle"
+	"github.com/quickfixgo/fix44/ordercancelreject"
+	"github.com/quickfixgo/fix44/ordercancelreplacerequest"
+	"github.com/shopspring/decimal"
+)
+
+func TestValidTransition(t *testing.T) {
+	tests := []struct {
+		from, to enum.OrdStatus
+		want     bool
+	}{
+		{enum.OrdStatus_PENDING_NEW, enum.OrdStatus_NEW, true},
+		{enum.OrdStatus_PENDING_NEW, enum.OrdStatus_REJECTED, true},
+		{enum.OrdStatus_NEW, enum.OrdStatus_PARTIALLY_FILLED, true},
+		{enum.OrdStatus_NEW, enum.OrdStatus_NEW, true},
+		{enum.OrdStatus_NEW, enum.OrdStatus_PENDING_NEW, false},
+		{enum.OrdStatus_NEW, enum.OrdStatus_REJECTED, false},
+		{enum.OrdStatus_PARTIALLY_FILLED, enum.OrdStatus_NEW, false},
+		{enum.OrdStatus_PARTIALLY_FILLED, enum.OrdStatus_PENDING_CANCEL, true},
+		{enum.OrdStatus_PENDING_CANCEL, enum.OrdStatus_CANCELED, true},
+		{enum.OrdStatus_PENDING_REPLACE, enum.OrdStatus_NEW, true},
+		{enum.OrdStatus_FILLED, enum.OrdStatus_PARTIALLY_FILLED, false},
+		{enum.OrdStatus_CANCELED, enum.OrdStatus_NEW, false},
+		{enum.OrdStatus_REJECTED, enum.OrdStatus_NEW, false},
+		{enum.OrdStatus_EXPIRED, enum.OrdStatus_FILLED, false},
+	}
+	for _, tt := range tests {
+		if got := validTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("validTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func report(clOrdID, origClOrdID, execID string, execType enum.ExecType, ordStatus enum.OrdStatus, cumQty, leavesQty int64) executionreport.ExecutionReport {
+	r := executionreport.New(field.NewOrderID("O1"), field.NewExecID(execID), field.NewExecType(execType), field.NewOrdStatus(ordStatus),
+		field.NewSide(enum.Side_BUY), field.NewLeavesQty(decimal.NewFromInt(leavesQty), 0),
+		field.NewCumQty(decimal.NewFromInt(cumQty), 0), field.NewAvgPx(decimal.NewFromInt(10), 0))
+	r.SetClOrdID(clOrdID)
+	if origClOrdID != "" {
+		r.SetOrigClOrdID(origClOrdID)
+	}
+	return r
+}
+
+// otherOrder sets the OrderID of r to one unknown to the Tracker
+func otherOrder(r executionreport.ExecutionReport) executionreport.ExecutionReport {
+	r.SetOrderID("O2")
+	return r
+}
+
+func newTracker(t *testing.T) *Tracker {
+	t.Helper()
+	tr := New()
+	nos := newordersingle.New(field.NewClOrdID("A1"), field.NewSide(enum.Side_BUY), field.NewTransactTime(time.Now()), field.NewOrdType(enum.OrdType_LIMIT))
+	nos.SetOrderQty(decimal.NewFromInt(100), 0)
+	nos.SetPrice(decimal.NewFromInt(10), 0)
+	if err := tr.Process(nos.ToMessage()); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.OnExecutionReport(report("A1", "", "E1", enum.ExecType_NEW, enum.OrdStatus_NEW, 0, 100)); err != nil {
+		t.Fatal(err)
+	}
+	return tr
+}
+
+func TestTrackerReports(t *testing.T) {
+	tests := []struct {
+		name    string
+		reports []executionreport.ExecutionReport
+		wantErr error
+		want    enum.OrdStatus
+	}{
+		{
+			name:    "duplicate ExecID",
+			reports: []executionreport.ExecutionReport{report("A1", "", "E1", enum.ExecType_NEW, enum.OrdStatus_NEW, 0, 100)},
+			wantErr: ErrDuplicateExecID,
+			want:    enum.OrdStatus_NEW,
+		},
+		{
+			name: "stale report",
+			reports: []executionreport.ExecutionReport{
+				report("A1", "", "E2", enum.ExecType_TRADE, enum.OrdStatus_PARTIALLY_FILLED, 40, 60),
+				report("A1", "", "E3", enum.ExecType_TRADE, enum.OrdStatus_PARTIALLY_FILLED, 30, 70),
+			},
+			wantErr: ErrStaleReport,
+			want:    enum.OrdStatus_PARTIALLY_FILLED,
+		},
+		{
+			name: "report after fill",
+			reports: []executionreport.ExecutionReport{
+				report("A1", "", "E2", enum.ExecType_TRADE, enum.OrdStatus_FILLED, 100, 0),
+				report("A1", "", "E3", enum.ExecType_NEW, enum.OrdStatus_NEW, 100, 0),
+			},
+			wantErr: &TransitionError{},
+			want:    enum.OrdStatus_FILLED,
+		},
+		{
+			name:    "unknown order",
+			reports: []executionreport.ExecutionReport{otherOrder(report("X1", "", "E2", enum.ExecType_NEW, enum.OrdStatus_NEW, 0, 100))},
+			wantErr: ErrUnknownOrder,
+			want:    enum.OrdStatus_NEW,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := newTracker(t)
+			var err error
+			for _, r := range tt.reports {
+				if err = tr.OnExecutionReport(r); err != nil {
+					break
+				}
+			}
+			var transitionErr *TransitionError
+			switch {
+			case errors.As(tt.wantErr, &transitionErr):
+				if !errors.As(err, &transitionErr) {
+					t.Errorf("got error %v, want a TransitionError", err)
+				}
+			case !errors.Is(err, tt.wantErr):
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if o, _ := tr.Order("A1"); o.OrdStatus != tt.want {
+				t.Errorf("got OrdStatus %s, want %s", o.OrdStatus, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrackerReplace(t *testing.T) {
+	tr := newTracker(t)
+	req := ordercancelreplacerequest.New(field.NewOrigClOrdID("A1"), field.NewClOrdID("A2"), field.NewSide(enum.Side_BUY),
+		field.NewTransactTime(time.Now()), field.NewOrdType(enum.OrdType_LIMIT))
+	req.SetOrderQty(decimal.NewFromInt(120), 0)
+	req.SetPrice(decimal.NewFromInt(11), 0)
+	if err := tr.Process(req.ToMessage()); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.OnExecutionReport(report("A2", "A1", "E2", enum.ExecType_REPLACED, enum.OrdStatus_NEW, 0, 120)); err != nil {
+		t.Fatal(err)
+	}
+	o, ok := tr.Order("A1")
+	if !ok {
+		t.Fatal("order not found by its first ClOrdID")
+	}
+	if o.ClOrdID != "A2" || len(o.ClOrdIDs) != 2 || o.Pending != nil {
+		t.Errorf("got ClOrdID %s, ClOrdIDs %v, Pending %v", o.ClOrdID, o.ClOrdIDs, o.Pending)
+	}
+	if !o.OrderQty.Equal(decimal.NewFromInt(120)) || !o.Price.Equal(decimal.NewFromInt(11)) {
+		t.Errorf("got OrderQty %s and Price %s, want 120 and 11", o.OrderQty, o.Price)
+	}
+}
+
+func TestTrackerCancelReject(t *testing.T) {
+	tr := newTracker(t)
+	req := ordercancelreplacerequest.New(field.NewOrigClOrdID("A1"), field.NewClOrdID("A2"), field.NewSide(enum.Side_BUY),
+		field.NewTransactTime(time.Now()), field.NewOrdType(enum.OrdType_LIMIT))
+	req.SetOrderQty(decimal.NewFromInt(120), 0)
+	if err := tr.Process(req.ToMessage()); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.OnExecutionReport(report("A2", "A1", "E2", enum.ExecType_PENDING_REPLACE, enum.OrdStatus_PENDING_REPLACE, 0, 100)); err != nil {
+		t.Fatal(err)
+	}
+	rej := ordercancelreject.New(field.NewOrderID("O1"), field.NewClOrdID("A2"), field.NewOrigClOrdID("A1"),
+		field.NewOrdStatus(enum.OrdStatus_REJECTED), field.NewCxlRejResponseTo(enum.CxlRejResponseTo_ORDER_CANCEL_REPLACE_REQUEST))
+	if err := tr.Process(rej.ToMessage()); err != nil {
+		t.Fatal(err)
+	}
+	o, _ := tr.Order("A1")
+	if o.Pending != nil || o.OrdStatus != enum.OrdStatus_NEW || o.ClOrdID != "A1" {
+		t.Errorf("got Pending %v, OrdStatus %s, ClOrdID %s, want the order back to New", o.Pending, o.OrdStatus, o.ClOrdID)
+	}
+}
+
+func TestTrackerOnChangeCanCallTracker(t *testing.T) {
+	tr := newTracker(t)
+	var seen []enum.OrdStatus
+	tr.OnChange = func(o Order) {
+		cur, _ := tr.Order(o.ClOrdID)
+		seen = append(seen, cur.OrdStatus)
+	}
+	done := make(chan error)
+	go func() {
+		done <- tr.OnExecutionReport(report("A1", "", "E2", enum.ExecType_TRADE, enum.OrdStatus_PARTIALLY_FILLED, 40, 60))
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnChange calling the Tracker deadlocked")
+	}
+	if len(seen) != 1 || seen[0] != enum.OrdStatus_PARTIALLY_FILLED {
+		t.Errorf("got %v", seen)
+	}
+}
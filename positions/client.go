@@ -0,0 +1,420 @@
+package positions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/assignmentreport"
+	"github.com/quickfixgo/fix44/positionmaintenancereport"
+	"github.com/quickfixgo/fix44/positionmaintenancerequest"
+	"github.com/quickfixgo/fix44/positionreport"
+	"github.com/quickfixgo/fix44/requestforpositions"
+	"github.com/quickfixgo/fix44/requestforpositionsack"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+// ErrUnknownPosReqID is returned for a PositionMaintenanceReport of a request not sent by the Client
+var ErrUnknownPosReqID = errors.New("positions: unknown PosReqID")
+
+// RequestError is a RequestForPositionsAck or a PositionReport rejecting a request
+type RequestError struct {
+	PosReqID string
+	Result   enum.PosReqResult
+	Text     string
+}
+
+func (e *RequestError) Error() string {
+	s := fmt.Sprintf("positions: request %s rejected with PosReqResult %s", e.PosReqID, e.Result)
+	if e.Text != "" {
+		s += ": " + e.Text
+	}
+	return s
+}
+
+// MaintState is the state of a position maintenance request
+type MaintState int
+
+const (
+	// MaintPending requests were sent without report yet
+	MaintPending MaintState = iota
+	MaintAccepted
+	MaintRejected
+	MaintCompleted
+)
+
+func (s MaintState) String() string {
+	switch s {
+	case MaintPending:
+		return "pending"
+	case MaintAccepted:
+		return "accepted"
+	case MaintRejected:
+		return "rejected"
+	case MaintCompleted:
+		return "completed"
+	}
+	return fmt.Sprintf("MaintState(%d)", int(s))
+}
+
+// Maintenance is an exercise or abandon request sent by the Client
+type Maintenance struct {
+	PosReqID             string
+	TransType            enum.PosTransType
+	Account              string
+	Symbol               string
+	Qty                  decimal.Decimal
+	ClearingBusinessDate string
+
+	State         MaintState
+	PosMaintRptID string
+	Status        enum.PosMaintStatus
+	Result        enum.PosMaintResult
+	Text          string
+	UpdatedAt     time.Time
+}
+
+// Assignment is an AssignmentReport of an account, its quantities summed by PosType
+type Assignment struct {
+	AsgnRptID            string
+	Account              string
+	Symbol               string
+	ClearingBusinessDate string
+	Qty                  map[enum.PosType]Qty
+}
+
+type subscription struct {
+	seq                  int
+	account              string
+	clearingBusinessDate string
+}
+
+// Client subscribes to PositionReports into a Ledger and sends
+// exercise and abandon PositionMaintenanceRequests.
+// A Client is safe for concurrent use.
+type Client struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// AccountType is set on the requests, NewClient sets it to customer side of the books
+	AccountType enum.AccountType
+	// Ledger holds the reported positions
+	Ledger *Ledger
+	// OnPosition, if set, is called with each reported position
+	OnPosition func(Position)
+	// OnReject, if set, is called when a position request is rejected
+	OnReject func(*RequestError)
+	// OnMaintenance, if set, is called with a copy of a maintenance request each time it changes
+	OnMaintenance func(Maintenance)
+	// OnAssignment, if set, is called with each AssignmentReport.
+	// The callbacks are called once the Client is unlocked, so they can call the Client.
+	OnAssignment func(Assignment)
+
+	mu            sync.Mutex
+	nextID        int
+	idPrefix      string
+	subscriptions map[string]subscription
+	maintenances  map[string]*Maintenance
+	// maintOrder are the PosReqIDs of the maintenance requests in sending order
+	maintOrder []string
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// NewClient returns a Client for the session
+func NewClient(sessionID quickfix.SessionID) *Client {
+	return &Client{
+		SessionID:     sessionID,
+		Send:          quickfix.SendToTarget,
+		AccountType:   enum.AccountType_ACCOUNT_IS_CARRIED_ON_CUSTOMER_SIDE_OF_THE_BOOKS,
+		Ledger:        NewLedger(),
+		idPrefix:      time.Now().Format("150405") + "-",
+		subscriptions: make(map[string]subscription),
+		maintenances:  make(map[string]*Maintenance),
+	}
+}
+
+// newID returns the next PosReqID, it must be called with mu held
+func (c *Client) newID() string {
+	c.nextID++
+	return fmt.Sprintf("%s%d", c.idPrefix, c.nextID)
+}
+
+// Subscribe asks for the positions of an account on a clearing business date (YYYYMMDD)
+// and their updates, it returns the PosReqID
+func (c *Client) Subscribe(account string, clearingBusinessDate string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id := c.newID()
+	req := requestforpositions.New(field.NewPosReqID(id), field.NewPosReqType(enum.PosReqType_POSITIONS),
+		field.NewAccount(account), field.NewAccountType(c.AccountType),
+		field.NewClearingBusinessDate(clearingBusinessDate), field.NewTransactTime(time.Now()))
+	req.SetSubscriptionRequestType(enum.SubscriptionRequestType_SNAPSHOT_PLUS_UPDATES)
+	c.subscriptions[id] = subscription{seq: c.nextID, account: account, clearingBusinessDate: clearingBusinessDate}
+	if err := c.Send(req, c.SessionID); err != nil {
+		delete(c.subscriptions, id)
+		return "", err
+	}
+	return id, nil
+}
+
+// Unsubscribe stops the updates of a subscription
+func (c *Client) Unsubscribe(posReqID string) error {
+	c.mu.Lock()
+	sub, ok := c.subscriptions[posReqID]
+	delete(c.subscriptions, posReqID)
+	c.mu.Unlock()
+	if !ok {
+		return ErrUnknownPosReqID
+	}
+	req := requestforpositions.New(field.NewPosReqID(posReqID), field.NewPosReqType(enum.PosReqType_POSITIONS),
+		field.NewAccount(sub.account), field.NewAccountType(c.AccountType),
+		field.NewClearingBusinessDate(sub.clearingBusinessDate), field.NewTransactTime(time.Now()))
+	req.SetSubscriptionRequestType(enum.SubscriptionRequestType_DISABLE_PREVIOUS_SNAPSHOT_PLUS_UPDATE_REQUEST)
+	return c.Send(req, c.SessionID)
+}
+
+// Subscriptions returns the PosReqIDs of the active subscriptions in creation order
+func (c *Client) Subscriptions() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]string, 0, len(c.subscriptions))
+	for id := range c.subscriptions {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return c.subscriptions[ids[i]].seq < c.subscriptions[ids[j]].seq })
+	return ids
+}
+
+// Exercise asks to exercise qty of the long position of an account, it returns the PosReqID
+func (c *Client) Exercise(account string, symbol string, qty decimal.Decimal, clearingBusinessDate string) (string, error) {
+	return c.maintain(&Maintenance{TransType: enum.PosTransType_EXERCISE, Account: account, Symbol: symbol,
+		Qty: qty, ClearingBusinessDate: clearingBusinessDate})
+}
+
+// Abandon asks not to exercise qty of the long position of an account, it returns the PosReqID
+func (c *Client) Abandon(account string, symbol string, qty decimal.Decimal, clearingBusinessDate string) (string, error) {
+	return c.maintain(&Maintenance{TransType: enum.PosTransType_DO_NOT_EXERCISE, Account: account, Symbol: symbol,
+		Qty: qty, ClearingBusinessDate: clearingBusinessDate})
+}
+
+func (c *Client) maintain(m *Maintenance) (string, error) {
+	c.mu.Lock()
+	m.PosReqID = c.newID()
+	m.UpdatedAt = time.Now()
+	req := positionmaintenancerequest.New(field.NewPosReqID(m.PosReqID), field.NewPosTransType(m.TransType),
+		field.NewPosMaintAction(enum.PosMaintAction_NEW), field.NewClearingBusinessDate(m.ClearingBusinessDate),
+		field.NewAccount(m.Account), field.NewAccountType(c.AccountType), field.NewTransactTime(m.UpdatedAt))
+	req.SetSymbol(m.Symbol)
+	positions := positionmaintenancerequest.NewNoPositionsRepeatingGroup()
+	e := positions.Add()
+	e.SetPosType(enum.PosType_OPTION_EXERCISE_QTY)
+	e.SetLongQty(m.Qty, scale(m.Qty))
+	req.SetNoPositions(positions)
+	c.maintenances[m.PosReqID] = m
+	if err := c.Send(req, c.SessionID); err != nil {
+		delete(c.maintenances, m.PosReqID)
+		c.mu.Unlock()
+		return "", err
+	}
+	c.maintOrder = append(c.maintOrder, m.PosReqID)
+	c.changed(m)
+	c.unlock()
+	return m.PosReqID, nil
+}
+
+// Process handles a RequestForPositionsAck, a PositionReport, a PositionMaintenanceReport
+// or an AssignmentReport, other messages are ignored. It is meant to be called from FromApp.
+func (c *Client) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "AO":
+		return c.OnRequestForPositionsAck(requestforpositionsack.FromMessage(msg))
+	case "AP":
+		return c.OnPositionReport(positionreport.FromMessage(msg))
+	case "AM":
+		return c.OnPositionMaintenanceReport(positionmaintenancereport.FromMessage(msg))
+	case "AW":
+		return c.OnAssignmentReport(assignmentreport.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnRequestForPositionsAck ends a subscription rejected by the counterparty
+func (c *Client) OnRequestForPositionsAck(a requestforpositionsack.RequestForPositionsAck) error {
+	id, err := a.GetPosReqID()
+	if err != nil {
+		return err
+	}
+	result, _ := a.GetPosReqResult()
+	status, _ := a.GetPosReqStatus()
+	if result == enum.PosReqResult_VALID_REQUEST && status != enum.PosReqStatus_REJECTED {
+		return nil
+	}
+	text, _ := a.GetText()
+	c.reject(&RequestError{PosReqID: id, Result: result, Text: text})
+	return nil
+}
+
+func (c *Client) reject(e *RequestError) {
+	c.mu.Lock()
+	delete(c.subscriptions, e.PosReqID)
+	c.mu.Unlock()
+	if c.OnReject != nil {
+		c.OnReject(e)
+	}
+}
+
+// OnPositionReport applies a position to the Ledger, a report with an invalid
+// PosReqResult rejects its request instead
+func (c *Client) OnPositionReport(r positionreport.PositionReport) error {
+	result, rerr := r.GetPosReqResult()
+	if rerr != nil {
+		return rerr
+	}
+	if result != enum.PosReqResult_VALID_REQUEST {
+		e := &RequestError{Result: result}
+		e.PosReqID, _ = r.GetPosReqID()
+		e.Text, _ = r.GetText()
+		c.reject(e)
+		return nil
+	}
+	p, err := FromReport(r)
+	if err != nil {
+		return err
+	}
+	c.Ledger.Apply(p)
+	if c.OnPosition != nil {
+		c.OnPosition(p)
+	}
+	return nil
+}
+
+// OnPositionMaintenanceReport applies the PosMaintStatus of a report to its maintenance request
+func (c *Client) OnPositionMaintenanceReport(r positionmaintenancereport.PositionMaintenanceReport) error {
+	status, err := r.GetPosMaintStatus()
+	if err != nil {
+		return err
+	}
+	id, _ := r.GetPosReqID()
+
+	c.mu.Lock()
+	defer c.unlock()
+	m, ok := c.maintenances[id]
+	if !ok {
+		id, _ = r.GetOrigPosReqRefID()
+		if m, ok = c.maintenances[id]; !ok {
+			return ErrUnknownPosReqID
+		}
+	}
+	m.Status = status
+	m.PosMaintRptID, _ = r.GetPosMaintRptID()
+	m.Result, _ = r.GetPosMaintResult()
+	m.Text, _ = r.GetText()
+	m.UpdatedAt = time.Now()
+	switch status {
+	case enum.PosMaintStatus_ACCEPTED, enum.PosMaintStatus_ACCEPTED_WITH_WARNINGS:
+		m.State = MaintAccepted
+	case enum.PosMaintStatus_COMPLETED, enum.PosMaintStatus_COMPLETED_WITH_WARNINGS:
+		m.State = MaintCompleted
+	case enum.PosMaintStatus_REJECTED:
+		m.State = MaintRejected
+	}
+	c.changed(m)
+	return nil
+}
+
+// changed queues the call of OnMaintenance with a copy of m, it must be called with mu held
+func (c *Client) changed(m *Maintenance) {
+	if c.OnMaintenance != nil {
+		cp := *m
+		c.callbacks = append(c.callbacks, func() { c.OnMaintenance(cp) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (c *Client) unlock() {
+	callbacks := c.callbacks
+	c.callbacks = nil
+	c.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// Maintenance returns a copy of the maintenance request with the PosReqID
+func (c *Client) Maintenance(posReqID string) (Maintenance, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m, ok := c.maintenances[posReqID]
+	if !ok {
+		return Maintenance{}, false
+	}
+	return *m, true
+}
+
+// Maintenances returns copies of all the maintenance requests in sending order
+func (c *Client) Maintenances() []Maintenance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ms := make([]Maintenance, 0, len(c.maintOrder))
+	for _, id := range c.maintOrder {
+		ms = append(ms, *c.maintenances[id])
+	}
+	return ms
+}
+
+// OnAssignmentReport passes an AssignmentReport to OnAssignment
+func (c *Client) OnAssignmentReport(r assignmentreport.AssignmentReport) error {
+	var a Assignment
+	var err error
+	if a.AsgnRptID, err = r.GetAsgnRptID(); err != nil {
+		return err
+	}
+	a.Account, _ = r.GetAccount()
+	a.Symbol, _ = r.GetSymbol()
+	if a.Symbol == "" {
+		a.Symbol, _ = r.GetSecurityID()
+	}
+	a.ClearingBusinessDate, _ = r.GetClearingBusinessDate()
+	a.Qty = make(map[enum.PosType]Qty)
+	if g, err := r.GetNoPositions(); err == nil {
+		for _, e := range g.All() {
+			posType, err := e.GetPosType()
+			if err != nil {
+				continue
+			}
+			q := a.Qty[posType]
+			if long, err := e.GetLongQty(); err == nil {
+				q.Long = q.Long.Add(long)
+			}
+			if short, err := e.GetShortQty(); err == nil {
+				q.Short = q.Short.Add(short)
+			}
+			a.Qty[posType] = q
+		}
+	}
+	if c.OnAssignment != nil {
+		c.OnAssignment(a)
+	}
+	return nil
+}
+
+// scale returns the number of decimal places of v without its trailing zeros,
+// so that a quantity is sent unrounded
+func scale(v decimal.Decimal) int32 {
+	var s int32
+	for !v.Truncate(s).Equal(v) {
+		s++
+	}
+	return s
+}
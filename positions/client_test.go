@@ -0,0 +1,197 @@
+package positions
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/positionmaintenancereport"
+	"github.com/quickfixgo/fix44/positionmaintenancerequest"
+	"github.com/quickfixgo/fix44/positionreport"
+	"github.com/quickfixgo/fix44/requestforpositionsack"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var session = quickfix.SessionID{SenderCompID: "FIRM", TargetCompID: "CCP"}
+
+// clearing returns a Client whose sends are appended to sent
+func clearing(sent *[]*quickfix.Message) *Client {
+	c := NewClient(session)
+	c.idPrefix = "p"
+	c.Send = func(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+		*sent = append(*sent, m.ToMessage())
+		return nil
+	}
+	return c
+}
+
+func maintReport(posReqID string, status enum.PosMaintStatus) positionmaintenancereport.PositionMaintenanceReport {
+	r := positionmaintenancereport.New(field.NewPosMaintRptID("r-"+posReqID), field.NewPosTransType(enum.PosTransType_EXERCISE),
+		field.NewPosMaintAction(enum.PosMaintAction_NEW), field.NewOrigPosReqRefID(posReqID), field.NewPosMaintStatus(status),
+		field.NewClearingBusinessDate("20261019"), field.NewAccount("ACC1"),
+		field.NewAccountType(enum.AccountType_ACCOUNT_IS_CARRIED_ON_CUSTOMER_SIDE_OF_THE_BOOKS), field.NewTransactTime(time.Now()))
+	r.SetPosReqID(posReqID)
+	return r
+}
+
+func TestSubscriptions(t *testing.T) {
+	var sent []*quickfix.Message
+	c := clearing(&sent)
+	var want []string
+	for i := 0; i < 10; i++ {
+		id, err := c.Subscribe("ACC1", "20261019")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, id)
+	}
+	if got := c.Subscriptions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if len(sent) != 10 {
+		t.Errorf("got %d requests sent, want 10", len(sent))
+	}
+
+	ack := requestforpositionsack.New(field.NewPosMaintRptID("a1"),
+		field.NewPosReqResult(enum.PosReqResult_INVALID_OR_UNSUPPORTED_REQUEST), field.NewPosReqStatus(enum.PosReqStatus_REJECTED),
+		field.NewAccount("ACC1"), field.NewAccountType(enum.AccountType_ACCOUNT_IS_CARRIED_ON_CUSTOMER_SIDE_OF_THE_BOOKS))
+	ack.SetPosReqID(want[1])
+	var rejected []string
+	c.OnReject = func(e *RequestError) { rejected = append(rejected, e.PosReqID) }
+	if err := c.Process(ack.ToMessage()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rejected, want[1:2]) {
+		t.Errorf("got %v rejected, want %v", rejected, want[1:2])
+	}
+	if err := c.Unsubscribe(want[0]); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Subscriptions(); !reflect.DeepEqual(got, want[2:]) {
+		t.Errorf("got %v, want %v", got, want[2:])
+	}
+	if err := c.Unsubscribe(want[0]); err != ErrUnknownPosReqID {
+		t.Errorf("got %v, want %v", err, ErrUnknownPosReqID)
+	}
+}
+
+func TestSubscribeSendFails(t *testing.T) {
+	c := NewClient(session)
+	errDown := errors.New("session down")
+	c.Send = func(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+		if len(c.subscriptions) != 1 {
+			t.Errorf("got %d subscriptions while sending, want 1", len(c.subscriptions))
+		}
+		return errDown
+	}
+	if _, err := c.Subscribe("ACC1", "20261019"); err != errDown {
+		t.Errorf("got %v, want %v", err, errDown)
+	}
+	if got := c.Subscriptions(); len(got) != 0 {
+		t.Errorf("got %v, want no subscription", got)
+	}
+}
+
+func TestExercise(t *testing.T) {
+	tests := []struct {
+		name   string
+		qty    string
+		status []enum.PosMaintStatus
+		want   MaintState
+	}{
+		{"pending", "10", nil, MaintPending},
+		{"accepted", "2.5", []enum.PosMaintStatus{enum.PosMaintStatus_ACCEPTED}, MaintAccepted},
+		{"completed", "0.125", []enum.PosMaintStatus{enum.PosMaintStatus_ACCEPTED, enum.PosMaintStatus_COMPLETED}, MaintCompleted},
+		{"rejected", "1", []enum.PosMaintStatus{enum.PosMaintStatus_REJECTED}, MaintRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []*quickfix.Message
+			c := clearing(&sent)
+			var changes []MaintState
+			c.OnMaintenance = func(m Maintenance) { changes = append(changes, m.State) }
+			id, err := c.Exercise("ACC1", "VN30F1M", decimal.RequireFromString(tt.qty), "20261019")
+			if err != nil {
+				t.Fatal(err)
+			}
+			g, err := positionmaintenancerequest.FromMessage(sent[0]).GetNoPositions()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if qty, _ := g.Get(0).GetString(tag.LongQty); qty != tt.qty {
+				t.Errorf("got LongQty %s sent, want %s", qty, tt.qty)
+			}
+			for _, s := range tt.status {
+				if err := c.Process(maintReport(id, s).ToMessage()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			m, ok := c.Maintenance(id)
+			if !ok || m.State != tt.want {
+				t.Errorf("got %v %v, want %v", m.State, ok, tt.want)
+			}
+			if len(changes) != len(tt.status)+1 {
+				t.Errorf("got %d changes, want %d", len(changes), len(tt.status)+1)
+			}
+		})
+	}
+}
+
+func TestMaintenances(t *testing.T) {
+	var sent []*quickfix.Message
+	c := clearing(&sent)
+	var want []string
+	for i := 0; i < 10; i++ {
+		id, err := c.Abandon("ACC1", "VN30F1M", decimal.NewFromInt(1), "20261019")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, id)
+	}
+	var got []string
+	for _, m := range c.Maintenances() {
+		got = append(got, m.PosReqID)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := c.Process(maintReport("unknown", enum.PosMaintStatus_ACCEPTED).ToMessage()); err != ErrUnknownPosReqID {
+		t.Errorf("got %v, want %v", err, ErrUnknownPosReqID)
+	}
+}
+
+func TestPositionReport(t *testing.T) {
+	c := NewClient(session)
+	var reported []Position
+	c.OnPosition = func(p Position) { reported = append(reported, p) }
+	r := positionreport.New(field.NewPosMaintRptID("pr1"), field.NewPosReqResult(enum.PosReqResult_VALID_REQUEST),
+		field.NewClearingBusinessDate("20261019"), field.NewAccount("ACC1"),
+		field.NewAccountType(enum.AccountType_ACCOUNT_IS_CARRIED_ON_CUSTOMER_SIDE_OF_THE_BOOKS),
+		field.NewSettlPrice(decimal.RequireFromString("1250.5"), 1), field.NewSettlPriceType(enum.SettlPriceType_FINAL),
+		field.NewPriorSettlPrice(decimal.RequireFromString("1248"), 0))
+	r.SetSymbol("VN30F1M")
+	g := positionreport.NewNoPositionsRepeatingGroup()
+	e := g.Add()
+	e.SetPosType(enum.PosType_OPTION_EXERCISE_QTY)
+	e.SetLongQty(decimal.NewFromInt(5), 0)
+	e.SetShortQty(decimal.NewFromInt(2), 0)
+	r.SetNoPositions(g)
+	if err := c.Process(r.ToMessage()); err != nil {
+		t.Fatal(err)
+	}
+	p, ok := c.Ledger.Position("ACC1", "VN30F1M")
+	if !ok {
+		t.Fatal("position not in the ledger")
+	}
+	if net := p.Net(enum.PosType_OPTION_EXERCISE_QTY); !net.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("got net %v, want 3", net)
+	}
+	if len(reported) != 1 {
+		t.Errorf("got %d positions reported, want 1", len(reported))
+	}
+}
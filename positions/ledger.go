@@ -0,0 +1,178 @@
+package positions
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/positionreport"
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoInstrument is returned for a PositionReport without Symbol nor SecurityID
+var ErrNoInstrument = errors.New("positions: report without Symbol nor SecurityID")
+
+// Qty is the long and short quantities of a PosType
+type Qty struct {
+	Long  decimal.Decimal
+	Short decimal.Decimal
+}
+
+// Net returns Long - Short
+func (q Qty) Net() decimal.Decimal {
+	return q.Long.Sub(q.Short)
+}
+
+// Key identifies a position, Symbol is the SecurityID for reports without Symbol
+type Key struct {
+	Account string
+	Symbol  string
+}
+
+// Position is the last reported position of an account in an instrument,
+// NoPositions entries are summed by PosType and NoPosAmt entries by PosAmtType
+type Position struct {
+	Key
+	PosMaintRptID        string
+	PosReqID             string
+	ClearingBusinessDate string
+	SettlPrice           decimal.Decimal
+	PriorSettlPrice      decimal.Decimal
+	Qty                  map[enum.PosType]Qty
+	Amt                  map[enum.PosAmtType]decimal.Decimal
+	UpdatedAt            time.Time
+}
+
+// Net returns the net quantity of a PosType, zero if it was not reported
+func (p Position) Net(posType enum.PosType) decimal.Decimal {
+	q, ok := p.Qty[posType]
+	if !ok {
+		return decimal.Zero
+	}
+	return q.Net()
+}
+
+func (p *Position) clone() Position {
+	c := *p
+	c.Qty = make(map[enum.PosType]Qty, len(p.Qty))
+	for k, v := range p.Qty {
+		c.Qty[k] = v
+	}
+	c.Amt = make(map[enum.PosAmtType]decimal.Decimal, len(p.Amt))
+	for k, v := range p.Amt {
+		c.Amt[k] = v
+	}
+	return c
+}
+
+// FromReport returns the position of a PositionReport
+func FromReport(r positionreport.PositionReport) (Position, error) {
+	var p Position
+	var err error
+	if p.Account, err = r.GetAccount(); err != nil {
+		return p, err
+	}
+	p.Symbol, _ = r.GetSymbol()
+	if p.Symbol == "" {
+		p.Symbol, _ = r.GetSecurityID()
+	}
+	if p.Symbol == "" {
+		return p, ErrNoInstrument
+	}
+	p.PosMaintRptID, _ = r.GetPosMaintRptID()
+	p.PosReqID, _ = r.GetPosReqID()
+	p.ClearingBusinessDate, _ = r.GetClearingBusinessDate()
+	p.SettlPrice, _ = r.GetSettlPrice()
+	p.PriorSettlPrice, _ = r.GetPriorSettlPrice()
+	p.Qty = make(map[enum.PosType]Qty)
+	if g, err := r.GetNoPositions(); err == nil {
+		for _, e := range g.All() {
+			posType, err := e.GetPosType()
+			if err != nil {
+				continue
+			}
+			q := p.Qty[posType]
+			if long, err := e.GetLongQty(); err == nil {
+				q.Long = q.Long.Add(long)
+			}
+			if short, err := e.GetShortQty(); err == nil {
+				q.Short = q.Short.Add(short)
+			}
+			p.Qty[posType] = q
+		}
+	}
+	p.Amt = make(map[enum.PosAmtType]decimal.Decimal)
+	if g, err := r.GetNoPosAmt(); err == nil {
+		for _, e := range g.All() {
+			amtType, err := e.GetPosAmtType()
+			if err != nil {
+				continue
+			}
+			amt, _ := e.GetPosAmt()
+			p.Amt[amtType] = p.Amt[amtType].Add(amt)
+		}
+	}
+	p.UpdatedAt = time.Now()
+	return p, nil
+}
+
+// Ledger holds the last position of each account and instrument.
+// A Ledger is safe for concurrent use.
+type Ledger struct {
+	mu        sync.Mutex
+	positions map[Key]*Position
+}
+
+// NewLedger returns an empty Ledger
+func NewLedger() *Ledger {
+	return &Ledger{positions: make(map[Key]*Position)}
+}
+
+// Apply replaces the position of p.Key by p
+func (l *Ledger) Apply(p Position) {
+	c := p.clone()
+	l.mu.Lock()
+	l.positions[p.Key] = &c
+	l.mu.Unlock()
+}
+
+// Position returns a copy of the position of an account in an instrument
+func (l *Ledger) Position(account string, symbol string) (Position, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	p, ok := l.positions[Key{Account: account, Symbol: symbol}]
+	if !ok {
+		return Position{}, false
+	}
+	return p.clone(), true
+}
+
+// Account returns copies of the positions of an account, sorted by Symbol
+func (l *Ledger) Account(account string) []Position {
+	var positions []Position
+	for _, p := range l.Positions() {
+		if p.Account == account {
+			positions = append(positions, p)
+		}
+	}
+	return positions
+}
+
+// Positions returns copies of all the positions, sorted by Account then Symbol
+func (l *Ledger) Positions() []Position {
+	l.mu.Lock()
+	positions := make([]Position, 0, len(l.positions))
+	for _, p := range l.positions {
+		positions = append(positions, p.clone())
+	}
+	l.mu.Unlock()
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Account != positions[j].Account {
+			return positions[i].Account < positions[j].Account
+		}
+		return positions[i].Symbol < positions[j].Symbol
+	})
+	return positions
+}
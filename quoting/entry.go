@@ -0,0 +1,60 @@
+package quoting
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+// Entry is the two-sided quote of an instrument, a zero price or size is left out
+type Entry struct {
+	Symbol    string
+	BidPx     decimal.Decimal
+	OfferPx   decimal.Decimal
+	BidSize   decimal.Decimal
+	OfferSize decimal.Decimal
+	// ValidUntil, if set, is the ValidUntilTime of the entry
+	ValidUntil time.Time
+}
+
+// State is the state of a quote entry
+type State int
+
+const (
+	// Pending entries were sent without acknowledgement yet
+	Pending State = iota
+	Accepted
+	Rejected
+	Canceled
+)
+
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Accepted:
+		return "accepted"
+	case Rejected:
+		return "rejected"
+	case Canceled:
+		return "canceled"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// QuoteEntry is the last quote sent for an instrument
+type QuoteEntry struct {
+	Entry
+	// QuoteEntryID is assigned on the first quote of the instrument and kept by its updates
+	QuoteEntryID string
+	// QuoteID and QuoteSetID are those of the last MassQuote of the entry
+	QuoteID    string
+	QuoteSetID string
+
+	State        State
+	RejectReason enum.QuoteEntryRejectReason
+	Text         string
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,388 @@
+package quoting
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/massquote"
+	"github.com/quickfixgo/fix44/massquoteacknowledgement"
+	"github.com/quickfixgo/fix44/quotecancel"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrNoEntries is returned when quoting or canceling nothing
+	ErrNoEntries = errors.New("quoting: no quote entries")
+	// ErrUnknownSymbol is returned when canceling the quote of an instrument never quoted
+	ErrUnknownSymbol = errors.New("quoting: unknown symbol")
+	// ErrInvalidLimits is returned when quoting with a MaxEntriesPerSet or a MaxSetsPerMessage below 1
+	ErrInvalidLimits = errors.New("quoting: MaxEntriesPerSet and MaxSetsPerMessage must be positive")
+)
+
+// batch is a MassQuote or a QuoteCancel waiting for its acknowledgement
+type batch struct {
+	symbols []string
+	cancel  bool
+}
+
+// Manager streams quote entries in MassQuotes and tracks their acknowledgements.
+// A Manager is safe for concurrent use.
+type Manager struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// MaxEntriesPerSet and MaxSetsPerMessage limit the size of the MassQuotes, they must be positive,
+	// New sets them to 100 and 10
+	MaxEntriesPerSet  int
+	MaxSetsPerMessage int
+	// ResponseLevel is the QuoteResponseLevel of the MassQuotes, New sets it to acknowledge
+	// each message. With no acknowledgement the entries are accepted once sent.
+	ResponseLevel enum.QuoteResponseLevel
+	// OnEntry, if set, is called with a copy of an entry each time it changes.
+	// It is called once the Manager is unlocked, so it can call the Manager.
+	OnEntry func(QuoteEntry)
+
+	mu       sync.Mutex
+	nextID   int
+	idPrefix string
+	entries  map[string]*QuoteEntry
+	// byID maps the QuoteEntryIDs to their symbol
+	byID    map[string]string
+	batches map[string]*batch
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns a Manager for the session
+func New(sessionID quickfix.SessionID) *Manager {
+	return &Manager{
+		SessionID:         sessionID,
+		Send:              quickfix.SendToTarget,
+		MaxEntriesPerSet:  100,
+		MaxSetsPerMessage: 10,
+		ResponseLevel:     enum.QuoteResponseLevel_ACKNOWLEDGE_EACH_QUOTE_MESSAGE,
+		idPrefix:          time.Now().Format("150405") + "-",
+		entries:           make(map[string]*QuoteEntry),
+		byID:              make(map[string]string),
+		batches:           make(map[string]*batch),
+	}
+}
+
+// newID must be called with mu held
+func (m *Manager) newID() string {
+	m.nextID++
+	return fmt.Sprintf("%s%d", m.idPrefix, m.nextID)
+}
+
+func (m *Manager) acked() bool {
+	return m.ResponseLevel != enum.QuoteResponseLevel_NO_ACKNOWLEDGEMENT
+}
+
+// Quote sends the entries in as many MassQuotes as the size limits need,
+// the last entry of a symbol wins. It returns the QuoteIDs.
+func (m *Manager) Quote(entries []Entry) ([]string, error) {
+	var symbols []string
+	latest := make(map[string]Entry, len(entries))
+	for _, e := range entries {
+		if _, ok := latest[e.Symbol]; !ok {
+			symbols = append(symbols, e.Symbol)
+		}
+		latest[e.Symbol] = e
+	}
+	if len(symbols) == 0 {
+		return nil, ErrNoEntries
+	}
+	if m.MaxEntriesPerSet < 1 || m.MaxSetsPerMessage < 1 {
+		return nil, ErrInvalidLimits
+	}
+	perMessage := m.MaxEntriesPerSet * m.MaxSetsPerMessage
+
+	m.mu.Lock()
+	defer m.unlock()
+	var quoteIDs []string
+	for len(symbols) > 0 {
+		n := perMessage
+		if n > len(symbols) {
+			n = len(symbols)
+		}
+		quoteID, err := m.sendQuote(symbols[:n], latest)
+		if err != nil {
+			return quoteIDs, err
+		}
+		quoteIDs = append(quoteIDs, quoteID)
+		symbols = symbols[n:]
+	}
+	return quoteIDs, nil
+}
+
+// sendQuote must be called with mu held. The message is built from copies of the entries,
+// which replace the tracked ones only once it is sent.
+func (m *Manager) sendQuote(symbols []string, latest map[string]Entry) (string, error) {
+	quoteID := m.newID()
+	msg := massquote.New(field.NewQuoteID(quoteID))
+	msg.SetQuoteResponseLevel(m.ResponseLevel)
+	sets := massquote.NewNoQuoteSetsRepeatingGroup()
+	now := time.Now()
+	updated := make([]QuoteEntry, 0, len(symbols))
+	for i := 0; i < len(symbols); i += m.MaxEntriesPerSet {
+		end := i + m.MaxEntriesPerSet
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+		setID := strconv.Itoa(sets.Len() + 1)
+		set := sets.Add()
+		set.SetQuoteSetID(setID)
+		set.SetTotNoQuoteEntries(end - i)
+		quoteEntries := massquote.NewNoQuoteEntriesRepeatingGroup()
+		for _, symbol := range symbols[i:end] {
+			var qe QuoteEntry
+			if cur, ok := m.entries[symbol]; ok {
+				qe = *cur
+			} else {
+				qe.QuoteEntryID = m.newID()
+			}
+			qe.Entry = latest[symbol]
+			qe.QuoteID, qe.QuoteSetID = quoteID, setID
+			qe.State, qe.RejectReason, qe.Text, qe.UpdatedAt = Pending, "", "", now
+			if !m.acked() {
+				qe.State = Accepted
+			}
+			setEntry(quoteEntries.Add(), &qe)
+			updated = append(updated, qe)
+		}
+		set.SetNoQuoteEntries(quoteEntries)
+	}
+	msg.SetNoQuoteSets(sets)
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+	if m.acked() {
+		m.batches[quoteID] = &batch{symbols: symbols}
+	}
+	for k, symbol := range symbols {
+		qe := updated[k]
+		m.entries[symbol] = &qe
+		m.byID[qe.QuoteEntryID] = symbol
+		m.changed(qe)
+	}
+	return quoteID, nil
+}
+
+func setEntry(e massquote.NoQuoteEntries, qe *QuoteEntry) {
+	e.SetQuoteEntryID(qe.QuoteEntryID)
+	e.SetSymbol(qe.Symbol)
+	if !qe.BidPx.IsZero() {
+		e.SetBidPx(qe.BidPx, scale(qe.BidPx))
+	}
+	if !qe.OfferPx.IsZero() {
+		e.SetOfferPx(qe.OfferPx, scale(qe.OfferPx))
+	}
+	if !qe.BidSize.IsZero() {
+		e.SetBidSize(qe.BidSize, scale(qe.BidSize))
+	}
+	if !qe.OfferSize.IsZero() {
+		e.SetOfferSize(qe.OfferSize, scale(qe.OfferSize))
+	}
+	if !qe.ValidUntil.IsZero() {
+		e.SetValidUntilTime(qe.ValidUntil)
+	}
+}
+
+// Cancel withdraws the quotes of the symbols, it returns the QuoteID of the QuoteCancel
+func (m *Manager) Cancel(symbols ...string) (string, error) {
+	if len(symbols) == 0 {
+		return "", ErrNoEntries
+	}
+	m.mu.Lock()
+	defer m.unlock()
+	for _, symbol := range symbols {
+		if _, ok := m.entries[symbol]; !ok {
+			return "", ErrUnknownSymbol
+		}
+	}
+	msg := quotecancel.New(field.NewQuoteID(m.newID()), field.NewQuoteCancelType(enum.QuoteCancelType_CANCEL_FOR_ONE_OR_MORE_SECURITIES))
+	entries := quotecancel.NewNoQuoteEntriesRepeatingGroup()
+	for _, symbol := range symbols {
+		entries.Add().SetSymbol(symbol)
+	}
+	msg.SetNoQuoteEntries(entries)
+	return m.sendCancel(msg, symbols)
+}
+
+// CancelAll withdraws all the quotes, it returns the QuoteID of the QuoteCancel
+func (m *Manager) CancelAll() (string, error) {
+	m.mu.Lock()
+	defer m.unlock()
+	symbols := make([]string, 0, len(m.entries))
+	for symbol := range m.entries {
+		symbols = append(symbols, symbol)
+	}
+	msg := quotecancel.New(field.NewQuoteID(m.newID()), field.NewQuoteCancelType(enum.QuoteCancelType_CANCEL_ALL_QUOTES))
+	return m.sendCancel(msg, symbols)
+}
+
+// sendCancel must be called with mu held
+func (m *Manager) sendCancel(msg quotecancel.QuoteCancel, symbols []string) (string, error) {
+	quoteID, _ := msg.GetQuoteID()
+	msg.SetQuoteResponseLevel(m.ResponseLevel)
+	if err := m.Send(msg, m.SessionID); err != nil {
+		return "", err
+	}
+	if m.acked() {
+		m.batches[quoteID] = &batch{symbols: symbols, cancel: true}
+		return quoteID, nil
+	}
+	for _, symbol := range symbols {
+		m.setState(m.entries[symbol], Canceled, "", "")
+	}
+	return quoteID, nil
+}
+
+// setState must be called with mu held
+func (m *Manager) setState(qe *QuoteEntry, state State, reason enum.QuoteEntryRejectReason, text string) {
+	qe.State, qe.RejectReason, qe.Text, qe.UpdatedAt = state, reason, text, time.Now()
+	m.changed(*qe)
+}
+
+// changed queues the call of OnEntry with qe, it must be called with mu held
+func (m *Manager) changed(qe QuoteEntry) {
+	if m.OnEntry != nil {
+		m.callbacks = append(m.callbacks, func() { m.OnEntry(qe) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (m *Manager) unlock() {
+	callbacks := m.callbacks
+	m.callbacks = nil
+	m.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// Process handles a MassQuoteAcknowledgement, other messages are ignored.
+// It is meant to be called from FromApp.
+func (m *Manager) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	if msgType == "b" {
+		return m.OnMassQuoteAcknowledgement(massquoteacknowledgement.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnMassQuoteAcknowledgement applies an acknowledgement to the entries of its MassQuote
+// or QuoteCancel. The entries listed with a QuoteEntryRejectReason are rejected, the other
+// ones take the QuoteStatus of the message.
+func (m *Manager) OnMassQuoteAcknowledgement(a massquoteacknowledgement.MassQuoteAcknowledgement) error {
+	status, err := a.GetQuoteStatus()
+	if err != nil {
+		return err
+	}
+	quoteID, _ := a.GetQuoteID()
+	text, _ := a.GetText()
+	rejects := make(map[string]enum.QuoteEntryRejectReason)
+	if g, err := a.GetNoQuoteSets(); err == nil {
+		for _, set := range g.All() {
+			entries, err := set.GetNoQuoteEntries()
+			if err != nil {
+				continue
+			}
+			for _, e := range entries.All() {
+				id, _ := e.GetQuoteEntryID()
+				if reason, err := e.GetQuoteEntryRejectReason(); err == nil {
+					rejects[id] = reason
+				}
+			}
+		}
+	}
+
+	m.mu.Lock()
+	defer m.unlock()
+	for id, reason := range rejects {
+		if qe, ok := m.entries[m.byID[id]]; ok {
+			m.setState(qe, Rejected, reason, text)
+		}
+	}
+	b, ok := m.batches[quoteID]
+	if !ok {
+		return nil
+	}
+	delete(m.batches, quoteID)
+	for _, symbol := range b.symbols {
+		qe := m.entries[symbol]
+		if _, rejected := rejects[qe.QuoteEntryID]; rejected {
+			continue
+		}
+		switch {
+		case b.cancel && status != enum.QuoteStatus_REJECTED:
+			// any status but rejected acknowledges the cancel
+			m.setState(qe, Canceled, "", text)
+		case b.cancel:
+		case qe.QuoteID != quoteID:
+			// the entry was quoted again since
+		case status == enum.QuoteStatus_ACCEPTED:
+			m.setState(qe, Accepted, "", text)
+		case status == enum.QuoteStatus_REJECTED:
+			m.setState(qe, Rejected, "", text)
+		}
+	}
+	return nil
+}
+
+// Entry returns a copy of the last quote entry of a symbol
+func (m *Manager) Entry(symbol string) (QuoteEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	qe, ok := m.entries[symbol]
+	if !ok {
+		return QuoteEntry{}, false
+	}
+	return *qe, true
+}
+
+// Entries returns copies of the last quote entries, sorted by Symbol
+func (m *Manager) Entries() []QuoteEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := make([]QuoteEntry, 0, len(m.entries))
+	for _, qe := range m.entries {
+		entries = append(entries, *qe)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Symbol < entries[j].Symbol })
+	return entries
+}
+
+// Pending returns the QuoteIDs of the MassQuotes and QuoteCancels waiting for
+// their acknowledgement, sorted
+func (m *Manager) Pending() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.batches))
+	for id := range m.batches {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// scale returns the number of decimal places of v without its trailing zeros,
+// so that a price or a size is sent unrounded
+func scale(v decimal.Decimal) int32 {
+	var s int32
+	for !v.Truncate(s).Equal(v) {
+		s++
+	}
+	return s
+}
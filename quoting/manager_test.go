@@ -0,0 +1,159 @@
+package quoting
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/quickfixgo/fix44/massquote"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+// quoteSets returns the number of entries of each QuoteSet of a MassQuote
+func quoteSets(msg *quickfix.Message) []int {
+	sets, err := massquote.FromMessage(msg).GetNoQuoteSets()
+	if err != nil {
+		return nil
+	}
+	var entries []int
+	for i := 0; i < sets.Len(); i++ {
+		g, _ := sets.Get(i).GetNoQuoteEntries()
+		entries = append(entries, g.Len())
+	}
+	return entries
+}
+
+func entries(n int) []Entry {
+	var es []Entry
+	for i := 0; i < n; i++ {
+		es = append(es, Entry{Symbol: fmt.Sprintf("S%d", i), BidPx: decimal.NewFromInt(10), OfferPx: decimal.NewFromInt(11)})
+	}
+	return es
+}
+
+func TestQuoteFragmentation(t *testing.T) {
+	tests := []struct {
+		name           string
+		entries        int
+		perSet, perMsg int
+		want           [][]int
+		wantErr        error
+	}{
+		{"one set", 3, 5, 2, [][]int{{3}}, nil},
+		{"sets", 7, 3, 5, [][]int{{3, 3, 1}}, nil},
+		{"messages", 9, 2, 2, [][]int{{2, 2}, {2, 2}, {1}}, nil},
+		{"exact", 4, 2, 2, [][]int{{2, 2}}, nil},
+		{"no entries", 0, 2, 2, nil, ErrNoEntries},
+		{"zero entries per set", 3, 0, 2, nil, ErrInvalidLimits},
+		{"negative sets per message", 3, 2, -1, nil, ErrInvalidLimits},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(quickfix.SessionID{})
+			m.MaxEntriesPerSet, m.MaxSetsPerMessage = tt.perSet, tt.perMsg
+			var got [][]int
+			m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+				got = append(got, quoteSets(msg.ToMessage()))
+				return nil
+			}
+			ids, err := m.Quote(entries(tt.entries))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got sets %v, want %v", got, tt.want)
+			}
+			if len(ids) != len(tt.want) {
+				t.Errorf("got %d QuoteIDs, want %d", len(ids), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestQuoteLastEntryWins(t *testing.T) {
+	m := New(quickfix.SessionID{})
+	var got [][]int
+	m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+		got = append(got, quoteSets(msg.ToMessage()))
+		return nil
+	}
+	es := entries(2)
+	es = append(es, Entry{Symbol: "S0", BidPx: decimal.NewFromInt(9), OfferPx: decimal.NewFromInt(12)})
+	if _, err := m.Quote(es); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, [][]int{{2}}) {
+		t.Errorf("got sets %v, want one set of 2 entries", got)
+	}
+	if e, _ := m.Entry("S0"); !e.BidPx.Equal(decimal.NewFromInt(9)) {
+		t.Errorf("got BidPx %s, want 9", e.BidPx)
+	}
+}
+
+func TestQuoteSendFails(t *testing.T) {
+	m := New(quickfix.SessionID{})
+	m.MaxEntriesPerSet, m.MaxSetsPerMessage = 1, 1
+	if _, err := m.Quote(entries(1)); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := m.Entry("S0")
+
+	errDown := errors.New("session down")
+	var sends int
+	m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+		if sends++; sends > 1 {
+			return errDown
+		}
+		return nil
+	}
+	es := entries(2)
+	es[0].BidPx = decimal.NewFromInt(9)
+	es[0].Symbol = "S1"
+	es[1].Symbol = "S0"
+	ids, err := m.Quote(es)
+	if err != errDown {
+		t.Fatalf("got %v, want %v", err, errDown)
+	}
+	if len(ids) != 1 {
+		t.Errorf("got %d QuoteIDs, want the first message only", len(ids))
+	}
+	if e, ok := m.Entry("S1"); !ok || e.QuoteID != ids[0] || !e.BidPx.Equal(decimal.NewFromInt(9)) {
+		t.Errorf("got %+v, want the entry of the sent message", e)
+	}
+	if after, _ := m.Entry("S0"); !reflect.DeepEqual(after, before) {
+		t.Errorf("got %+v, want the entry left unchanged %+v", after, before)
+	}
+
+	m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error { return errDown }
+	if _, err := m.Quote([]Entry{{Symbol: "S9", BidPx: decimal.NewFromInt(1)}}); err != errDown {
+		t.Fatalf("got %v, want %v", err, errDown)
+	}
+	if _, ok := m.Entry("S9"); ok {
+		t.Error("got an entry for a symbol never sent")
+	}
+}
+
+func TestQuoteScales(t *testing.T) {
+	m := New(quickfix.SessionID{})
+	var sent *quickfix.Message
+	m.Send = func(msg quickfix.Messagable, sessionID quickfix.SessionID) error {
+		sent = msg.ToMessage()
+		return nil
+	}
+	e := Entry{Symbol: "S0", BidPx: decimal.RequireFromString("18.125"), OfferPx: decimal.RequireFromString("18.2"),
+		BidSize: decimal.RequireFromString("1000"), OfferSize: decimal.RequireFromString("2.5")}
+	if _, err := m.Quote([]Entry{e}); err != nil {
+		t.Fatal(err)
+	}
+	sets, _ := massquote.FromMessage(sent).GetNoQuoteSets()
+	g, _ := sets.Get(0).GetNoQuoteEntries()
+	want := map[quickfix.Tag]string{tag.BidPx: "18.125", tag.OfferPx: "18.2", tag.BidSize: "1000", tag.OfferSize: "2.5"}
+	for tg, v := range want {
+		if got, _ := g.Get(0).GetString(tg); got != v {
+			t.Errorf("got %s for tag %d, want %s", got, tg, v)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package registrationinstructions
 
 import (
 	"github.com/shopspring/decimal"
+	"iter"
 
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
@@ -426,6 +427,44 @@ func (m NoPartySubIDsRepeatingGroup) Get(i int) NoPartySubIDs {
 	return NoPartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPartySubIDs of each entry in the NoPartySubIDsRepeatingGroup
+func (m NoPartySubIDsRepeatingGroup) All() iter.Seq2[int, NoPartySubIDs] {
+	return func(yield func(int, NoPartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPartySubIDs entries of the NoPartySubIDsRepeatingGroup as a slice
+func (m NoPartySubIDsRepeatingGroup) Slice() []NoPartySubIDs {
+	s := make([]NoPartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPartySubIDsRow holds the values of a NoPartySubIDs, nil fields are left unset by AddRow
+type NoPartySubIDsRow struct {
+	PartySubID     *string
+	PartySubIDType *enum.PartySubIDType
+}
+
+//AddRow creates and appends a new NoPartySubIDs to this group, setting the fields present in row
+func (m NoPartySubIDsRepeatingGroup) AddRow(row NoPartySubIDsRow) NoPartySubIDs {
+	g := m.Add()
+	if row.PartySubID != nil {
+		g.SetPartySubID(*row.PartySubID)
+	}
+	if row.PartySubIDType != nil {
+		g.SetPartySubIDType(*row.PartySubIDType)
+	}
+	return g
+}
+
 //NoPartyIDsRepeatingGroup is a repeating group, Tag 453
 type NoPartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -449,6 +488,56 @@ func (m NoPartyIDsRepeatingGroup) Get(i int) NoPartyIDs {
 	return NoPartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoPartyIDs of each entry in the NoPartyIDsRepeatingGroup
+func (m NoPartyIDsRepeatingGroup) All() iter.Seq2[int, NoPartyIDs] {
+	return func(yield func(int, NoPartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoPartyIDs entries of the NoPartyIDsRepeatingGroup as a slice
+func (m NoPartyIDsRepeatingGroup) Slice() []NoPartyIDs {
+	s := make([]NoPartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoPartyIDsRow holds the values of a NoPartyIDs, nil fields are left unset by AddRow
+type NoPartyIDsRow struct {
+	PartyID       *string
+	PartyIDSource *enum.PartyIDSource
+	PartyRole     *enum.PartyRole
+	NoPartySubIDs []NoPartySubIDsRow
+}
+
+//AddRow creates and appends a new NoPartyIDs to this group, setting the fields present in row
+func (m NoPartyIDsRepeatingGroup) AddRow(row NoPartyIDsRow) NoPartyIDs {
+	g := m.Add()
+	if row.PartyID != nil {
+		g.SetPartyID(*row.PartyID)
+	}
+	if row.PartyIDSource != nil {
+		g.SetPartyIDSource(*row.PartyIDSource)
+	}
+	if row.PartyRole != nil {
+		g.SetPartyRole(*row.PartyRole)
+	}
+	if len(row.NoPartySubIDs) > 0 {
+		f := NewNoPartySubIDsRepeatingGroup()
+		for _, r := range row.NoPartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoPartySubIDs(f)
+	}
+	return g
+}
+
 //NoRegistDtls is a repeating group element, Tag 473
 type NoRegistDtls struct {
 	*quickfix.Group
@@ -749,6 +838,44 @@ func (m NoNestedPartySubIDsRepeatingGroup) Get(i int) NoNestedPartySubIDs {
 	return NoNestedPartySubIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoNestedPartySubIDs of each entry in the NoNestedPartySubIDsRepeatingGroup
+func (m NoNestedPartySubIDsRepeatingGroup) All() iter.Seq2[int, NoNestedPartySubIDs] {
+	return func(yield func(int, NoNestedPartySubIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoNestedPartySubIDs entries of the NoNestedPartySubIDsRepeatingGroup as a slice
+func (m NoNestedPartySubIDsRepeatingGroup) Slice() []NoNestedPartySubIDs {
+	s := make([]NoNestedPartySubIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoNestedPartySubIDsRow holds the values of a NoNestedPartySubIDs, nil fields are left unset by AddRow
+type NoNestedPartySubIDsRow struct {
+	NestedPartySubID     *string
+	NestedPartySubIDType *int
+}
+
+//AddRow creates and appends a new NoNestedPartySubIDs to this group, setting the fields present in row
+func (m NoNestedPartySubIDsRepeatingGroup) AddRow(row NoNestedPartySubIDsRow) NoNestedPartySubIDs {
+	g := m.Add()
+	if row.NestedPartySubID != nil {
+		g.SetNestedPartySubID(*row.NestedPartySubID)
+	}
+	if row.NestedPartySubIDType != nil {
+		g.SetNestedPartySubIDType(*row.NestedPartySubIDType)
+	}
+	return g
+}
+
 //NoNestedPartyIDsRepeatingGroup is a repeating group, Tag 539
 type NoNestedPartyIDsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -772,6 +899,56 @@ func (m NoNestedPartyIDsRepeatingGroup) Get(i int) NoNestedPartyIDs {
 	return NoNestedPartyIDs{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoNestedPartyIDs of each entry in the NoNestedPartyIDsRepeatingGroup
+func (m NoNestedPartyIDsRepeatingGroup) All() iter.Seq2[int, NoNestedPartyIDs] {
+	return func(yield func(int, NoNestedPartyIDs) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoNestedPartyIDs entries of the NoNestedPartyIDsRepeatingGroup as a slice
+func (m NoNestedPartyIDsRepeatingGroup) Slice() []NoNestedPartyIDs {
+	s := make([]NoNestedPartyIDs, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoNestedPartyIDsRow holds the values of a NoNestedPartyIDs, nil fields are left unset by AddRow
+type NoNestedPartyIDsRow struct {
+	NestedPartyID       *string
+	NestedPartyIDSource *string
+	NestedPartyRole     *int
+	NoNestedPartySubIDs []NoNestedPartySubIDsRow
+}
+
+//AddRow creates and appends a new NoNestedPartyIDs to this group, setting the fields present in row
+func (m NoNestedPartyIDsRepeatingGroup) AddRow(row NoNestedPartyIDsRow) NoNestedPartyIDs {
+	g := m.Add()
+	if row.NestedPartyID != nil {
+		g.SetNestedPartyID(*row.NestedPartyID)
+	}
+	if row.NestedPartyIDSource != nil {
+		g.SetNestedPartyIDSource(*row.NestedPartyIDSource)
+	}
+	if row.NestedPartyRole != nil {
+		g.SetNestedPartyRole(*row.NestedPartyRole)
+	}
+	if len(row.NoNestedPartySubIDs) > 0 {
+		f := NewNoNestedPartySubIDsRepeatingGroup()
+		for _, r := range row.NoNestedPartySubIDs {
+			f.AddRow(r)
+		}
+		g.SetNoNestedPartySubIDs(f)
+	}
+	return g
+}
+
 //NoRegistDtlsRepeatingGroup is a repeating group, Tag 473
 type NoRegistDtlsRepeatingGroup struct {
 	*quickfix.RepeatingGroup
@@ -795,6 +972,72 @@ func (m NoRegistDtlsRepeatingGroup) Get(i int) NoRegistDtls {
 	return NoRegistDtls{m.RepeatingGroup.Get(i)}
 }
 
+//All returns an iterator over the index and NoRegistDtls of each entry in the NoRegistDtlsRepeatingGroup
+func (m NoRegistDtlsRepeatingGroup) All() iter.Seq2[int, NoRegistDtls] {
+	return func(yield func(int, NoRegistDtls) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoRegistDtls entries of the NoRegistDtlsRepeatingGroup as a slice
+func (m NoRegistDtlsRepeatingGroup) Slice() []NoRegistDtls {
+	s := make([]NoRegistDtls, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoRegistDtlsRow holds the values of a NoRegistDtls, nil fields are left unset by AddRow
+type NoRegistDtlsRow struct {
+	RegistDtls                 *string
+	RegistEmail                *string
+	MailingDtls                *string
+	MailingInst                *string
+	NoNestedPartyIDs           []NoNestedPartyIDsRow
+	OwnerType                  *enum.OwnerType
+	DateOfBirth                *string
+	InvestorCountryOfResidence *string
+}
+
+//AddRow creates and appends a new NoRegistDtls to this group, setting the fields present in row
+func (m NoRegistDtlsRepeatingGroup) AddRow(row NoRegistDtlsRow) NoRegistDtls {
+	g := m.Add()
+	if row.RegistDtls != nil {
+		g.SetRegistDtls(*row.RegistDtls)
+	}
+	if row.RegistEmail != nil {
+		g.SetRegistEmail(*row.RegistEmail)
+	}
+	if row.MailingDtls != nil {
+		g.SetMailingDtls(*row.MailingDtls)
+	}
+	if row.MailingInst != nil {
+		g.SetMailingInst(*row.MailingInst)
+	}
+	if len(row.NoNestedPartyIDs) > 0 {
+		f := NewNoNestedPartyIDsRepeatingGroup()
+		for _, r := range row.NoNestedPartyIDs {
+			f.AddRow(r)
+		}
+		g.SetNoNestedPartyIDs(f)
+	}
+	if row.OwnerType != nil {
+		g.SetOwnerType(*row.OwnerType)
+	}
+	if row.DateOfBirth != nil {
+		g.SetDateOfBirth(*row.DateOfBirth)
+	}
+	if row.InvestorCountryOfResidence != nil {
+		g.SetInvestorCountryOfResidence(*row.InvestorCountryOfResidence)
+	}
+	return g
+}
+
 //NoDistribInsts is a repeating group element, Tag 510
 type NoDistribInsts struct {
 	*quickfix.Group
@@ -974,3 +1217,65 @@ func (m NoDistribInstsRepeatingGroup) Add() NoDistribInsts {
 func (m NoDistribInstsRepeatingGroup) Get(i int) NoDistribInsts {
 	return NoDistribInsts{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoDistribInsts of each entry in the NoDistribInstsRepeatingGroup
+func (m NoDistribInstsRepeatingGroup) All() iter.Seq2[int, NoDistribInsts] {
+	return func(yield func(int, NoDistribInsts) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoDistribInsts entries of the NoDistribInstsRepeatingGroup as a slice
+func (m NoDistribInstsRepeatingGroup) Slice() []NoDistribInsts {
+	s := make([]NoDistribInsts, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoDistribInstsRow holds the values of a NoDistribInsts, nil fields are left unset by AddRow
+type NoDistribInstsRow struct {
+	DistribPaymentMethod       *enum.DistribPaymentMethod
+	DistribPercentage          *quickfix.FIXDecimal
+	CashDistribCurr            *string
+	CashDistribAgentName       *string
+	CashDistribAgentCode       *string
+	CashDistribAgentAcctNumber *string
+	CashDistribPayRef          *string
+	CashDistribAgentAcctName   *string
+}
+
+//AddRow creates and appends a new NoDistribInsts to this group, setting the fields present in row
+func (m NoDistribInstsRepeatingGroup) AddRow(row NoDistribInstsRow) NoDistribInsts {
+	g := m.Add()
+	if row.DistribPaymentMethod != nil {
+		g.SetDistribPaymentMethod(*row.DistribPaymentMethod)
+	}
+	if row.DistribPercentage != nil {
+		g.SetDistribPercentage(row.DistribPercentage.Decimal, row.DistribPercentage.Scale)
+	}
+	if row.CashDistribCurr != nil {
+		g.SetCashDistribCurr(*row.CashDistribCurr)
+	}
+	if row.CashDistribAgentName != nil {
+		g.SetCashDistribAgentName(*row.CashDistribAgentName)
+	}
+	if row.CashDistribAgentCode != nil {
+		g.SetCashDistribAgentCode(*row.CashDistribAgentCode)
+	}
+	if row.CashDistribAgentAcctNumber != nil {
+		g.SetCashDistribAgentAcctNumber(*row.CashDistribAgentAcctNumber)
+	}
+	if row.CashDistribPayRef != nil {
+		g.SetCashDistribPayRef(*row.CashDistribPayRef)
+	}
+	if row.CashDistribAgentAcctName != nil {
+		g.SetCashDistribAgentAcctName(*row.CashDistribAgentAcctName)
+	}
+	return g
+}
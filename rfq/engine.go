@@ -0,0 +1,220 @@
+package rfq
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/fix44/quote"
+	"github.com/quickfixgo/fix44/quotecancel"
+	"github.com/quickfixgo/fix44/quoterequest"
+	"github.com/quickfixgo/fix44/quoterequestreject"
+	"github.com/quickfixgo/fix44/quoteresponse"
+	"github.com/quickfixgo/fix44/quotestatusreport"
+	"github.com/quickfixgo/fix44/rfqrequest"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrUnknownQuoteReqID is returned for a QuoteReqID not known by the Engine
+	ErrUnknownQuoteReqID = errors.New("rfq: unknown QuoteReqID")
+	// ErrUnknownQuoteID is returned for a QuoteResponse of a QuoteID not sent by the Engine
+	ErrUnknownQuoteID = errors.New("rfq: unknown QuoteID")
+	// ErrWrongRole is returned when calling a requester method on a responder negotiation
+	// or the other way around
+	ErrWrongRole = errors.New("rfq: method not allowed for the negotiation role")
+	// ErrQuoteExpired is returned when trading on a quote past its ValidUntilTime
+	ErrQuoteExpired = errors.New("rfq: quote expired")
+	// ErrNoSide is returned when accepting a two-sided quote without side
+	ErrNoSide = errors.New("rfq: side needed to accept a two-sided quote")
+)
+
+// Engine negotiates quotes for both the requester and the responder roles.
+// Requests sent with Request are negotiated with Counter, Accept and Pass,
+// QuoteRequests received are answered with Quote, Reject and CancelQuote.
+// An Engine is safe for concurrent use.
+type Engine struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// OnChange, if set, is called with a copy of a negotiation each time it changes,
+	// including the QuoteRequests received
+	OnChange func(Negotiation)
+	// OnRFQ, if set, is called with the symbols of each RFQRequest received.
+	// OnChange and OnRFQ are called once the Engine is unlocked, so they can call the Engine.
+	OnRFQ func(rfqReqID string, symbols []string)
+
+	mu           sync.Mutex
+	nextID       int
+	idPrefix     string
+	negotiations map[string]*Negotiation
+	// quotes maps the QuoteIDs to their QuoteReqID
+	quotes map[string]string
+	// callbacks are the calls queued while mu is held, made by unlock
+	callbacks []func()
+}
+
+// New returns an Engine for the session
+func New(sessionID quickfix.SessionID) *Engine {
+	return &Engine{
+		SessionID:    sessionID,
+		Send:         quickfix.SendToTarget,
+		idPrefix:     time.Now().Format("150405") + "-",
+		negotiations: make(map[string]*Negotiation),
+		quotes:       make(map[string]string),
+	}
+}
+
+// newID must be called with mu held
+func (e *Engine) newID() string {
+	e.nextID++
+	return fmt.Sprintf("%s%d", e.idPrefix, e.nextID)
+}
+
+// get must be called with mu held
+func (e *Engine) get(quoteReqID string, role Role) (*Negotiation, error) {
+	n, ok := e.negotiations[quoteReqID]
+	if !ok {
+		return nil, ErrUnknownQuoteReqID
+	}
+	if n.Role != role {
+		return nil, ErrWrongRole
+	}
+	return n, nil
+}
+
+// transition must be called with mu held
+func (e *Engine) transition(n *Negotiation, to State) error {
+	if !validTransition(n.State, to) {
+		return &TransitionError{QuoteReqID: n.QuoteReqID, From: n.State, To: to}
+	}
+	n.State = to
+	n.UpdatedAt = time.Now()
+	return nil
+}
+
+// apply moves the negotiation of a received message to State to and updates it
+func (e *Engine) apply(quoteReqID string, role Role, to State, update func(n *Negotiation)) error {
+	e.mu.Lock()
+	defer e.unlock()
+	n, err := e.get(quoteReqID, role)
+	if err != nil {
+		return err
+	}
+	if err := e.transition(n, to); err != nil {
+		return err
+	}
+	update(n)
+	e.changed(n)
+	return nil
+}
+
+// changed queues the call of OnChange with a copy of n, it must be called with mu held
+func (e *Engine) changed(n *Negotiation) {
+	if e.OnChange != nil {
+		c := *n
+		e.callbacks = append(e.callbacks, func() { e.OnChange(c) })
+	}
+}
+
+// unlock releases mu, then makes the calls queued while it was held
+func (e *Engine) unlock() {
+	callbacks := e.callbacks
+	e.callbacks = nil
+	e.mu.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// Process handles the messages of the quote negotiations, other messages are ignored.
+// It is meant to be called from FromApp.
+func (e *Engine) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "AH":
+		return e.OnRFQRequest(rfqrequest.FromMessage(msg))
+	case "R":
+		return e.OnQuoteRequest(quoterequest.FromMessage(msg))
+	case "S":
+		return e.OnQuote(quote.FromMessage(msg))
+	case "AG":
+		return e.OnQuoteRequestReject(quoterequestreject.FromMessage(msg))
+	case "Z":
+		return e.OnQuoteCancel(quotecancel.FromMessage(msg))
+	case "AJ":
+		return e.OnQuoteResponse(quoteresponse.FromMessage(msg))
+	case "AI":
+		return e.OnQuoteStatusReport(quotestatusreport.FromMessage(msg))
+	}
+	return nil
+}
+
+// Expire moves the negotiations whose quote is past its ValidUntilTime at t to Expired,
+// it returns their QuoteReqIDs sorted
+func (e *Engine) Expire(t time.Time) []string {
+	e.mu.Lock()
+	defer e.unlock()
+	var ids []string
+	for id, n := range e.negotiations {
+		if (n.State == Quoted || n.State == Countered) && n.expired(t) {
+			n.State = Expired
+			n.UpdatedAt = time.Now()
+			e.changed(n)
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Negotiation returns a copy of the negotiation with the QuoteReqID
+func (e *Engine) Negotiation(quoteReqID string) (Negotiation, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n, ok := e.negotiations[quoteReqID]
+	if !ok {
+		return Negotiation{}, false
+	}
+	return *n, true
+}
+
+// Negotiations returns copies of all the negotiations, sorted by QuoteReqID
+func (e *Engine) Negotiations() []Negotiation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ns := make([]Negotiation, 0, len(e.negotiations))
+	for _, n := range e.negotiations {
+		ns = append(ns, *n)
+	}
+	sort.Slice(ns, func(i, j int) bool { return ns[i].QuoteReqID < ns[j].QuoteReqID })
+	return ns
+}
+
+// Active returns copies of the negotiations not done yet, sorted by QuoteReqID
+func (e *Engine) Active() []Negotiation {
+	var ns []Negotiation
+	for _, n := range e.Negotiations() {
+		if !n.State.Done() {
+			ns = append(ns, n)
+		}
+	}
+	return ns
+}
+
+// scale returns the number of decimal places of v without its trailing zeros,
+// so that a quantity or a price is sent unrounded
+func scale(v decimal.Decimal) int32 {
+	var s int32
+	for !v.Truncate(s).Equal(v) {
+		s++
+	}
+	return s
+}
@@ -0,0 +1,198 @@
+package rfq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/quote"
+	"github.com/quickfixgo/fix44/quoterequest"
+	"github.com/quickfixgo/fix44/quoteresponse"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+var session = quickfix.SessionID{SenderCompID: "FIRM", TargetCompID: "DEALER"}
+
+// desk returns an Engine whose sends are appended to sent
+func desk(sent *[]*quickfix.Message) *Engine {
+	e := New(session)
+	e.idPrefix = "q"
+	e.Send = func(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+		*sent = append(*sent, m.ToMessage())
+		return nil
+	}
+	return e
+}
+
+func msgType(m *quickfix.Message) string {
+	t, _ := m.Header.GetString(tag.MsgType)
+	return t
+}
+
+// quoted returns an Engine with a request of side quoted by the counterparty until validUntil
+func quoted(t *testing.T, sent *[]*quickfix.Message, side enum.Side, validUntil time.Time) (*Engine, string) {
+	e := desk(sent)
+	id, err := e.Request(Request{Symbol: "VND", Side: side, OrderQty: decimal.RequireFromString("1500")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := quote.New(field.NewQuoteID("dealer-1"))
+	q.SetQuoteReqID(id)
+	q.SetBidPx(decimal.RequireFromString("18.25"), 2)
+	q.SetOfferPx(decimal.RequireFromString("18.35"), 2)
+	if !validUntil.IsZero() {
+		q.SetValidUntilTime(validUntil)
+	}
+	if err := e.Process(q.ToMessage()); err != nil {
+		t.Fatal(err)
+	}
+	return e, id
+}
+
+func TestAccept(t *testing.T) {
+	tests := []struct {
+		name       string
+		reqSide    enum.Side
+		side       enum.Side
+		validUntil time.Duration
+		want       State
+		wantPx     string
+		wantErr    error
+	}{
+		{"buy lifts the offer", enum.Side_BUY, "", 0, Lifted, "18.35", nil},
+		{"sell hits the bid", enum.Side_SELL, "", 0, Hit, "18.25", nil},
+		{"two-sided with side", "", enum.Side_SELL, time.Minute, Hit, "18.25", nil},
+		{"two-sided without side", "", "", 0, Quoted, "", ErrNoSide},
+		{"expired", enum.Side_BUY, "", -time.Minute, Expired, "", ErrQuoteExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []*quickfix.Message
+			var validUntil time.Time
+			if tt.validUntil != 0 {
+				validUntil = time.Now().Add(tt.validUntil)
+			}
+			e, id := quoted(t, &sent, tt.reqSide, validUntil)
+			if err := e.Accept(id, tt.side); err != tt.wantErr {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if n, _ := e.Negotiation(id); n.State != tt.want {
+				t.Errorf("got %v, want %v", n.State, tt.want)
+			}
+			if tt.wantErr != nil {
+				if len(sent) != 1 {
+					t.Errorf("got %d messages sent, want the QuoteRequest only", len(sent))
+				}
+				return
+			}
+			r := quoteresponse.FromMessage(sent[1])
+			if px, _ := r.GetString(tag.Price); px != tt.wantPx {
+				t.Errorf("got Price %s, want %s", px, tt.wantPx)
+			}
+			if qty, _ := r.GetString(tag.OrderQty); qty != "1500" {
+				t.Errorf("got OrderQty %s, want 1500", qty)
+			}
+		})
+	}
+}
+
+func TestCounterAndPass(t *testing.T) {
+	var sent []*quickfix.Message
+	e, id := quoted(t, &sent, enum.Side_BUY, time.Time{})
+	if err := e.Counter(id, decimal.RequireFromString("18.3")); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := e.Negotiation(id); n.State != Countered || !n.Price.Equal(decimal.RequireFromString("18.3")) {
+		t.Errorf("got %v at %v, want countered at 18.3", n.State, n.Price)
+	}
+	if err := e.Pass(id); err != nil {
+		t.Fatal(err)
+	}
+	err := e.Accept(id, "")
+	if te, ok := err.(*TransitionError); !ok || te.From != Passed || te.To != Lifted {
+		t.Errorf("got %v, want a TransitionError from passed to lifted", err)
+	}
+}
+
+// requested returns an Engine quoting until validUntil a QuoteRequest received from the counterparty
+func requested(t *testing.T, sent *[]*quickfix.Message, validUntil time.Time) (*Engine, string) {
+	e := desk(sent)
+	r := quoterequest.New(field.NewQuoteReqID("client-1"))
+	syms := quoterequest.NewNoRelatedSymRepeatingGroup()
+	sym := syms.Add()
+	sym.SetSymbol("VND")
+	sym.SetOrderQty(decimal.RequireFromString("1500"), 0)
+	r.SetNoRelatedSym(syms)
+	if err := e.Process(r.ToMessage()); err != nil {
+		t.Fatal(err)
+	}
+	quoteID, err := e.Quote("client-1", Prices{BidPx: decimal.RequireFromString("18.25"),
+		OfferPx: decimal.RequireFromString("18.35"), ValidUntil: validUntil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e, quoteID
+}
+
+func TestQuoteResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		validUntil time.Duration
+		side       enum.Side
+		want       State
+		wantSent   string
+	}{
+		{"lifted", time.Minute, enum.Side_BUY, Lifted, ""},
+		{"hit", 0, enum.Side_SELL, Hit, ""},
+		{"expired", -time.Minute, enum.Side_BUY, Expired, "AI"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []*quickfix.Message
+			var validUntil time.Time
+			if tt.validUntil != 0 {
+				validUntil = time.Now().Add(tt.validUntil)
+			}
+			e, quoteID := requested(t, &sent, validUntil)
+			if px, _ := sent[0].Body.GetString(tag.OfferPx); px != "18.35" {
+				t.Errorf("got OfferPx %s, want 18.35", px)
+			}
+			r := quoteresponse.New(field.NewQuoteRespID("resp-1"), field.NewQuoteRespType(enum.QuoteRespType_HIT_LIFT))
+			r.SetQuoteID(quoteID)
+			r.SetSide(tt.side)
+			if err := e.Process(r.ToMessage()); err != nil {
+				t.Fatal(err)
+			}
+			if n, _ := e.Negotiation("client-1"); n.State != tt.want {
+				t.Errorf("got %v, want %v", n.State, tt.want)
+			}
+			var answer string
+			if len(sent) > 1 {
+				answer = msgType(sent[1])
+			}
+			if answer != tt.wantSent {
+				t.Errorf("got %q sent, want %q", answer, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestUnknownAndWrongRole(t *testing.T) {
+	var sent []*quickfix.Message
+	e := desk(&sent)
+	r := quoteresponse.New(field.NewQuoteRespID("resp-1"), field.NewQuoteRespType(enum.QuoteRespType_HIT_LIFT))
+	r.SetQuoteID("unknown")
+	if err := e.Process(r.ToMessage()); err != ErrUnknownQuoteID {
+		t.Errorf("got %v, want %v", err, ErrUnknownQuoteID)
+	}
+	if err := e.Accept("unknown", enum.Side_BUY); err != ErrUnknownQuoteReqID {
+		t.Errorf("got %v, want %v", err, ErrUnknownQuoteReqID)
+	}
+	responder, _ := requested(t, &sent, time.Time{})
+	if err := responder.Accept("client-1", enum.Side_BUY); err != ErrWrongRole {
+		t.Errorf("got %v, want %v", err, ErrWrongRole)
+	}
+}
@@ -0,0 +1,143 @@
+package rfq
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/shopspring/decimal"
+)
+
+// State is the state of a quote negotiation
+type State int
+
+const (
+	// Requested negotiations have a QuoteRequest without Quote yet
+	Requested State = iota
+	Quoted
+	// Countered negotiations have a counter QuoteResponse waiting for a new Quote
+	Countered
+	// Hit negotiations were traded at the bid, Lifted ones at the offer
+	Hit
+	Lifted
+	Expired
+	Rejected
+	// Passed negotiations were declined by the requester
+	Passed
+	// Canceled negotiations had their quote canceled by the responder
+	Canceled
+)
+
+func (s State) String() string {
+	switch s {
+	case Requested:
+		return "requested"
+	case Quoted:
+		return "quoted"
+	case Countered:
+		return "countered"
+	case Hit:
+		return "hit"
+	case Lifted:
+		return "lifted"
+	case Expired:
+		return "expired"
+	case Rejected:
+		return "rejected"
+	case Passed:
+		return "passed"
+	case Canceled:
+		return "canceled"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Done tells if no message can change a negotiation in the state anymore
+func (s State) Done() bool {
+	switch s {
+	case Hit, Lifted, Expired, Rejected, Passed, Canceled:
+		return true
+	}
+	return false
+}
+
+// validTransition tells if a negotiation may move from State from to State to
+func validTransition(from, to State) bool {
+	if from.Done() {
+		return false
+	}
+	switch to {
+	case Requested:
+		return false
+	case Quoted:
+		// quotes answer a request or a counter, or refresh a previous quote
+		return true
+	case Countered, Hit, Lifted, Passed:
+		return from == Quoted || from == Countered
+	case Expired, Rejected, Canceled:
+		return true
+	}
+	return false
+}
+
+// TransitionError is returned for a message or a call that would move a negotiation
+// to a State it cannot reach from its current one
+type TransitionError struct {
+	QuoteReqID string
+	From, To   State
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("rfq: invalid transition of negotiation %s from %s to %s", e.QuoteReqID, e.From, e.To)
+}
+
+// Role tells which side of a negotiation the Engine is on
+type Role int
+
+const (
+	// Requester negotiations were requested by the Engine
+	Requester Role = iota
+	// Responder negotiations were requested by the counterparty and are quoted by the Engine
+	Responder
+)
+
+func (r Role) String() string {
+	if r == Responder {
+		return "responder"
+	}
+	return "requester"
+}
+
+// Negotiation is the state of a QuoteReqID
+type Negotiation struct {
+	QuoteReqID string
+	// RFQReqID is the RFQRequest the QuoteRequest answers, if any
+	RFQReqID string
+	Role     Role
+	Symbol   string
+	// Side is the side of the requester, empty for two-sided requests
+	Side     enum.Side
+	OrderQty decimal.Decimal
+	Account  string
+
+	State State
+	// QuoteID is the last Quote of the negotiation
+	QuoteID   string
+	BidPx     decimal.Decimal
+	OfferPx   decimal.Decimal
+	BidSize   decimal.Decimal
+	OfferSize decimal.Decimal
+	// ValidUntil is the ValidUntilTime of the last Quote, zero if the quote does not expire
+	ValidUntil time.Time
+	// QuoteRespID and Price are those of the last QuoteResponse, the counter or traded price
+	QuoteRespID  string
+	Price        decimal.Decimal
+	RejectReason enum.QuoteRequestRejectReason
+	Text         string
+	UpdatedAt    time.Time
+}
+
+// expired tells if the quote of n is no longer valid at t
+func (n *Negotiation) expired(t time.Time) bool {
+	return !n.ValidUntil.IsZero() && !t.Before(n.ValidUntil)
+}
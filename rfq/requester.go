@@ -0,0 +1,267 @@
+package rfq
+
+import (
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/quote"
+	"github.com/quickfixgo/fix44/quotecancel"
+	"github.com/quickfixgo/fix44/quoterequest"
+	"github.com/quickfixgo/fix44/quoterequestreject"
+	"github.com/quickfixgo/fix44/quoteresponse"
+	"github.com/quickfixgo/fix44/quotestatusreport"
+	"github.com/quickfixgo/fix44/quotestatusrequest"
+	"github.com/shopspring/decimal"
+)
+
+// Request is a quote request of an instrument
+type Request struct {
+	Symbol string
+	// Side is empty to ask for a two-sided quote
+	Side     enum.Side
+	OrderQty decimal.Decimal
+	Account  string
+	// ValidUntil, if set, is the time after which the request is no longer valid
+	ValidUntil time.Time
+	// RFQReqID, if set, is the RFQRequest answered by the request
+	RFQReqID string
+}
+
+// Request sends a QuoteRequest, it returns the QuoteReqID
+func (e *Engine) Request(r Request) (string, error) {
+	e.mu.Lock()
+	defer e.unlock()
+	n := &Negotiation{QuoteReqID: e.newID(), RFQReqID: r.RFQReqID, Role: Requester, Symbol: r.Symbol,
+		Side: r.Side, OrderQty: r.OrderQty, Account: r.Account, State: Requested, UpdatedAt: time.Now()}
+
+	msg := quoterequest.New(field.NewQuoteReqID(n.QuoteReqID))
+	if r.RFQReqID != "" {
+		msg.SetRFQReqID(r.RFQReqID)
+	}
+	syms := quoterequest.NewNoRelatedSymRepeatingGroup()
+	sym := syms.Add()
+	sym.SetSymbol(r.Symbol)
+	if r.Side != "" {
+		sym.SetSide(r.Side)
+	}
+	sym.SetOrderQty(r.OrderQty, scale(r.OrderQty))
+	if r.Account != "" {
+		sym.SetAccount(r.Account)
+	}
+	if !r.ValidUntil.IsZero() {
+		sym.SetValidUntilTime(r.ValidUntil)
+	}
+	msg.SetNoRelatedSym(syms)
+	if err := e.Send(msg, e.SessionID); err != nil {
+		return "", err
+	}
+	e.negotiations[n.QuoteReqID] = n
+	e.changed(n)
+	return n.QuoteReqID, nil
+}
+
+// Counter answers the last quote of a request with a counter price
+func (e *Engine) Counter(quoteReqID string, price decimal.Decimal) error {
+	e.mu.Lock()
+	defer e.unlock()
+	n, err := e.tradable(quoteReqID)
+	if err != nil {
+		return err
+	}
+	return e.respond(n, enum.QuoteRespType_COUNTER, n.Side, price, Countered)
+}
+
+// Accept trades the last quote of a request, hitting the bid for a sell and lifting
+// the offer for a buy. side is only needed for two-sided requests, ErrNoSide is
+// returned without it.
+func (e *Engine) Accept(quoteReqID string, side enum.Side) error {
+	e.mu.Lock()
+	defer e.unlock()
+	n, err := e.tradable(quoteReqID)
+	if err != nil {
+		return err
+	}
+	if side == "" {
+		side = n.Side
+	}
+	if side == "" {
+		return ErrNoSide
+	}
+	to, price := Lifted, n.OfferPx
+	if side == enum.Side_SELL || side == enum.Side_SELL_SHORT || side == enum.Side_SELL_SHORT_EXEMPT {
+		to, price = Hit, n.BidPx
+	}
+	return e.respond(n, enum.QuoteRespType_HIT_LIFT, side, price, to)
+}
+
+// Pass declines the last quote of a request
+func (e *Engine) Pass(quoteReqID string) error {
+	e.mu.Lock()
+	defer e.unlock()
+	n, err := e.get(quoteReqID, Requester)
+	if err != nil {
+		return err
+	}
+	return e.respond(n, enum.QuoteRespType_PASS, n.Side, decimal.Zero, Passed)
+}
+
+// tradable must be called with mu held, it expires the negotiation if its quote is no longer valid
+func (e *Engine) tradable(quoteReqID string) (*Negotiation, error) {
+	n, err := e.get(quoteReqID, Requester)
+	if err != nil {
+		return nil, err
+	}
+	if e.stale(n) {
+		return nil, ErrQuoteExpired
+	}
+	return n, nil
+}
+
+// respond must be called with mu held
+func (e *Engine) respond(n *Negotiation, respType enum.QuoteRespType, side enum.Side, price decimal.Decimal, to State) error {
+	if !validTransition(n.State, to) {
+		return &TransitionError{QuoteReqID: n.QuoteReqID, From: n.State, To: to}
+	}
+	respID := e.newID()
+	msg := quoteresponse.New(field.NewQuoteRespID(respID), field.NewQuoteRespType(respType))
+	msg.SetQuoteID(n.QuoteID)
+	msg.SetSymbol(n.Symbol)
+	if side != "" {
+		msg.SetSide(side)
+	}
+	msg.SetOrderQty(n.OrderQty, scale(n.OrderQty))
+	if !price.IsZero() {
+		msg.SetPrice(price, scale(price))
+	}
+	msg.SetTransactTime(time.Now())
+	if err := e.Send(msg, e.SessionID); err != nil {
+		return err
+	}
+	n.QuoteRespID = respID
+	if !price.IsZero() {
+		n.Price = price
+	}
+	if err := e.transition(n, to); err != nil {
+		return err
+	}
+	e.changed(n)
+	return nil
+}
+
+// StatusRequest sends a QuoteStatusRequest for the last quote of a request,
+// it returns the QuoteStatusReqID
+func (e *Engine) StatusRequest(quoteReqID string) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n, err := e.get(quoteReqID, Requester)
+	if err != nil {
+		return "", err
+	}
+	id := e.newID()
+	msg := quotestatusrequest.New()
+	msg.SetQuoteStatusReqID(id)
+	if n.QuoteID != "" {
+		msg.SetQuoteID(n.QuoteID)
+	}
+	msg.SetSymbol(n.Symbol)
+	return id, e.Send(msg, e.SessionID)
+}
+
+// OnQuote applies a Quote answering a request sent by the Engine,
+// unsolicited quotes without QuoteReqID are ignored
+func (e *Engine) OnQuote(q quote.Quote) error {
+	quoteID, err := q.GetQuoteID()
+	if err != nil {
+		return err
+	}
+	quoteReqID, _ := q.GetQuoteReqID()
+	if quoteReqID == "" {
+		return nil
+	}
+
+	bidPx, _ := q.GetBidPx()
+	offerPx, _ := q.GetOfferPx()
+	bidSize, _ := q.GetBidSize()
+	offerSize, _ := q.GetOfferSize()
+	validUntil, _ := q.GetValidUntilTime()
+	text, _ := q.GetText()
+	return e.apply(quoteReqID, Requester, Quoted, func(n *Negotiation) {
+		n.QuoteID = quoteID
+		n.BidPx, n.OfferPx, n.BidSize, n.OfferSize = bidPx, offerPx, bidSize, offerSize
+		n.ValidUntil = validUntil
+		n.Text = text
+		e.quotes[quoteID] = quoteReqID
+	})
+}
+
+// OnQuoteRequestReject rejects a request sent by the Engine
+func (e *Engine) OnQuoteRequestReject(r quoterequestreject.QuoteRequestReject) error {
+	quoteReqID, err := r.GetQuoteReqID()
+	if err != nil {
+		return err
+	}
+
+	reason, _ := r.GetQuoteRequestRejectReason()
+	text, _ := r.GetText()
+	return e.apply(quoteReqID, Requester, Rejected, func(n *Negotiation) {
+		n.RejectReason = reason
+		n.Text = text
+	})
+}
+
+// OnQuoteCancel cancels the negotiation of a quote canceled by the responder
+func (e *Engine) OnQuoteCancel(c quotecancel.QuoteCancel) error {
+	quoteReqID, _ := c.GetQuoteReqID()
+	if quoteReqID == "" {
+		quoteID, _ := c.GetQuoteID()
+		e.mu.Lock()
+		quoteReqID = e.quotes[quoteID]
+		e.mu.Unlock()
+	}
+	return e.apply(quoteReqID, Requester, Canceled, func(*Negotiation) {})
+}
+
+// OnQuoteStatusReport applies the canceled, rejected, expired and pass QuoteStatus of a report,
+// other statuses leave the negotiation unchanged
+func (e *Engine) OnQuoteStatusReport(r quotestatusreport.QuoteStatusReport) error {
+	status, err := r.GetQuoteStatus()
+	if err != nil {
+		return err
+	}
+	var to State
+	switch status {
+	case enum.QuoteStatus_CANCEL_FOR_SYMBOL, enum.QuoteStatus_CANCELED_FOR_SECURITY_TYPE, enum.QuoteStatus_CANCELED_FOR_UNDERLYING,
+		enum.QuoteStatus_CANCELED_ALL, enum.QuoteStatus_REMOVED_FROM_MARKET:
+		to = Canceled
+	case enum.QuoteStatus_REJECTED:
+		to = Rejected
+	case enum.QuoteStatus_EXPIRED:
+		to = Expired
+	case enum.QuoteStatus_PASS:
+		to = Passed
+	default:
+		return nil
+	}
+	quoteReqID, _ := r.GetQuoteReqID()
+
+	e.mu.Lock()
+	defer e.unlock()
+	if quoteReqID == "" {
+		quoteID, _ := r.GetQuoteID()
+		quoteReqID = e.quotes[quoteID]
+	}
+	n, ok := e.negotiations[quoteReqID]
+	if !ok {
+		return ErrUnknownQuoteReqID
+	}
+	if n.State == to {
+		return nil
+	}
+	if err := e.transition(n, to); err != nil {
+		return err
+	}
+	n.Text, _ = r.GetText()
+	e.changed(n)
+	return nil
+}
@@ -0,0 +1,262 @@
+package rfq
+
+import (
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/quote"
+	"github.com/quickfixgo/fix44/quotecancel"
+	"github.com/quickfixgo/fix44/quoterequest"
+	"github.com/quickfixgo/fix44/quoterequestreject"
+	"github.com/quickfixgo/fix44/quoteresponse"
+	"github.com/quickfixgo/fix44/quotestatusreport"
+	"github.com/quickfixgo/fix44/rfqrequest"
+	"github.com/shopspring/decimal"
+)
+
+// Prices are the prices of a Quote, a zero price or size is left out
+type Prices struct {
+	BidPx     decimal.Decimal
+	OfferPx   decimal.Decimal
+	BidSize   decimal.Decimal
+	OfferSize decimal.Decimal
+	// ValidUntil, if set, is the ValidUntilTime of the quote
+	ValidUntil time.Time
+}
+
+// OnRFQRequest passes the symbols of an RFQRequest to OnRFQ
+func (e *Engine) OnRFQRequest(r rfqrequest.RFQRequest) error {
+	rfqReqID, err := r.GetRFQReqID()
+	if err != nil {
+		return err
+	}
+	var symbols []string
+	if g, err := r.GetNoRelatedSym(); err == nil {
+		for _, s := range g.All() {
+			if symbol, err := s.GetSymbol(); err == nil {
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	if e.OnRFQ != nil {
+		e.OnRFQ(rfqReqID, symbols)
+	}
+	return nil
+}
+
+// OnQuoteRequest starts a responder negotiation for a QuoteRequest.
+// Only the first instrument of the request is negotiated.
+func (e *Engine) OnQuoteRequest(r quoterequest.QuoteRequest) error {
+	quoteReqID, err := r.GetQuoteReqID()
+	if err != nil {
+		return err
+	}
+	n := &Negotiation{QuoteReqID: quoteReqID, Role: Responder, State: Requested, UpdatedAt: time.Now()}
+	n.RFQReqID, _ = r.GetRFQReqID()
+	n.Text, _ = r.GetText()
+	if g, err := r.GetNoRelatedSym(); err == nil && g.Len() > 0 {
+		s := g.Get(0)
+		n.Symbol, _ = s.GetSymbol()
+		n.Side, _ = s.GetSide()
+		n.OrderQty, _ = s.GetOrderQty()
+		n.Account, _ = s.GetAccount()
+		n.ValidUntil, _ = s.GetValidUntilTime()
+	}
+
+	e.mu.Lock()
+	defer e.unlock()
+	if _, ok := e.negotiations[quoteReqID]; ok {
+		return nil
+	}
+	e.negotiations[quoteReqID] = n
+	e.changed(n)
+	return nil
+}
+
+// Quote sends a Quote answering a request or a counter, or refreshing the previous quote.
+// It returns the QuoteID.
+func (e *Engine) Quote(quoteReqID string, p Prices) (string, error) {
+	e.mu.Lock()
+	defer e.unlock()
+	n, err := e.get(quoteReqID, Responder)
+	if err != nil {
+		return "", err
+	}
+	if !validTransition(n.State, Quoted) {
+		return "", &TransitionError{QuoteReqID: quoteReqID, From: n.State, To: Quoted}
+	}
+	quoteID := e.newID()
+	msg := quote.New(field.NewQuoteID(quoteID))
+	msg.SetQuoteReqID(quoteReqID)
+	if n.State == Countered && n.QuoteRespID != "" {
+		msg.SetQuoteRespID(n.QuoteRespID)
+	}
+	msg.SetSymbol(n.Symbol)
+	if n.Side != "" {
+		msg.SetSide(n.Side)
+	}
+	if !n.OrderQty.IsZero() {
+		msg.SetOrderQty(n.OrderQty, scale(n.OrderQty))
+	}
+	if !p.BidPx.IsZero() {
+		msg.SetBidPx(p.BidPx, scale(p.BidPx))
+	}
+	if !p.OfferPx.IsZero() {
+		msg.SetOfferPx(p.OfferPx, scale(p.OfferPx))
+	}
+	if !p.BidSize.IsZero() {
+		msg.SetBidSize(p.BidSize, scale(p.BidSize))
+	}
+	if !p.OfferSize.IsZero() {
+		msg.SetOfferSize(p.OfferSize, scale(p.OfferSize))
+	}
+	if !p.ValidUntil.IsZero() {
+		msg.SetValidUntilTime(p.ValidUntil)
+	}
+	msg.SetTransactTime(time.Now())
+	if err := e.Send(msg, e.SessionID); err != nil {
+		return "", err
+	}
+	n.QuoteID = quoteID
+	n.BidPx, n.OfferPx, n.BidSize, n.OfferSize = p.BidPx, p.OfferPx, p.BidSize, p.OfferSize
+	n.ValidUntil = p.ValidUntil
+	e.quotes[quoteID] = quoteReqID
+	if err := e.transition(n, Quoted); err != nil {
+		return "", err
+	}
+	e.changed(n)
+	return quoteID, nil
+}
+
+// Reject sends a QuoteRequestReject for a request received
+func (e *Engine) Reject(quoteReqID string, reason enum.QuoteRequestRejectReason, text string) error {
+	e.mu.Lock()
+	defer e.unlock()
+	n, err := e.get(quoteReqID, Responder)
+	if err != nil {
+		return err
+	}
+	if !validTransition(n.State, Rejected) {
+		return &TransitionError{QuoteReqID: quoteReqID, From: n.State, To: Rejected}
+	}
+	msg := quoterequestreject.New(field.NewQuoteReqID(quoteReqID), field.NewQuoteRequestRejectReason(reason))
+	syms := quoterequestreject.NewNoRelatedSymRepeatingGroup()
+	syms.Add().SetSymbol(n.Symbol)
+	msg.SetNoRelatedSym(syms)
+	if text != "" {
+		msg.SetText(text)
+	}
+	if err := e.Send(msg, e.SessionID); err != nil {
+		return err
+	}
+	n.RejectReason = reason
+	n.Text = text
+	if err := e.transition(n, Rejected); err != nil {
+		return err
+	}
+	e.changed(n)
+	return nil
+}
+
+// CancelQuote withdraws the last quote of a request received
+func (e *Engine) CancelQuote(quoteReqID string) error {
+	e.mu.Lock()
+	defer e.unlock()
+	n, err := e.get(quoteReqID, Responder)
+	if err != nil {
+		return err
+	}
+	if !validTransition(n.State, Canceled) {
+		return &TransitionError{QuoteReqID: quoteReqID, From: n.State, To: Canceled}
+	}
+	msg := quotecancel.New(field.NewQuoteID(n.QuoteID), field.NewQuoteCancelType(enum.QuoteCancelType_CANCEL_FOR_ONE_OR_MORE_SECURITIES))
+	msg.SetQuoteReqID(quoteReqID)
+	entries := quotecancel.NewNoQuoteEntriesRepeatingGroup()
+	entries.Add().SetSymbol(n.Symbol)
+	msg.SetNoQuoteEntries(entries)
+	if err := e.Send(msg, e.SessionID); err != nil {
+		return err
+	}
+	if err := e.transition(n, Canceled); err != nil {
+		return err
+	}
+	e.changed(n)
+	return nil
+}
+
+// OnQuoteResponse applies the QuoteRespType of a response to a quote sent by the Engine
+func (e *Engine) OnQuoteResponse(r quoteresponse.QuoteResponse) error {
+	respType, err := r.GetQuoteRespType()
+	if err != nil {
+		return err
+	}
+	quoteID, _ := r.GetQuoteID()
+	side, _ := r.GetSide()
+	var to State
+	switch respType {
+	case enum.QuoteRespType_HIT_LIFT:
+		to = Lifted
+		if side == enum.Side_SELL || side == enum.Side_SELL_SHORT || side == enum.Side_SELL_SHORT_EXEMPT {
+			to = Hit
+		}
+	case enum.QuoteRespType_COUNTER:
+		to = Countered
+	case enum.QuoteRespType_EXPIRED:
+		to = Expired
+	case enum.QuoteRespType_COVER, enum.QuoteRespType_DONE_AWAY, enum.QuoteRespType_PASS:
+		to = Passed
+	default:
+		return nil
+	}
+
+	quoteRespID, _ := r.GetQuoteRespID()
+	price, priceErr := r.GetPrice()
+	text, _ := r.GetText()
+
+	e.mu.Lock()
+	quoteReqID, ok := e.quotes[quoteID]
+	if !ok {
+		e.mu.Unlock()
+		return ErrUnknownQuoteID
+	}
+	if respType == enum.QuoteRespType_HIT_LIFT {
+		if n, err := e.get(quoteReqID, Responder); err == nil && n.QuoteID == quoteID && e.stale(n) {
+			defer e.unlock()
+			return e.answerExpired(n, quoteRespID)
+		}
+	}
+	e.mu.Unlock()
+	return e.apply(quoteReqID, Responder, to, func(n *Negotiation) {
+		n.QuoteRespID = quoteRespID
+		if priceErr == nil {
+			n.Price = price
+		}
+		n.Text = text
+	})
+}
+
+// stale must be called with mu held, it expires the negotiation if its quote is no longer valid
+// and tells if the quote cannot be traded because it expired
+func (e *Engine) stale(n *Negotiation) bool {
+	if (n.State == Quoted || n.State == Countered) && n.expired(time.Now()) {
+		n.State = Expired
+		n.UpdatedAt = time.Now()
+		e.changed(n)
+	}
+	return n.State == Expired
+}
+
+// answerExpired sends a QuoteStatusReport telling the counterparty that the quote it traded
+// on is expired, it must be called with mu held
+func (e *Engine) answerExpired(n *Negotiation, quoteRespID string) error {
+	msg := quotestatusreport.New(field.NewQuoteID(n.QuoteID))
+	msg.SetQuoteReqID(n.QuoteReqID)
+	if quoteRespID != "" {
+		msg.SetQuoteRespID(quoteRespID)
+	}
+	msg.SetQuoteStatus(enum.QuoteStatus_EXPIRED)
+	msg.SetSymbol(n.Symbol)
+	msg.SetTransactTime(time.Now())
+	return e.Send(msg, e.SessionID)
+}
@@ -0,0 +1,284 @@
+package router
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/quickfixgo/fix44/businessreject"
+	"github.com/quickfixgo/fix44/hnxinfogate"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+var (
+	// ErrUnknownDesk is returned for a message received on a session that is not a desk
+	ErrUnknownDesk = errors.New("router: unknown desk")
+	// ErrInvalidDeskID is returned for an empty desk ID or one containing the Separator
+	ErrInvalidDeskID = errors.New("router: invalid desk ID")
+	// ErrUnroutable is passed to OnUnroutable for an exchange message matching no desk
+	ErrUnroutable = errors.New("router: no desk for message")
+)
+
+// DefaultSeparator separates the desk ID from the desk ClOrdID in the exchange ClOrdIDs
+const DefaultSeparator = "/"
+
+// deskTags are the IDs namespaced per desk, by MsgType of the order messages desks can send.
+// Mass cancels and mass status requests are not forwarded since on the shared exchange
+// account they would affect the orders of every desk.
+var deskTags = map[string][]quickfix.Tag{
+	"D":  {tag.ClOrdID},
+	"F":  {tag.ClOrdID, tag.OrigClOrdID},
+	"G":  {tag.ClOrdID, tag.OrigClOrdID},
+	"H":  {tag.ClOrdID},
+	"AB": {tag.ClOrdID},
+	"AC": {tag.ClOrdID, tag.OrigClOrdID},
+}
+
+// exchangeTags are the namespaced IDs of the exchange messages routed back to a desk, by MsgType
+var exchangeTags = map[string][]quickfix.Tag{
+	"8": {tag.ClOrdID, tag.OrigClOrdID},
+	"9": {tag.ClOrdID, tag.OrigClOrdID},
+	"j": {tag.BusinessRejectRefID},
+}
+
+// broadcast are the MsgTypes of the exchange messages sent to every desk
+var broadcast = map[string]bool{
+	"h": true, // TradingSessionStatus
+	"f": true, // SecurityStatus
+	"B": true, // News
+}
+
+type desk struct {
+	id        string
+	sessionID quickfix.SessionID
+	// allSymbols or symbols select the InfoGate market data of the desk
+	allSymbols bool
+	symbols    map[string]bool
+}
+
+// Router shares one exchange session between internal desk sessions. The orders of the desks
+// are sent to the exchange with their ClOrdIDs prefixed by the desk ID, the ExecutionReports,
+// OrderCancelRejects and BusinessMessageRejects are sent back to the desk of the order with
+// the prefix removed, and the InfoGate market data is sent to the subscribed desks.
+// A Router is safe for concurrent use.
+type Router struct {
+	Exchange quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send      func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	Separator string
+	// OnUnroutable, if set, is called for the exchange messages matching no desk
+	OnUnroutable func(msg *quickfix.Message, err error)
+
+	mu        sync.Mutex
+	desks     map[string]*desk
+	bySession map[quickfix.SessionID]*desk
+	// orderDesks are the desks by exchange OrderID, for the reports without ClOrdID
+	orderDesks map[string]string
+}
+
+// New returns a Router to the exchange session, without desks
+func New(exchange quickfix.SessionID) *Router {
+	return &Router{
+		Exchange:   exchange,
+		Send:       quickfix.SendToTarget,
+		Separator:  DefaultSeparator,
+		desks:      make(map[string]*desk),
+		bySession:  make(map[quickfix.SessionID]*desk),
+		orderDesks: make(map[string]string),
+	}
+}
+
+// AddDesk routes the messages of the session as the desk with the ID
+func (r *Router) AddDesk(deskID string, sessionID quickfix.SessionID) error {
+	if deskID == "" || strings.Contains(deskID, r.Separator) {
+		return ErrInvalidDeskID
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d := &desk{id: deskID, sessionID: sessionID, symbols: make(map[string]bool)}
+	r.desks[deskID] = d
+	r.bySession[sessionID] = d
+	return nil
+}
+
+// RemoveDesk stops routing to the desk
+func (r *Router) RemoveDesk(deskID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if d, ok := r.desks[deskID]; ok {
+		delete(r.bySession, d.sessionID)
+		delete(r.desks, deskID)
+	}
+}
+
+// Subscribe sends the InfoGate market data of the symbols to the desk, or all the
+// InfoGate messages, including the BoardInfo and Index ones, if no symbol is given
+func (r *Router) Subscribe(deskID string, symbols ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.desks[deskID]
+	if !ok {
+		return ErrUnknownDesk
+	}
+	if len(symbols) == 0 {
+		d.allSymbols = true
+	}
+	for _, s := range symbols {
+		d.symbols[s] = true
+	}
+	return nil
+}
+
+// Unsubscribe stops sending the market data of the symbols to the desk, of every symbol if none is given
+func (r *Router) Unsubscribe(deskID string, symbols ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.desks[deskID]
+	if !ok {
+		return ErrUnknownDesk
+	}
+	if len(symbols) == 0 {
+		d.allSymbols = false
+		d.symbols = make(map[string]bool)
+	}
+	for _, s := range symbols {
+		delete(d.symbols, s)
+	}
+	return nil
+}
+
+// Process routes a message received on the exchange session or on a desk session.
+// It is meant to be called from FromApp, e.g. through businessreject.Route so that
+// the errors of desk messages are answered with BusinessMessageRejects.
+func (r *Router) Process(msg *quickfix.Message, sessionID quickfix.SessionID) error {
+	if sessionID == r.Exchange {
+		return r.fromExchange(msg)
+	}
+	return r.fromDesk(msg, sessionID)
+}
+
+// carried are the header fields kept by readdress, so that the receiver of a possible
+// duplicate or resend can check it against what it already got
+var carried = []quickfix.Tag{tag.PossDupFlag, tag.PossResend, tag.OrigSendingTime}
+
+// readdress returns a copy of msg without its session header fields, set again when sent
+func readdress(msg *quickfix.Message, msgType string) *quickfix.Message {
+	cp := quickfix.NewMessage()
+	msg.CopyInto(cp)
+	cp.Header.Clear()
+	cp.Header.SetString(tag.MsgType, msgType)
+	for _, t := range carried {
+		if v, err := msg.Header.GetString(t); err == nil {
+			cp.Header.SetString(t, v)
+		}
+	}
+	cp.Trailer.Clear()
+	return cp
+}
+
+func (r *Router) fromDesk(msg *quickfix.Message, sessionID quickfix.SessionID) error {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	d, ok := r.bySession[sessionID]
+	r.mu.Unlock()
+	if !ok {
+		return ErrUnknownDesk
+	}
+	tags, ok := deskTags[msgType]
+	if !ok {
+		return businessreject.UnsupportedMessageType(msgType)
+	}
+	fwd := readdress(msg, msgType)
+	for _, t := range tags {
+		if v, err := fwd.Body.GetString(t); err == nil {
+			fwd.Body.SetString(t, d.id+r.Separator+v)
+		}
+	}
+	return r.Send(fwd, r.Exchange)
+}
+
+// deskOf returns the desk of a namespaced ID, must be called with mu held
+func (r *Router) deskOf(id string) (*desk, bool) {
+	deskID, _, ok := strings.Cut(id, r.Separator)
+	if !ok {
+		return nil, false
+	}
+	d, ok := r.desks[deskID]
+	return d, ok
+}
+
+func (r *Router) fromExchange(msg *quickfix.Message) error {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return err
+	}
+	if tags, ok := exchangeTags[msgType]; ok {
+		return r.toDesk(msg, msgType, tags)
+	}
+	symbol, _ := msg.Body.GetString(tag.Symbol)
+	isMarketData := slices.Contains(hnxinfogate.MsgTypes, msgType)
+	if !isMarketData && !broadcast[msgType] {
+		r.unroutable(msg, ErrUnroutable)
+		return nil
+	}
+
+	r.mu.Lock()
+	var to []quickfix.SessionID
+	for _, d := range r.desks {
+		if !isMarketData || d.allSymbols || symbol != "" && d.symbols[symbol] {
+			to = append(to, d.sessionID)
+		}
+	}
+	r.mu.Unlock()
+	var firstErr error
+	for _, sessionID := range to {
+		if err := r.Send(readdress(msg, msgType), sessionID); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (r *Router) toDesk(msg *quickfix.Message, msgType string, tags []quickfix.Tag) error {
+	orderID, _ := msg.Body.GetString(tag.OrderID)
+	r.mu.Lock()
+	var d *desk
+	for _, t := range tags {
+		if v, err := msg.Body.GetString(t); err == nil {
+			if d, _ = r.deskOf(v); d != nil {
+				break
+			}
+		}
+	}
+	if d == nil && orderID != "" {
+		d = r.desks[r.orderDesks[orderID]]
+	}
+	if d != nil && orderID != "" && msgType == "8" {
+		r.orderDesks[orderID] = d.id
+	}
+	r.mu.Unlock()
+	if d == nil {
+		r.unroutable(msg, ErrUnroutable)
+		return nil
+	}
+
+	fwd := readdress(msg, msgType)
+	prefix := d.id + r.Separator
+	for _, t := range tags {
+		if v, err := fwd.Body.GetString(t); err == nil && strings.HasPrefix(v, prefix) {
+			fwd.Body.SetString(t, strings.TrimPrefix(v, prefix))
+		}
+	}
+	return r.Send(fwd, d.sessionID)
+}
+
+func (r *Router) unroutable(msg *quickfix.Message, err error) {
+	if r.OnUnroutable != nil {
+		r.OnUnroutable(msg, err)
+	}
+}
@@ -0,0 +1,239 @@
+package router
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/businessreject"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+var (
+	exchange = quickfix.SessionID{SenderCompID: "FIRM", TargetCompID: "HNX"}
+	deskA    = quickfix.SessionID{SenderCompID: "FIRM", TargetCompID: "DESKA"}
+	deskB    = quickfix.SessionID{SenderCompID: "FIRM", TargetCompID: "DESKB"}
+)
+
+// delivery is a message sent by the Router: the session it was sent to and its body fields
+type delivery struct {
+	sessionID quickfix.SessionID
+	fields    map[quickfix.Tag]string
+}
+
+// floor is the desks A and B and the exchange behind a Router, it records what the Router
+// sends and what it cannot route
+type floor struct {
+	t          *testing.T
+	router     *Router
+	sent       []delivery
+	unroutable []error
+}
+
+func newFloor(t *testing.T) *floor {
+	t.Helper()
+	f := &floor{t: t, router: New(exchange)}
+	f.router.Send = f.receive
+	f.router.OnUnroutable = func(msg *quickfix.Message, err error) { f.unroutable = append(f.unroutable, err) }
+	if err := f.router.AddDesk("A", deskA); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.router.AddDesk("B", deskB); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func (f *floor) receive(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+	msg := m.ToMessage()
+	d := delivery{sessionID: sessionID, fields: make(map[quickfix.Tag]string)}
+	for _, t := range []quickfix.Tag{tag.ClOrdID, tag.OrigClOrdID, tag.BusinessRejectRefID} {
+		if v, err := msg.Body.GetString(t); err == nil {
+			d.fields[t] = v
+		}
+	}
+	if msg.Header.Has(tag.SenderCompID) {
+		f.t.Errorf("got SenderCompID set on the message sent to %v", sessionID)
+	}
+	f.sent = append(f.sent, d)
+	return nil
+}
+
+// message returns a message of the MsgType with the body fields, as received
+func message(msgType string, fields map[quickfix.Tag]string) *quickfix.Message {
+	msg := quickfix.NewMessage()
+	msg.Header.SetString(tag.MsgType, msgType)
+	msg.Header.SetString(tag.SenderCompID, "SENDER")
+	for t, v := range fields {
+		msg.Body.SetString(t, v)
+	}
+	return msg
+}
+
+func TestFromDesk(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    quickfix.SessionID
+		msgType string
+		fields  map[quickfix.Tag]string
+		want    map[quickfix.Tag]string
+		wantErr error
+	}{
+		{"new order", deskA, "D", map[quickfix.Tag]string{tag.ClOrdID: "1"}, map[quickfix.Tag]string{tag.ClOrdID: "A/1"}, nil},
+		{"cancel", deskB, "F", map[quickfix.Tag]string{tag.ClOrdID: "2", tag.OrigClOrdID: "1"},
+			map[quickfix.Tag]string{tag.ClOrdID: "B/2", tag.OrigClOrdID: "B/1"}, nil},
+		{"unknown desk", quickfix.SessionID{TargetCompID: "OTHER"}, "D", map[quickfix.Tag]string{tag.ClOrdID: "1"}, nil, ErrUnknownDesk},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFloor(t)
+			if err := f.router.Process(message(tt.msgType, tt.fields), tt.from); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			var want []delivery
+			if tt.want != nil {
+				want = []delivery{{exchange, tt.want}}
+			}
+			if !reflect.DeepEqual(f.sent, want) {
+				t.Errorf("got %v, want %v", f.sent, want)
+			}
+		})
+	}
+}
+
+func TestReaddress(t *testing.T) {
+	tests := []struct {
+		name   string
+		header map[quickfix.Tag]string
+		want   map[quickfix.Tag]string
+	}{
+		{"session fields dropped", map[quickfix.Tag]string{tag.MsgSeqNum: "7"}, map[quickfix.Tag]string{}},
+		{"possible duplicate", map[quickfix.Tag]string{tag.PossDupFlag: "Y", tag.OrigSendingTime: "20260101-01:00:00.000"},
+			map[quickfix.Tag]string{tag.PossDupFlag: "Y", tag.OrigSendingTime: "20260101-01:00:00.000"}},
+		{"possible resend", map[quickfix.Tag]string{tag.PossResend: "Y"}, map[quickfix.Tag]string{tag.PossResend: "Y"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := message("D", map[quickfix.Tag]string{tag.ClOrdID: "1"})
+			for k, v := range tt.header {
+				msg.Header.SetString(k, v)
+			}
+			cp := readdress(msg, "D")
+			got := make(map[quickfix.Tag]string)
+			for _, k := range []quickfix.Tag{tag.SenderCompID, tag.MsgSeqNum, tag.PossDupFlag, tag.PossResend, tag.OrigSendingTime} {
+				if v, err := cp.Header.GetString(k); err == nil {
+					got[k] = v
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromDeskUnsupported(t *testing.T) {
+	f := newFloor(t)
+	// an OrderMassCancelRequest would cancel the orders of every desk
+	err := f.router.Process(message("q", map[quickfix.Tag]string{tag.ClOrdID: "1"}), deskA)
+	var reject *businessreject.Error
+	if !errors.As(err, &reject) || reject.Reason != enum.BusinessRejectReason_UNSUPPORTED_MESSAGE_TYPE {
+		t.Errorf("got %v, want an unsupported MsgType reject", err)
+	}
+	if len(f.sent) != 0 {
+		t.Errorf("got %v sent", f.sent)
+	}
+}
+
+func TestAddDesk(t *testing.T) {
+	r := New(exchange)
+	for _, id := range []string{"", "A/B"} {
+		if err := r.AddDesk(id, deskA); !errors.Is(err, ErrInvalidDeskID) {
+			t.Errorf("AddDesk(%q) = %v, want %v", id, err, ErrInvalidDeskID)
+		}
+	}
+}
+
+func TestFromExchange(t *testing.T) {
+	tests := []struct {
+		name string
+		// msgs are processed in order, the deliveries of the last one are checked
+		msgs       []*quickfix.Message
+		want       []delivery
+		unroutable bool
+	}{
+		{"report", []*quickfix.Message{message("8", map[quickfix.Tag]string{tag.ClOrdID: "A/1", tag.OrderID: "X1"})},
+			[]delivery{{deskA, map[quickfix.Tag]string{tag.ClOrdID: "1"}}}, false},
+		{"cancel reject", []*quickfix.Message{message("9", map[quickfix.Tag]string{tag.ClOrdID: "B/2", tag.OrigClOrdID: "B/1"})},
+			[]delivery{{deskB, map[quickfix.Tag]string{tag.ClOrdID: "2", tag.OrigClOrdID: "1"}}}, false},
+		{"business reject", []*quickfix.Message{message("j", map[quickfix.Tag]string{tag.BusinessRejectRefID: "A/1"})},
+			[]delivery{{deskA, map[quickfix.Tag]string{tag.BusinessRejectRefID: "1"}}}, false},
+		{"report by OrderID", []*quickfix.Message{
+			message("8", map[quickfix.Tag]string{tag.ClOrdID: "B/1", tag.OrderID: "X1"}),
+			message("8", map[quickfix.Tag]string{tag.OrderID: "X1"}),
+		}, []delivery{{deskB, map[quickfix.Tag]string{}}}, false},
+		{"unknown desk", []*quickfix.Message{message("8", map[quickfix.Tag]string{tag.ClOrdID: "C/1"})}, nil, true},
+		{"not namespaced", []*quickfix.Message{message("8", map[quickfix.Tag]string{tag.ClOrdID: "1"})}, nil, true},
+		{"unroutable MsgType", []*quickfix.Message{message("AE", nil)}, nil, true},
+		{"broadcast", []*quickfix.Message{message("h", nil)},
+			[]delivery{{deskA, map[quickfix.Tag]string{}}, {deskB, map[quickfix.Tag]string{}}}, false},
+		{"market data of a subscribed symbol", []*quickfix.Message{message("SI", map[quickfix.Tag]string{tag.Symbol: "VND"})},
+			[]delivery{{deskA, map[quickfix.Tag]string{}}, {deskB, map[quickfix.Tag]string{}}}, false},
+		{"market data of another symbol", []*quickfix.Message{message("SI", map[quickfix.Tag]string{tag.Symbol: "SHB"})},
+			[]delivery{{deskA, map[quickfix.Tag]string{}}}, false},
+		{"market data without symbol", []*quickfix.Message{message("BI", nil)},
+			[]delivery{{deskA, map[quickfix.Tag]string{}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFloor(t)
+			// desk A receives all the market data, desk B that of VND
+			if err := f.router.Subscribe("A"); err != nil {
+				t.Fatal(err)
+			}
+			if err := f.router.Subscribe("B", "VND"); err != nil {
+				t.Fatal(err)
+			}
+			for _, msg := range tt.msgs {
+				f.sent = nil
+				if err := f.router.Process(msg, exchange); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got := f.sent
+			// the broadcasts are sent in no particular order
+			if len(got) == 2 && got[0].sessionID == deskB {
+				got[0], got[1] = got[1], got[0]
+			}
+			if len(got) != len(tt.want) || len(tt.want) > 0 && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if tt.unroutable != (len(f.unroutable) == 1 && errors.Is(f.unroutable[0], ErrUnroutable)) {
+				t.Errorf("got unroutable %v", f.unroutable)
+			}
+		})
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	f := newFloor(t)
+	if err := f.router.Subscribe("B", "VND", "SHB"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.router.Unsubscribe("B", "VND"); err != nil {
+		t.Fatal(err)
+	}
+	for _, symbol := range []string{"VND", "SHB"} {
+		if err := f.router.Process(message("SI", map[quickfix.Tag]string{tag.Symbol: symbol}), exchange); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(f.sent) != 1 || f.sent[0].sessionID != deskB {
+		t.Errorf("got %v, want the SHB data sent to desk B", f.sent)
+	}
+	if err := f.router.Unsubscribe("C"); !errors.Is(err, ErrUnknownDesk) {
+		t.Errorf("got %v, want %v", err, ErrUnknownDesk)
+	}
+}
@@ -0,0 +1,199 @@
+package routing
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/fix44"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+var (
+	// ErrNoDeliverTo is returned for a message received by a hub without DeliverToCompID
+	ErrNoDeliverTo = errors.New("routing: no DeliverToCompID")
+	// ErrUnknownDeliverTo is returned for a DeliverToCompID the hub has no session to
+	ErrUnknownDeliverTo = errors.New("routing: unknown DeliverToCompID")
+	// ErrNoOnBehalfOf is returned when replying to a message without OnBehalfOfCompID
+	ErrNoOnBehalfOf = errors.New("routing: no OnBehalfOfCompID")
+)
+
+// Route is the third party routing of a message
+type Route struct {
+	OnBehalfOfCompID     string
+	OnBehalfOfSubID      string
+	OnBehalfOfLocationID string
+	DeliverToCompID      string
+	DeliverToSubID       string
+	DeliverToLocationID  string
+}
+
+// RouteOf returns the third party routing fields of msg
+func RouteOf(msg *quickfix.Message) Route {
+	var r Route
+	h := fix44.Header{Header: &msg.Header}
+	r.OnBehalfOfCompID, _ = h.GetOnBehalfOfCompID()
+	r.OnBehalfOfSubID, _ = h.GetOnBehalfOfSubID()
+	r.OnBehalfOfLocationID, _ = h.GetOnBehalfOfLocationID()
+	r.DeliverToCompID, _ = h.GetDeliverToCompID()
+	r.DeliverToSubID, _ = h.GetDeliverToSubID()
+	r.DeliverToLocationID, _ = h.GetDeliverToLocationID()
+	return r
+}
+
+// setOrRemove sets the header field, or removes it if v is empty
+func setOrRemove(msg *quickfix.Message, t quickfix.Tag, v string) {
+	if v == "" {
+		msg.Header.Remove(t)
+		return
+	}
+	msg.Header.SetString(t, v)
+}
+
+func firstOf(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
+
+// SetDeliverTo addresses msg, sent to a hub, to a firm behind the hub
+func SetDeliverTo(msg *quickfix.Message, compID, subID, locationID string) {
+	setOrRemove(msg, tag.DeliverToCompID, compID)
+	setOrRemove(msg, tag.DeliverToSubID, subID)
+	setOrRemove(msg, tag.DeliverToLocationID, locationID)
+}
+
+// Reply addresses reply, answering received, back to the firm on behalf of which
+// received was sent by a hub
+func Reply(received, reply *quickfix.Message) error {
+	r := RouteOf(received)
+	if r.OnBehalfOfCompID == "" {
+		return ErrNoOnBehalfOf
+	}
+	SetDeliverTo(reply, r.OnBehalfOfCompID, r.OnBehalfOfSubID, r.OnBehalfOfLocationID)
+	return nil
+}
+
+// Hop is a third party a message went through
+type Hop struct {
+	CompID      string
+	SendingTime time.Time
+	RefID       int
+}
+
+// Hops returns the NoHops entries of msg
+func Hops(msg *quickfix.Message) []Hop {
+	g, err := fix44.Header{Header: &msg.Header}.GetNoHops()
+	if err != nil {
+		return nil
+	}
+	var hops []Hop
+	for _, row := range g.All() {
+		var hop Hop
+		hop.CompID, _ = row.GetHopCompID()
+		hop.SendingTime, _ = row.GetHopSendingTime()
+		hop.RefID, _ = row.GetHopRefID()
+		hops = append(hops, hop)
+	}
+	return hops
+}
+
+// Forward returns the copy of msg, received by a hub, to send on the to
+// session, readdressed per the FIX 4.4 third party routing:
+//   - the sender of msg becomes the OnBehalfOf, unless msg was already sent on behalf of a firm,
+//     in which case the sender, a previous hub, is appended to NoHops
+//   - the DeliverTo of msg becomes the target
+//   - the session fields are cleared to be set again when sent
+func Forward(msg *quickfix.Message, to quickfix.SessionID) *quickfix.Message {
+	fwd := quickfix.NewMessage()
+	msg.CopyInto(fwd)
+	r := RouteOf(msg)
+	h := fix44.Header{Header: &fwd.Header}
+	senderCompID, _ := h.GetSenderCompID()
+	senderSubID, _ := h.GetSenderSubID()
+	senderLocationID, _ := h.GetSenderLocationID()
+
+	if r.OnBehalfOfCompID == "" {
+		setOrRemove(fwd, tag.OnBehalfOfCompID, senderCompID)
+		setOrRemove(fwd, tag.OnBehalfOfSubID, senderSubID)
+		setOrRemove(fwd, tag.OnBehalfOfLocationID, senderLocationID)
+	} else {
+		g, err := h.GetNoHops()
+		if err != nil {
+			g = fix44.NewNoHopsRepeatingGroup()
+		}
+		hop := g.Add()
+		hop.SetHopCompID(senderCompID)
+		if t, err := h.GetSendingTime(); err == nil {
+			hop.SetHopSendingTime(t)
+		}
+		if seqNum, err := h.GetMsgSeqNum(); err == nil {
+			hop.SetHopRefID(seqNum)
+		}
+		h.SetNoHops(g)
+	}
+
+	setOrRemove(fwd, tag.SenderCompID, to.SenderCompID)
+	setOrRemove(fwd, tag.SenderSubID, to.SenderSubID)
+	setOrRemove(fwd, tag.SenderLocationID, to.SenderLocationID)
+	setOrRemove(fwd, tag.TargetCompID, to.TargetCompID)
+	setOrRemove(fwd, tag.TargetSubID, firstOf(r.DeliverToSubID, to.TargetSubID))
+	setOrRemove(fwd, tag.TargetLocationID, firstOf(r.DeliverToLocationID, to.TargetLocationID))
+	SetDeliverTo(fwd, "", "", "")
+
+	for _, t := range []quickfix.Tag{tag.BodyLength, tag.MsgSeqNum, tag.SendingTime, tag.PossDupFlag, tag.PossResend, tag.OrigSendingTime, tag.CheckSum} {
+		fwd.Header.Remove(t)
+	}
+	fwd.Trailer.Remove(tag.CheckSum)
+	return fwd
+}
+
+// Hub forwards the messages received with a DeliverToCompID to the session of that firm.
+// A Hub is safe for concurrent use.
+type Hub struct {
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+
+	mu       sync.Mutex
+	sessions map[string]quickfix.SessionID
+}
+
+// NewHub returns a Hub without sessions
+func NewHub() *Hub {
+	return &Hub{Send: quickfix.SendToTarget, sessions: make(map[string]quickfix.SessionID)}
+}
+
+// AddSession routes the messages delivered to the TargetCompID of the session to it,
+// it is meant to be called from OnLogon
+func (h *Hub) AddSession(sessionID quickfix.SessionID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sessions[sessionID.TargetCompID] = sessionID
+}
+
+// RemoveSession stops routing to the session, it is meant to be called from OnLogout
+func (h *Hub) RemoveSession(sessionID quickfix.SessionID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.sessions[sessionID.TargetCompID] == sessionID {
+		delete(h.sessions, sessionID.TargetCompID)
+	}
+}
+
+// Forward forwards msg to the session of its DeliverToCompID, it is meant to be called
+// from FromApp. ErrNoDeliverTo is returned for a message addressed to the hub itself.
+func (h *Hub) Forward(msg *quickfix.Message) error {
+	deliverTo := RouteOf(msg).DeliverToCompID
+	if deliverTo == "" {
+		return ErrNoDeliverTo
+	}
+	h.mu.Lock()
+	to, ok := h.sessions[deliverTo]
+	h.mu.Unlock()
+	if !ok {
+		return ErrUnknownDeliverTo
+	}
+	return h.Send(Forward(msg, to), to)
+}
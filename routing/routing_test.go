@@ -0,0 +1,131 @@
+package routing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/newordersingle"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// received returns an order received by the hub HUB from FIRM, delivered to deliverTo
+func received(deliverTo string) *quickfix.Message {
+	msg := newordersingle.New(field.NewClOrdID("C1"), field.NewSide(enum.Side_BUY), field.NewTransactTime(time.Now()),
+		field.NewOrdType(enum.OrdType_LIMIT)).ToMessage()
+	msg.Header.SetString(tag.SenderCompID, "FIRM")
+	msg.Header.SetString(tag.SenderSubID, "DESK")
+	msg.Header.SetString(tag.TargetCompID, "HUB")
+	msg.Header.SetInt(tag.MsgSeqNum, 5)
+	msg.Header.SetString(tag.SendingTime, "20260101-01:00:00")
+	if deliverTo != "" {
+		SetDeliverTo(msg, deliverTo, "TRADER", "")
+	}
+	return msg
+}
+
+func header(msg *quickfix.Message, t quickfix.Tag) string {
+	v, _ := msg.Header.GetString(t)
+	return v
+}
+
+func TestForward(t *testing.T) {
+	to := quickfix.SessionID{SenderCompID: "HUB", TargetCompID: "BROKER"}
+	msg := received("BROKER")
+	fwd := Forward(msg, to)
+
+	want := map[quickfix.Tag]string{
+		tag.SenderCompID:     "HUB",
+		tag.TargetCompID:     "BROKER",
+		tag.TargetSubID:      "TRADER",
+		tag.OnBehalfOfCompID: "FIRM",
+		tag.OnBehalfOfSubID:  "DESK",
+		tag.SenderSubID:      "",
+		tag.DeliverToCompID:  "",
+		tag.DeliverToSubID:   "",
+		tag.MsgSeqNum:        "",
+		tag.SendingTime:      "",
+	}
+	for k, v := range want {
+		if got := header(fwd, k); got != v {
+			t.Errorf("got tag %d = %q, want %q", k, got, v)
+		}
+	}
+	if len(Hops(fwd)) != 0 {
+		t.Errorf("got hops %v on the first hop", Hops(fwd))
+	}
+	if header(msg, tag.DeliverToCompID) != "BROKER" || header(msg, tag.SenderCompID) != "FIRM" {
+		t.Error("the received message was changed")
+	}
+
+	// a second hub keeps the OnBehalfOf and records the first one as a hop
+	fwd.Header.SetString(tag.SenderCompID, "HUB")
+	fwd.Header.SetInt(tag.MsgSeqNum, 9)
+	fwd.Header.SetString(tag.SendingTime, "20260101-01:00:01")
+	SetDeliverTo(fwd, "EXCHANGE", "", "")
+	fwd2 := Forward(fwd, quickfix.SessionID{SenderCompID: "HUB2", TargetCompID: "EXCHANGE"})
+	if got := header(fwd2, tag.OnBehalfOfCompID); got != "FIRM" {
+		t.Errorf("got OnBehalfOfCompID %q", got)
+	}
+	hops := Hops(fwd2)
+	if len(hops) != 1 || hops[0].CompID != "HUB" || hops[0].RefID != 9 {
+		t.Errorf("got hops %+v", hops)
+	}
+
+	// the reply goes back to the firm the order was sent on behalf of
+	reply := quickfix.NewMessage()
+	if err := Reply(fwd2, reply); err != nil {
+		t.Fatal(err)
+	}
+	if header(reply, tag.DeliverToCompID) != "FIRM" || header(reply, tag.DeliverToSubID) != "DESK" {
+		t.Errorf("got reply DeliverTo %q/%q", header(reply, tag.DeliverToCompID), header(reply, tag.DeliverToSubID))
+	}
+	if err := Reply(msg, reply); !errors.Is(err, ErrNoOnBehalfOf) {
+		t.Errorf("got %v, want %v", err, ErrNoOnBehalfOf)
+	}
+}
+
+func TestHubForward(t *testing.T) {
+	broker := quickfix.SessionID{SenderCompID: "HUB", TargetCompID: "BROKER"}
+	tests := []struct {
+		name      string
+		deliverTo string
+		sessions  []quickfix.SessionID
+		removed   []quickfix.SessionID
+		want      error
+	}{
+		{"forwarded", "BROKER", []quickfix.SessionID{broker}, nil, nil},
+		{"no DeliverTo", "", []quickfix.SessionID{broker}, nil, ErrNoDeliverTo},
+		{"unknown DeliverTo", "OTHER", []quickfix.SessionID{broker}, nil, ErrUnknownDeliverTo},
+		{"session removed", "BROKER", []quickfix.SessionID{broker}, []quickfix.SessionID{broker}, ErrUnknownDeliverTo},
+		{"other session removed", "BROKER", []quickfix.SessionID{broker},
+			[]quickfix.SessionID{{SenderCompID: "HUB2", TargetCompID: "BROKER"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHub()
+			var sentTo []quickfix.SessionID
+			h.Send = func(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+				sentTo = append(sentTo, sessionID)
+				return nil
+			}
+			for _, s := range tt.sessions {
+				h.AddSession(s)
+			}
+			for _, s := range tt.removed {
+				h.RemoveSession(s)
+			}
+			if err := h.Forward(received(tt.deliverTo)); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && (len(sentTo) != 1 || sentTo[0] != broker) {
+				t.Errorf("got sent to %v", sentTo)
+			} else if tt.want != nil && len(sentTo) != 0 {
+				t.Errorf("got sent to %v", sentTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+package secmaster
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/derivativesecuritylist"
+	"github.com/quickfixgo/fix44/hnxinfogate"
+	"github.com/quickfixgo/fix44/securitydefinition"
+	"github.com/quickfixgo/fix44/securitydefinitionrequest"
+	"github.com/quickfixgo/fix44/securitylist"
+	"github.com/quickfixgo/fix44/securitylistrequest"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoSymbol is returned for a security that has neither a Symbol
+// nor a SecurityID already known by the Master
+var ErrNoSymbol = errors.New("secmaster: security without Symbol")
+
+// RequestError is a SecurityList or SecurityDefinition answering a request with a failure
+type RequestError struct {
+	SecurityReqID string
+	// Result is the SecurityRequestResult of a SecurityList or
+	// the SecurityResponseType of a SecurityDefinition
+	Result string
+	Text   string
+}
+
+func (e *RequestError) Error() string {
+	s := fmt.Sprintf("secmaster: request %s failed with result %s", e.SecurityReqID, e.Result)
+	if e.Text != "" {
+		s += ": " + e.Text
+	}
+	return s
+}
+
+type altID struct {
+	source, id string
+}
+
+// Master merges reference data from SecurityList, DerivativeSecurityList, SecurityDefinition
+// and the InfoGate StockInfo and DerivativeInfo into one Security per Symbol.
+// A Master is safe for concurrent use.
+type Master struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// OnChange, if set, is called with a copy of a security each time it changes,
+	// the bool is false when it was deleted
+	OnChange func(s Security, exists bool)
+	// OnComplete, if set, is called when the last fragment of the answer to a request is received.
+	// OnChange and OnComplete are called once the Master is unlocked, so they can call the Master.
+	OnComplete func(securityReqID string)
+
+	mu       sync.Mutex
+	nextID   int
+	pending  map[string]bool
+	bySymbol map[string]*Security
+	byID     map[string]string
+	byAltID  map[altID]string
+	idPrefix string
+}
+
+// New returns an empty Master, requests are sent on the session
+func New(sessionID quickfix.SessionID) *Master {
+	return &Master{
+		SessionID: sessionID,
+		Send:      quickfix.SendToTarget,
+		pending:   make(map[string]bool),
+		bySymbol:  make(map[string]*Security),
+		byID:      make(map[string]string),
+		byAltID:   make(map[altID]string),
+		idPrefix:  time.Now().Format("150405") + "-",
+	}
+}
+
+func (m *Master) newReqID() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	id := fmt.Sprintf("%s%d", m.idPrefix, m.nextID)
+	m.pending[id] = true
+	return id
+}
+
+// RequestSecurityList asks for the list of all securities, it returns the SecurityReqID
+func (m *Master) RequestSecurityList() (string, error) {
+	id := m.newReqID()
+	req := securitylistrequest.New(field.NewSecurityReqID(id), field.NewSecurityListRequestType(enum.SecurityListRequestType_ALL_SECURITIES))
+	req.SetSubscriptionRequestType(enum.SubscriptionRequestType_SNAPSHOT)
+	return id, m.send(id, req)
+}
+
+// RequestSecurityDefinition asks for the definition of a symbol, it returns the SecurityReqID
+func (m *Master) RequestSecurityDefinition(symbol string) (string, error) {
+	id := m.newReqID()
+	req := securitydefinitionrequest.New(field.NewSecurityReqID(id),
+		field.NewSecurityRequestType(enum.SecurityRequestType_REQUEST_SECURITY_IDENTITY_AND_SPECIFICATIONS))
+	req.SetSymbol(symbol)
+	return id, m.send(id, req)
+}
+
+func (m *Master) send(id string, req quickfix.Messagable) error {
+	if err := m.Send(req, m.SessionID); err != nil {
+		m.mu.Lock()
+		delete(m.pending, id)
+		m.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Pending returns the SecurityReqIDs still waiting for their last fragment, sorted
+func (m *Master) Pending() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.pending))
+	for id := range m.pending {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Process applies any of the reference data messages, other messages are ignored
+func (m *Master) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "y":
+		return m.OnSecurityList(securitylist.FromMessage(msg))
+	case "AA":
+		return m.OnDerivativeSecurityList(derivativesecuritylist.FromMessage(msg))
+	case "d":
+		return m.OnSecurityDefinition(securitydefinition.FromMessage(msg))
+	case "SI":
+		return m.OnStockInfo(hnxinfogate.FromMessageToStockInfo(msg))
+	case "DI":
+		return m.OnDerivativeInfo(hnxinfogate.FromMessageToDerivativeInfo(msg))
+	}
+	return nil
+}
+
+// updateAction returns the SecurityUpdateAction of a message body, UpdateModify if absent
+func updateAction(body *quickfix.Body) UpdateAction {
+	v, err := body.GetString(TagSecurityUpdateAction)
+	if err != nil || v == "" {
+		return UpdateModify
+	}
+	return UpdateAction(v)
+}
+
+// OnSecurityList applies the securities of a SecurityList
+func (m *Master) OnSecurityList(l securitylist.SecurityList) error {
+	reqID, _ := l.GetSecurityReqID()
+	if err := m.checkResult(l.GetSecurityRequestResult, reqID); err != nil {
+		return err
+	}
+	var securities []Security
+	if g, err := l.GetNoRelatedSym(); err == nil {
+		for _, e := range g.All() {
+			s := fromInstrument(e)
+			if alt, err := e.GetNoSecurityAltID(); err == nil {
+				for _, a := range alt.All() {
+					source, _ := a.GetSecurityAltIDSource()
+					id, _ := a.GetSecurityAltID()
+					addAltID(&s, source, id)
+				}
+			}
+			securities = append(securities, s)
+		}
+	}
+	lastFragment, err := l.GetLastFragment()
+	return m.apply(reqID, lastFragment || err != nil, updateAction(l.Body), securities)
+}
+
+// OnDerivativeSecurityList applies the securities of a DerivativeSecurityList
+func (m *Master) OnDerivativeSecurityList(l derivativesecuritylist.DerivativeSecurityList) error {
+	reqID, _ := l.GetSecurityReqID()
+	if err := m.checkResult(l.GetSecurityRequestResult, reqID); err != nil {
+		return err
+	}
+	var securities []Security
+	if g, err := l.GetNoRelatedSym(); err == nil {
+		for _, e := range g.All() {
+			s := fromInstrument(e)
+			if alt, err := e.GetNoSecurityAltID(); err == nil {
+				for _, a := range alt.All() {
+					source, _ := a.GetSecurityAltIDSource()
+					id, _ := a.GetSecurityAltID()
+					addAltID(&s, source, id)
+				}
+			}
+			securities = append(securities, s)
+		}
+	}
+	lastFragment, err := l.GetLastFragment()
+	return m.apply(reqID, lastFragment || err != nil, updateAction(l.Body), securities)
+}
+
+func (m *Master) checkResult(result func() (enum.SecurityRequestResult, quickfix.MessageRejectError), reqID string) error {
+	r, err := result()
+	if err != nil || r == enum.SecurityRequestResult_VALID_REQUEST {
+		return nil
+	}
+	m.mu.Lock()
+	delete(m.pending, reqID)
+	m.mu.Unlock()
+	return &RequestError{SecurityReqID: reqID, Result: string(r)}
+}
+
+// OnSecurityDefinition applies a SecurityDefinition
+func (m *Master) OnSecurityDefinition(d securitydefinition.SecurityDefinition) error {
+	reqID, _ := d.GetSecurityReqID()
+	responseType, _ := d.GetSecurityResponseType()
+	switch responseType {
+	case enum.SecurityResponseType_REJECT_SECURITY_PROPOSAL, enum.SecurityResponseType_CAN_NOT_MATCH_SELECTION_CRITERIA:
+		m.mu.Lock()
+		delete(m.pending, reqID)
+		m.mu.Unlock()
+		rejErr := &RequestError{SecurityReqID: reqID, Result: string(responseType)}
+		rejErr.Text, _ = d.GetText()
+		return rejErr
+	}
+	s := fromInstrument(d)
+	if alt, err := d.GetNoSecurityAltID(); err == nil {
+		for _, a := range alt.All() {
+			source, _ := a.GetSecurityAltIDSource()
+			id, _ := a.GetSecurityAltID()
+			addAltID(&s, source, id)
+		}
+	}
+	return m.apply(reqID, true, updateAction(d.Body), []Security{s})
+}
+
+// OnStockInfo merges the reference fields of an InfoGate StockInfo
+func (m *Master) OnStockInfo(si hnxinfogate.StockInfo) error {
+	s, err := fromStockInfo(si)
+	if err != nil {
+		return err
+	}
+	return m.apply("", false, UpdateModify, []Security{s})
+}
+
+// OnDerivativeInfo merges the reference fields of an InfoGate DerivativeInfo
+func (m *Master) OnDerivativeInfo(di hnxinfogate.DerivativeInfo) error {
+	s, err := fromStockInfo(*di.StockInfo)
+	if err != nil {
+		return err
+	}
+	s.Underlying, _ = di.GetUnderlying()
+	return m.apply("", false, UpdateModify, []Security{s})
+}
+
+func fromStockInfo(si hnxinfogate.StockInfo) (Security, error) {
+	var s Security
+	var err quickfix.MessageRejectError
+	if s.Symbol, err = si.GetSymbol(); err != nil {
+		return s, err
+	}
+	if v, err := si.GetSecurityType(); err == nil {
+		s.SecurityType = enum.SecurityType(v)
+	}
+	s.BoardCode, _ = si.GetBoardCode()
+	s.Issuer, _ = si.GetIssuer()
+	s.SecurityDesc, _ = si.GetSecurityDesc()
+	s.IssueDate, _ = si.GetIssueDate()
+	s.MaturityDate, _ = si.GetMaturityDate()
+	if v, err := si.GetCouponRate(); err == nil {
+		s.CouponRate = decimal.NewFromFloat(v)
+	}
+	if v, err := si.GetParValue(); err == nil {
+		s.ParValue = decimal.NewFromFloat(v)
+	}
+	if v, err := si.GetTradingUnit(); err == nil {
+		s.TradingUnit = decimal.NewFromFloat(v)
+	}
+	if v, err := si.GetTotalListingQtty(); err == nil {
+		s.TotalListingQtty = decimal.NewFromFloat(v)
+	}
+	s.UpdatedAt = time.Now()
+	return s, nil
+}
+
+func addAltID(s *Security, source, id string) {
+	if id == "" {
+		return
+	}
+	if s.AltIDs == nil {
+		s.AltIDs = make(map[string]string)
+	}
+	s.AltIDs[source] = id
+}
+
+// apply merges or deletes securities, done tells that reqID got its last fragment
+func (m *Master) apply(reqID string, done bool, action UpdateAction, securities []Security) error {
+	type change struct {
+		s      Security
+		exists bool
+	}
+	var changes []change
+	changed := func(s *Security, exists bool) {
+		if m.OnChange != nil {
+			changes = append(changes, change{s.clone(), exists})
+		}
+	}
+
+	m.mu.Lock()
+	var errs []error
+	for _, u := range securities {
+		if u.Symbol == "" {
+			u.Symbol = m.byID[u.SecurityID]
+		}
+		if u.Symbol == "" {
+			errs = append(errs, ErrNoSymbol)
+			continue
+		}
+		s, exists := m.bySymbol[u.Symbol]
+		if exists {
+			m.unindex(s)
+		}
+		if action == UpdateDelete {
+			if exists {
+				delete(m.bySymbol, u.Symbol)
+				changed(s, false)
+			}
+			continue
+		}
+		if !exists {
+			s = &Security{Symbol: u.Symbol}
+			m.bySymbol[u.Symbol] = s
+		}
+		s.merge(u)
+		m.index(s)
+		changed(s, true)
+	}
+	complete := done && reqID != "" && m.pending[reqID]
+	if complete {
+		delete(m.pending, reqID)
+	}
+	m.mu.Unlock()
+	for _, c := range changes {
+		m.OnChange(c.s, c.exists)
+	}
+	if complete && m.OnComplete != nil {
+		m.OnComplete(reqID)
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Master) index(s *Security) {
+	if s.SecurityID != "" {
+		m.byID[s.SecurityID] = s.Symbol
+	}
+	for source, id := range s.AltIDs {
+		m.byAltID[altID{source, id}] = s.Symbol
+	}
+}
+
+func (m *Master) unindex(s *Security) {
+	if m.byID[s.SecurityID] == s.Symbol {
+		delete(m.byID, s.SecurityID)
+	}
+	for source, id := range s.AltIDs {
+		if k := (altID{source, id}); m.byAltID[k] == s.Symbol {
+			delete(m.byAltID, k)
+		}
+	}
+}
+
+// BySymbol returns a copy of the security with the Symbol
+func (m *Master) BySymbol(symbol string) (Security, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.get(symbol)
+}
+
+// BySecurityID returns a copy of the security with the SecurityID
+func (m *Master) BySecurityID(securityID string) (Security, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.get(m.byID[securityID])
+}
+
+// BySecurityAltID returns a copy of the security with the SecurityAltID from the SecurityAltIDSource
+func (m *Master) BySecurityAltID(source, id string) (Security, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.get(m.byAltID[altID{source, id}])
+}
+
+func (m *Master) get(symbol string) (Security, bool) {
+	s, ok := m.bySymbol[symbol]
+	if !ok {
+		return Security{}, false
+	}
+	return s.clone(), true
+}
+
+// Securities returns copies of all the securities, sorted by Symbol
+func (m *Master) Securities() []Security {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	securities := make([]Security, 0, len(m.bySymbol))
+	for _, s := range m.bySymbol {
+		securities = append(securities, s.clone())
+	}
+	sort.Slice(securities, func(i, j int) bool { return securities[i].Symbol < securities[j].Symbol })
+	return securities
+}
@@ -0,0 +1,152 @@
+package secmaster
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/securitydefinition"
+	"github.com/quickfixgo/fix44/securitylist"
+	"github.com/quickfixgo/quickfix"
+	"github.com/shopspring/decimal"
+)
+
+// listing is a security of a SecurityList fragment
+type listing struct {
+	symbol, securityID, isin string
+	roundLot                 int64
+}
+
+// fragment returns a SecurityList fragment answering reqID
+func fragment(reqID string, last bool, action UpdateAction, securities ...listing) *quickfix.Message {
+	l := securitylist.New(field.NewSecurityReqID(reqID), field.NewSecurityResponseID("R"+reqID),
+		field.NewSecurityRequestResult(enum.SecurityRequestResult_VALID_REQUEST))
+	l.SetLastFragment(last)
+	g := securitylist.NewNoRelatedSymRepeatingGroup()
+	for _, s := range securities {
+		e := g.Add()
+		if s.symbol != "" {
+			e.SetSymbol(s.symbol)
+		}
+		if s.securityID != "" {
+			e.SetSecurityID(s.securityID)
+		}
+		if s.roundLot != 0 {
+			e.SetRoundLot(decimal.NewFromInt(s.roundLot), 0)
+		}
+		if s.isin != "" {
+			alt := securitylist.NewNoSecurityAltIDRepeatingGroup()
+			a := alt.Add()
+			a.SetSecurityAltID(s.isin)
+			a.SetSecurityAltIDSource("4")
+			e.SetNoSecurityAltID(alt)
+		}
+	}
+	l.SetNoRelatedSym(g)
+	msg := l.ToMessage()
+	if action != "" {
+		msg.Body.SetString(TagSecurityUpdateAction, string(action))
+	}
+	return msg
+}
+
+func TestSecurityList(t *testing.T) {
+	m := New(quickfix.SessionID{})
+	m.Send = func(quickfix.Messagable, quickfix.SessionID) error { return nil }
+	var changed []string
+	var completed []string
+	m.OnChange = func(s Security, exists bool) {
+		if !exists {
+			changed = append(changed, "-"+s.Symbol)
+			return
+		}
+		changed = append(changed, s.Symbol)
+	}
+	m.OnComplete = func(reqID string) { completed = append(completed, reqID) }
+	reqID, err := m.RequestSecurityList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		msg           *quickfix.Message
+		wantChanged   []string
+		wantCompleted []string
+	}{
+		{fragment(reqID, false, "", listing{"VND", "S1", "VN000VND", 100}, listing{"SHB", "S2", "", 100}), []string{"VND", "SHB"}, nil},
+		// a security known by its SecurityID only is merged into the one of its Symbol
+		{fragment(reqID, true, "", listing{"", "S1", "", 10}), []string{"VND"}, []string{reqID}},
+		{fragment("other", true, UpdateDelete, listing{"SHB", "", "", 0}, listing{"ACB", "", "", 0}), []string{"-SHB"}, nil},
+	}
+	for i, step := range steps {
+		changed, completed = nil, nil
+		if err := m.Process(step.msg); err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if !reflect.DeepEqual(changed, step.wantChanged) || !reflect.DeepEqual(completed, step.wantCompleted) {
+			t.Errorf("%d: got changed %v and completed %v, want %v and %v", i, changed, completed, step.wantChanged, step.wantCompleted)
+		}
+	}
+
+	if len(m.Pending()) != 0 {
+		t.Errorf("got pending %v", m.Pending())
+	}
+	s, ok := m.BySecurityAltID("4", "VN000VND")
+	if !ok || !s.RoundLot.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("got %+v, want VND with RoundLot 10", s)
+	}
+	if s, ok := m.BySecurityID("S1"); !ok || s.Symbol != "VND" {
+		t.Errorf("got %+v by SecurityID", s)
+	}
+	if _, ok := m.BySymbol("SHB"); ok {
+		t.Error("got the deleted SHB")
+	}
+	if _, ok := m.BySecurityID("S2"); ok {
+		t.Error("got the deleted SHB by SecurityID")
+	}
+	if err := m.Process(fragment("other", true, "", listing{"", "S9", "", 0})); !errors.Is(err, ErrNoSymbol) {
+		t.Errorf("got %v, want %v", err, ErrNoSymbol)
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	definition := func(responseType enum.SecurityResponseType) *quickfix.Message {
+		d := securitydefinition.New(field.NewSecurityReqID("Q1"), field.NewSecurityResponseID("R1"), field.NewSecurityResponseType(responseType))
+		d.SetSymbol("VND")
+		d.SetText("not listed")
+		return d.ToMessage()
+	}
+	rejectedList := securitylist.New(field.NewSecurityReqID("Q1"), field.NewSecurityResponseID("R1"),
+		field.NewSecurityRequestResult(enum.SecurityRequestResult_INVALID_OR_UNSUPPORTED_REQUEST)).ToMessage()
+	tests := []struct {
+		name string
+		msg  *quickfix.Message
+		want *RequestError
+	}{
+		{"accepted", definition(enum.SecurityResponseType_ACCEPT_SECURITY_PROPOSAL_AS_IS), nil},
+		{"rejected", definition(enum.SecurityResponseType_REJECT_SECURITY_PROPOSAL), &RequestError{"Q1", "5", "not listed"}},
+		{"no match", definition(enum.SecurityResponseType_CAN_NOT_MATCH_SELECTION_CRITERIA), &RequestError{"Q1", "6", "not listed"}},
+		{"invalid list request", rejectedList, &RequestError{"Q1", "1", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(quickfix.SessionID{})
+			err := m.Process(tt.msg)
+			var reqErr *RequestError
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("got %v", err)
+				}
+				if _, ok := m.BySymbol("VND"); !ok {
+					t.Error("VND not defined")
+				}
+				return
+			}
+			if !errors.As(err, &reqErr) || *reqErr != *tt.want {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
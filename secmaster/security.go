@@ -0,0 +1,174 @@
+package secmaster
+
+import (
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/quickfix"
+	"github.com/shopspring/decimal"
+)
+
+// Security is the reference data of an instrument, merged from
+// SecurityList, DerivativeSecurityList, SecurityDefinition and InfoGate StockInfo
+type Security struct {
+	Symbol           string
+	SecurityID       string
+	SecurityIDSource enum.SecurityIDSource
+	// AltIDs maps SecurityAltIDSource to SecurityAltID
+	AltIDs           map[string]string
+	Product          enum.Product
+	CFICode          string
+	SecurityType     enum.SecurityType
+	SecuritySubType  string
+	SecurityDesc     string
+	SecurityExchange string
+	Issuer           string
+	IssueDate        string
+	MaturityDate     string
+	Currency         string
+
+	ContractMultiplier decimal.Decimal
+	StrikePrice        decimal.Decimal
+	CouponRate         decimal.Decimal
+	RoundLot           decimal.Decimal
+	MinTradeVol        decimal.Decimal
+
+	// BoardCode, ParValue, TradingUnit, TotalListingQtty and Underlying come from HNX InfoGate
+	BoardCode        string
+	ParValue         decimal.Decimal
+	TradingUnit      decimal.Decimal
+	TotalListingQtty decimal.Decimal
+	Underlying       string
+
+	UpdatedAt time.Time
+}
+
+// UpdateAction tells how a received Security changes the master
+type UpdateAction string
+
+const (
+	// UpdateAdd and UpdateModify merge the received fields into the known Security
+	UpdateAdd    UpdateAction = "A"
+	UpdateModify UpdateAction = "M"
+	// UpdateDelete removes the Security
+	UpdateDelete UpdateAction = "D"
+)
+
+// TagSecurityUpdateAction is the SecurityUpdateAction field, it is not part of FIX 4.4
+// but some venues send it in the body of SecurityList and SecurityDefinition
+const TagSecurityUpdateAction quickfix.Tag = 980
+
+// merge copies the non empty fields of u into s
+func (s *Security) merge(u Security) {
+	setString(&s.SecurityID, u.SecurityID)
+	if u.SecurityIDSource != "" {
+		s.SecurityIDSource = u.SecurityIDSource
+	}
+	for source, id := range u.AltIDs {
+		if s.AltIDs == nil {
+			s.AltIDs = make(map[string]string)
+		}
+		s.AltIDs[source] = id
+	}
+	if u.Product != "" {
+		s.Product = u.Product
+	}
+	setString(&s.CFICode, u.CFICode)
+	if u.SecurityType != "" {
+		s.SecurityType = u.SecurityType
+	}
+	setString(&s.SecuritySubType, u.SecuritySubType)
+	setString(&s.SecurityDesc, u.SecurityDesc)
+	setString(&s.SecurityExchange, u.SecurityExchange)
+	setString(&s.Issuer, u.Issuer)
+	setString(&s.IssueDate, u.IssueDate)
+	setString(&s.MaturityDate, u.MaturityDate)
+	setString(&s.Currency, u.Currency)
+	setDecimal(&s.ContractMultiplier, u.ContractMultiplier)
+	setDecimal(&s.StrikePrice, u.StrikePrice)
+	setDecimal(&s.CouponRate, u.CouponRate)
+	setDecimal(&s.RoundLot, u.RoundLot)
+	setDecimal(&s.MinTradeVol, u.MinTradeVol)
+	setString(&s.BoardCode, u.BoardCode)
+	setDecimal(&s.ParValue, u.ParValue)
+	setDecimal(&s.TradingUnit, u.TradingUnit)
+	setDecimal(&s.TotalListingQtty, u.TotalListingQtty)
+	setString(&s.Underlying, u.Underlying)
+	s.UpdatedAt = u.UpdatedAt
+}
+
+func setString(dst *string, v string) {
+	if v != "" {
+		*dst = v
+	}
+}
+
+func setDecimal(dst *decimal.Decimal, v decimal.Decimal) {
+	if !v.IsZero() {
+		*dst = v
+	}
+}
+
+func (s *Security) clone() Security {
+	c := *s
+	if s.AltIDs != nil {
+		c.AltIDs = make(map[string]string, len(s.AltIDs))
+		for k, v := range s.AltIDs {
+			c.AltIDs[k] = v
+		}
+	}
+	return c
+}
+
+// instrument holds the getters of the Instrument component shared by the
+// SecurityList and DerivativeSecurityList entries and the SecurityDefinition
+type instrument interface {
+	GetSymbol() (string, quickfix.MessageRejectError)
+	GetSecurityID() (string, quickfix.MessageRejectError)
+	GetSecurityIDSource() (enum.SecurityIDSource, quickfix.MessageRejectError)
+	GetProduct() (enum.Product, quickfix.MessageRejectError)
+	GetCFICode() (string, quickfix.MessageRejectError)
+	GetSecurityType() (enum.SecurityType, quickfix.MessageRejectError)
+	GetSecuritySubType() (string, quickfix.MessageRejectError)
+	GetSecurityDesc() (string, quickfix.MessageRejectError)
+	GetSecurityExchange() (string, quickfix.MessageRejectError)
+	GetIssuer() (string, quickfix.MessageRejectError)
+	GetIssueDate() (string, quickfix.MessageRejectError)
+	GetMaturityDate() (string, quickfix.MessageRejectError)
+	GetCurrency() (string, quickfix.MessageRejectError)
+	GetContractMultiplier() (decimal.Decimal, quickfix.MessageRejectError)
+	GetStrikePrice() (decimal.Decimal, quickfix.MessageRejectError)
+	GetCouponRate() (decimal.Decimal, quickfix.MessageRejectError)
+}
+
+// lots is implemented by the instruments that carry trading lot sizes
+type lots interface {
+	GetRoundLot() (decimal.Decimal, quickfix.MessageRejectError)
+	GetMinTradeVol() (decimal.Decimal, quickfix.MessageRejectError)
+}
+
+func fromInstrument(i instrument) Security {
+	var s Security
+	s.Symbol, _ = i.GetSymbol()
+	s.SecurityID, _ = i.GetSecurityID()
+	s.SecurityIDSource, _ = i.GetSecurityIDSource()
+	s.Product, _ = i.GetProduct()
+	s.CFICode, _ = i.GetCFICode()
+	s.SecurityType, _ = i.GetSecurityType()
+	s.SecuritySubType, _ = i.GetSecuritySubType()
+	s.SecurityDesc, _ = i.GetSecurityDesc()
+	s.SecurityExchange, _ = i.GetSecurityExchange()
+	s.Issuer, _ = i.GetIssuer()
+	s.IssueDate, _ = i.GetIssueDate()
+	s.MaturityDate, _ = i.GetMaturityDate()
+	s.Currency, _ = i.GetCurrency()
+	s.ContractMultiplier, _ = i.GetContractMultiplier()
+	s.StrikePrice, _ = i.GetStrikePrice()
+	s.CouponRate, _ = i.GetCouponRate()
+	if l, ok := i.(lots); ok {
+		s.RoundLot, _ = l.GetRoundLot()
+		s.MinTradeVol, _ = l.GetMinTradeVol()
+	}
+	s.UpdatedAt = time.Now()
+	return s
+}
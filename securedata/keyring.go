@@ -0,0 +1,116 @@
+package securedata
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrUnknownKey is returned for a key ID not in the Keyring
+	ErrUnknownKey = errors.New("securedata: unknown key")
+	// ErrNoCurrentKey is returned when sealing with a Keyring without current key
+	ErrNoCurrentKey = errors.New("securedata: no current key")
+	// ErrInvalidKeyID is returned for an empty key ID or one containing ':'
+	ErrInvalidKeyID = errors.New("securedata: invalid key ID")
+)
+
+// Keyring holds the pre-shared AES keys by ID. Messages are sealed with the current key
+// and opened with the key named in their SecureData, so keys are rotated by adding the new
+// key on both sides, making it current, then removing the old key once no message in
+// flight uses it. A Keyring is safe for concurrent use.
+type Keyring struct {
+	mu      sync.RWMutex
+	keys    map[string]cipher.AEAD
+	current string
+}
+
+// NewKeyring returns an empty Keyring
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string]cipher.AEAD)}
+}
+
+// Add adds an AES-128, AES-192 or AES-256 key, the first key added becomes current
+func (k *Keyring) Add(id string, key []byte) error {
+	if id == "" || strings.Contains(id, ":") {
+		return ErrInvalidKeyID
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[id] = aead
+	if k.current == "" {
+		k.current = id
+	}
+	return nil
+}
+
+// SetCurrent makes the key with the ID the one sealing the messages
+func (k *Keyring) SetCurrent(id string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, ok := k.keys[id]; !ok {
+		return ErrUnknownKey
+	}
+	k.current = id
+	return nil
+}
+
+// Remove removes a key, the current key cannot be removed
+func (k *Keyring) Remove(id string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if id == k.current {
+		return errors.New("securedata: cannot remove the current key")
+	}
+	delete(k.keys, id)
+	return nil
+}
+
+// Current returns the ID of the current key
+func (k *Keyring) Current() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.current
+}
+
+// IDs returns the sorted key IDs
+func (k *Keyring) IDs() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (k *Keyring) currentKey() (string, cipher.AEAD, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.current == "" {
+		return "", nil, ErrNoCurrentKey
+	}
+	return k.current, k.keys[k.current], nil
+}
+
+func (k *Keyring) key(id string) (cipher.AEAD, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	aead, ok := k.keys[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return aead, nil
+}
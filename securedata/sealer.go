@@ -0,0 +1,235 @@
+package securedata
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/quickfixgo/fix44"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// ErrInvalidSecureData is returned for a SecureData that cannot be decoded or decrypted
+var ErrInvalidSecureData = errors.New("securedata: invalid SecureData")
+
+// sessionRejectReasonDecryption is the SessionRejectReason 7: decryption problem
+const sessionRejectReasonDecryption = 7
+
+// Placeholder replaces the sealed values of the group fields, which cannot be removed
+// without breaking the group
+const Placeholder = "*"
+
+// GroupFields are sealed fields of the entries of a repeating group
+type GroupFields struct {
+	// NewGroup returns an empty repeating group with the template of the group
+	NewGroup func() *quickfix.RepeatingGroup
+	Tags     []quickfix.Tag
+}
+
+// Parties returns an empty NoPartyIDs repeating group of the Parties component
+func Parties() *quickfix.RepeatingGroup {
+	return quickfix.NewRepeatingGroup(tag.NoPartyIDs, quickfix.GroupTemplate{
+		quickfix.GroupElement(tag.PartyID),
+		quickfix.GroupElement(tag.PartyIDSource),
+		quickfix.GroupElement(tag.PartyRole),
+		quickfix.NewRepeatingGroup(tag.NoPartySubIDs, quickfix.GroupTemplate{
+			quickfix.GroupElement(tag.PartySubID),
+			quickfix.GroupElement(tag.PartySubIDType),
+		}),
+	})
+}
+
+// Sealer moves sensitive fields of the messages sent into the Header SecureData,
+// encrypted with AES-GCM, and restores them in the messages received.
+// Messages are bound to their MsgType, SenderCompID, TargetCompID and MsgSeqNum,
+// so that a SecureData cannot be replayed in another message.
+// When also signing, seal before signing and verify before opening.
+type Sealer struct {
+	Keys *Keyring
+	// Tags are body fields removed from the messages sent
+	Tags []quickfix.Tag
+	// Groups are group fields replaced by the Placeholder in the messages sent
+	Groups []GroupFields
+}
+
+// New returns a Sealer of Account and of the PartyIDs of the Parties component
+func New(keys *Keyring) *Sealer {
+	return &Sealer{
+		Keys:   keys,
+		Tags:   []quickfix.Tag{tag.Account},
+		Groups: []GroupFields{{NewGroup: Parties, Tags: []quickfix.Tag{tag.PartyID}}},
+	}
+}
+
+// additionalData binds the SecureData to the message
+func additionalData(msg *quickfix.Message) []byte {
+	msgType, _ := msg.Header.GetString(tag.MsgType)
+	sender, _ := msg.Header.GetString(tag.SenderCompID)
+	target, _ := msg.Header.GetString(tag.TargetCompID)
+	seqNum, _ := msg.Header.GetString(tag.MsgSeqNum)
+	return []byte(msgType + "|" + sender + "|" + target + "|" + seqNum)
+}
+
+// Seal moves the sensitive fields of msg into its SecureData, a message already
+// carrying SecureData or without sensitive fields is left untouched.
+// The MsgSeqNum of msg must already be set, as it is in ToApp.
+func (s *Sealer) Seal(msg *quickfix.Message) error {
+	if msg.Header.Has(tag.SecureData) {
+		return nil
+	}
+	var plain []string
+	for _, t := range s.Tags {
+		if v, err := msg.Body.GetString(t); err == nil {
+			plain = append(plain, fmt.Sprintf("%d=%s", t, v))
+		}
+	}
+	type sealedGroup struct {
+		group *quickfix.RepeatingGroup
+		tags  []quickfix.Tag
+	}
+	var groups []sealedGroup
+	for _, gf := range s.Groups {
+		g := gf.NewGroup()
+		if !msg.Body.Has(g.Tag()) || msg.Body.GetGroup(g) != nil {
+			continue
+		}
+		for i := 0; i < g.Len(); i++ {
+			for _, t := range gf.Tags {
+				if v, err := g.Get(i).GetString(t); err == nil {
+					plain = append(plain, fmt.Sprintf("%d/%d/%d=%s", g.Tag(), i, t, v))
+				}
+			}
+		}
+		groups = append(groups, sealedGroup{g, gf.Tags})
+	}
+	if len(plain) == 0 {
+		return nil
+	}
+
+	id, aead, err := s.Keys.currentKey()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(strings.Join(plain, "\x01")), additionalData(msg))
+	data := id + ":" + base64.StdEncoding.EncodeToString(sealed)
+
+	for _, t := range s.Tags {
+		msg.Body.Remove(t)
+	}
+	for _, sg := range groups {
+		for i := 0; i < sg.group.Len(); i++ {
+			for _, t := range sg.tags {
+				if sg.group.Get(i).Has(t) {
+					sg.group.Get(i).SetString(t, Placeholder)
+				}
+			}
+		}
+		msg.Body.SetGroup(sg.group)
+	}
+	h := fix44.Header{Header: &msg.Header}
+	h.SetSecureDataLen(len(data))
+	h.SetSecureData(data)
+	return nil
+}
+
+// Open decrypts the SecureData of msg and restores its sensitive fields,
+// a message without SecureData is left untouched
+func (s *Sealer) Open(msg *quickfix.Message) error {
+	data, err := msg.Header.GetString(tag.SecureData)
+	if err != nil {
+		return nil
+	}
+	id, encoded, ok := strings.Cut(data, ":")
+	if !ok {
+		return ErrInvalidSecureData
+	}
+	aead, keyErr := s.Keys.key(id)
+	if keyErr != nil {
+		return keyErr
+	}
+	sealed, decodeErr := base64.StdEncoding.DecodeString(encoded)
+	if decodeErr != nil || len(sealed) < aead.NonceSize() {
+		return ErrInvalidSecureData
+	}
+	plain, openErr := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData(msg))
+	if openErr != nil {
+		return ErrInvalidSecureData
+	}
+
+	groupValues := make(map[quickfix.Tag]map[int]map[quickfix.Tag]string)
+	for _, line := range strings.Split(string(plain), "\x01") {
+		path, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return ErrInvalidSecureData
+		}
+		parts := strings.Split(path, "/")
+		nums := make([]int, len(parts))
+		for i, p := range parts {
+			n, err := strconv.Atoi(p)
+			if err != nil {
+				return ErrInvalidSecureData
+			}
+			nums[i] = n
+		}
+		switch len(nums) {
+		case 1:
+			msg.Body.SetString(quickfix.Tag(nums[0]), v)
+		case 3:
+			g := quickfix.Tag(nums[0])
+			if groupValues[g] == nil {
+				groupValues[g] = make(map[int]map[quickfix.Tag]string)
+			}
+			if groupValues[g][nums[1]] == nil {
+				groupValues[g][nums[1]] = make(map[quickfix.Tag]string)
+			}
+			groupValues[g][nums[1]][quickfix.Tag(nums[2])] = v
+		default:
+			return ErrInvalidSecureData
+		}
+	}
+	for _, gf := range s.Groups {
+		g := gf.NewGroup()
+		values, ok := groupValues[g.Tag()]
+		if !ok {
+			continue
+		}
+		if msg.Body.GetGroup(g) != nil {
+			return ErrInvalidSecureData
+		}
+		for i, fields := range values {
+			if i >= g.Len() {
+				return ErrInvalidSecureData
+			}
+			for t, v := range fields {
+				g.Get(i).SetString(t, v)
+			}
+		}
+		msg.Body.SetGroup(g)
+	}
+	msg.Header.Remove(tag.SecureData)
+	msg.Header.Remove(tag.SecureDataLen)
+	return nil
+}
+
+// ToApp seals msg, it is meant to be called from ToApp once the message is complete
+func (s *Sealer) ToApp(msg *quickfix.Message, sessionID quickfix.SessionID) error {
+	return s.Seal(msg)
+}
+
+// FromApp opens msg before it is handled, it is meant to be called from FromApp.
+// A SecureData that cannot be opened is rejected with SessionRejectReason 7.
+func (s *Sealer) FromApp(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	if err := s.Open(msg); err != nil {
+		t := tag.SecureData
+		return quickfix.NewMessageRejectError(err.Error(), sessionRejectReasonDecryption, &t)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package securedata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/newordersingle"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// sensitiveOrder returns an order with an Account and PartyIDs to seal, as sent on the session
+func sensitiveOrder() *quickfix.Message {
+	o := newordersingle.New(field.NewClOrdID("C1"), field.NewSide(enum.Side_BUY), field.NewTransactTime(time.Now()),
+		field.NewOrdType(enum.OrdType_LIMIT))
+	o.SetAccount("ACC1")
+	parties := newordersingle.NewNoPartyIDsRepeatingGroup()
+	parties.Add().SetPartyID("P1")
+	parties.Add().SetPartyID("P2")
+	o.SetNoPartyIDs(parties)
+	msg := o.ToMessage()
+	msg.Header.SetString(tag.SenderCompID, "FIRM")
+	msg.Header.SetString(tag.TargetCompID, "HNX")
+	msg.Header.SetInt(tag.MsgSeqNum, 3)
+	return msg
+}
+
+func keyring(t *testing.T) *Keyring {
+	t.Helper()
+	k := NewKeyring()
+	if err := k.Add("k1", make([]byte, 32)); err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestSealOpen(t *testing.T) {
+	k := keyring(t)
+	s := New(k)
+	msg := sensitiveOrder()
+	if err := s.Seal(msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Body.Has(tag.Account) || !msg.Header.Has(tag.SecureData) {
+		t.Fatal("Account not sealed")
+	}
+	sealed := newordersingle.FromMessage(msg)
+	g, _ := sealed.GetNoPartyIDs()
+	if id, _ := g.Get(0).GetPartyID(); id != Placeholder {
+		t.Errorf("got PartyID %s, want the placeholder", id)
+	}
+
+	// a rotated key still opens the messages sealed with the previous one
+	if err := k.Add("k2", make([]byte, 16)); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.SetCurrent("k2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Open(msg); err != nil {
+		t.Fatal(err)
+	}
+	opened := newordersingle.FromMessage(msg)
+	account, _ := opened.GetAccount()
+	g, _ = opened.GetNoPartyIDs()
+	p1, _ := g.Get(0).GetPartyID()
+	p2, _ := g.Get(1).GetPartyID()
+	if account != "ACC1" || p1 != "P1" || p2 != "P2" || msg.Header.Has(tag.SecureData) {
+		t.Errorf("got Account %s and PartyIDs %s, %s", account, p1, p2)
+	}
+}
+
+func TestOpenRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(msg *quickfix.Message, k *Keyring)
+		want   error
+	}{
+		{"unchanged", func(*quickfix.Message, *Keyring) {}, nil},
+		{"other sender", func(msg *quickfix.Message, k *Keyring) { msg.Header.SetString(tag.SenderCompID, "OTHER") }, ErrInvalidSecureData},
+		{"other MsgSeqNum", func(msg *quickfix.Message, k *Keyring) { msg.Header.SetInt(tag.MsgSeqNum, 4) }, ErrInvalidSecureData},
+		{"other MsgType", func(msg *quickfix.Message, k *Keyring) { msg.Header.SetString(tag.MsgType, "G") }, ErrInvalidSecureData},
+		{"corrupted", func(msg *quickfix.Message, k *Keyring) { msg.Header.SetString(tag.SecureData, "k1:AAAA") }, ErrInvalidSecureData},
+		{"removed key", func(msg *quickfix.Message, k *Keyring) {
+			k.Add("k2", make([]byte, 16))
+			k.SetCurrent("k2")
+			k.Remove("k1")
+		}, ErrUnknownKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := keyring(t)
+			s := New(k)
+			msg := sensitiveOrder()
+			if err := s.ToApp(msg, quickfix.SessionID{}); err != nil {
+				t.Fatal(err)
+			}
+			tt.change(msg, k)
+			err := s.FromApp(msg, quickfix.SessionID{})
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want.Error() || err.RejectReason() != sessionRejectReasonDecryption {
+				t.Errorf("got %v, want a reject of %v with SessionRejectReason %d", err, tt.want, sessionRejectReasonDecryption)
+			}
+		})
+	}
+}
+
+func TestSealNothing(t *testing.T) {
+	s := New(keyring(t))
+	msg := newordersingle.New(field.NewClOrdID("C1"), field.NewSide(enum.Side_BUY), field.NewTransactTime(time.Now()),
+		field.NewOrdType(enum.OrdType_LIMIT)).ToMessage()
+	if err := s.Seal(msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Header.Has(tag.SecureData) {
+		t.Error("got a SecureData for a message without sensitive fields")
+	}
+	if err := s.Open(msg); err != nil {
+		t.Errorf("got %v for a message without SecureData", err)
+	}
+}
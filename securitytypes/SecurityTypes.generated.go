@@ -1,6 +1,8 @@
 package securitytypes
 
 import (
+	"iter"
+
 	"github.com/quickfixgo/enum"
 	"github.com/quickfixgo/field"
 	"github.com/quickfixgo/fix44"
@@ -386,3 +388,49 @@ func (m NoSecurityTypesRepeatingGroup) Add() NoSecurityTypes {
 func (m NoSecurityTypesRepeatingGroup) Get(i int) NoSecurityTypes {
 	return NoSecurityTypes{m.RepeatingGroup.Get(i)}
 }
+
+//All returns an iterator over the index and NoSecurityTypes of each entry in the NoSecurityTypesRepeatingGroup
+func (m NoSecurityTypesRepeatingGroup) All() iter.Seq2[int, NoSecurityTypes] {
+	return func(yield func(int, NoSecurityTypes) bool) {
+		for i := 0; i < m.Len(); i++ {
+			if !yield(i, m.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+//Slice returns the NoSecurityTypes entries of the NoSecurityTypesRepeatingGroup as a slice
+func (m NoSecurityTypesRepeatingGroup) Slice() []NoSecurityTypes {
+	s := make([]NoSecurityTypes, m.Len())
+	for i := range s {
+		s[i] = m.Get(i)
+	}
+	return s
+}
+
+//NoSecurityTypesRow holds the values of a NoSecurityTypes, nil fields are left unset by AddRow
+type NoSecurityTypesRow struct {
+	SecurityType    *enum.SecurityType
+	SecuritySubType *string
+	Product         *enum.Product
+	CFICode         *string
+}
+
+//AddRow creates and appends a new NoSecurityTypes to this group, setting the fields present in row
+func (m NoSecurityTypesRepeatingGroup) AddRow(row NoSecurityTypesRow) NoSecurityTypes {
+	g := m.Add()
+	if row.SecurityType != nil {
+		g.SetSecurityType(*row.SecurityType)
+	}
+	if row.SecuritySubType != nil {
+		g.SetSecuritySubType(*row.SecuritySubType)
+	}
+	if row.Product != nil {
+		g.SetProduct(*row.Product)
+	}
+	if row.CFICode != nil {
+		g.SetCFICode(*row.CFICode)
+	}
+	return g
+}
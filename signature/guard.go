@@ -0,0 +1,136 @@
+package signature
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/quickfixgo/fix44"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// ErrNoSignature is returned for a message received without Signature
+var ErrNoSignature = errors.New("signature: no signature")
+
+// sessionRejectReasonSignature is the SessionRejectReason 8: signature problem
+const sessionRejectReasonSignature = 8
+
+// unsigned are the tags left out of the canonical form: the framing fields, the signature
+// itself, and the fields changed when a message is resent or forwarded by a hub
+var unsigned = map[quickfix.Tag]bool{
+	tag.BeginString:     true,
+	tag.BodyLength:      true,
+	tag.CheckSum:        true,
+	tag.SignatureLength: true,
+	tag.Signature:       true,
+	tag.PossDupFlag:     true,
+	tag.PossResend:      true,
+	tag.SendingTime:     true,
+	tag.OrigSendingTime: true,
+	tag.NoHops:          true,
+	tag.HopCompID:       true,
+	tag.HopSendingTime:  true,
+	tag.HopRefID:        true,
+}
+
+// Canonical returns the signed form of msg: its fields in wire order, without the unsigned
+// fields, preceded by the original SendingTime so that resent copies keep the same form
+func Canonical(msg *quickfix.Message) []byte {
+	var b bytes.Buffer
+	sendingTime, err := msg.Header.GetString(tag.OrigSendingTime)
+	if err != nil {
+		sendingTime, _ = msg.Header.GetString(tag.SendingTime)
+	}
+	b.WriteString("52=" + sendingTime + "\x01")
+	for _, raw := range strings.Split(msg.String(), "\x01") {
+		i := strings.IndexByte(raw, '=')
+		if i <= 0 {
+			continue
+		}
+		t, err := strconv.Atoi(raw[:i])
+		if err != nil || unsigned[quickfix.Tag(t)] {
+			continue
+		}
+		b.WriteString(raw)
+		b.WriteByte('\x01')
+	}
+	return b.Bytes()
+}
+
+// Sign sets the base64 Signature and the SignatureLength of msg in its Trailer
+func Sign(msg *quickfix.Message, s Signer) error {
+	msg.Trailer.Remove(tag.Signature)
+	msg.Trailer.Remove(tag.SignatureLength)
+	sig, err := s.Sign(Canonical(msg))
+	if err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(sig)
+	t := fix44.Trailer{Trailer: &msg.Trailer}
+	t.SetSignatureLength(len(encoded))
+	t.SetSignature(encoded)
+	return nil
+}
+
+// Verify returns ErrNoSignature or ErrInvalidSignature unless msg carries a valid Signature
+func Verify(msg *quickfix.Message, v Verifier) error {
+	t := fix44.Trailer{Trailer: &msg.Trailer}
+	encoded, err := t.GetSignature()
+	if err != nil {
+		return ErrNoSignature
+	}
+	sig, decodeErr := base64.StdEncoding.DecodeString(encoded)
+	if decodeErr != nil {
+		return ErrInvalidSignature
+	}
+	return v.Verify(Canonical(msg), sig)
+}
+
+// Guard signs the messages sent and verifies the messages received on a session,
+// messages missing or failing verification are rejected with SessionRejectReason 8.
+// Both sides must sign and verify the same messages, application messages only
+// unless ToAdmin and FromAdmin are also called.
+type Guard struct {
+	// Signer, if set, signs the messages sent
+	Signer Signer
+	// Verifier, if set, verifies the messages received
+	Verifier Verifier
+	// OnInvalid, if set, is called for each message rejected, e.g. to raise an alert
+	OnInvalid func(msg *quickfix.Message, sessionID quickfix.SessionID, err error)
+}
+
+// ToApp signs msg, it is meant to be called last from ToApp once the message is complete
+func (g *Guard) ToApp(msg *quickfix.Message, sessionID quickfix.SessionID) error {
+	if g.Signer == nil {
+		return nil
+	}
+	return Sign(msg, g.Signer)
+}
+
+// FromApp verifies msg, it is meant to be called first from FromApp
+func (g *Guard) FromApp(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	if g.Verifier == nil {
+		return nil
+	}
+	if err := Verify(msg, g.Verifier); err != nil {
+		if g.OnInvalid != nil {
+			g.OnInvalid(msg, sessionID, err)
+		}
+		t := tag.Signature
+		return quickfix.NewMessageRejectError(err.Error(), sessionRejectReasonSignature, &t)
+	}
+	return nil
+}
+
+// ToAdmin signs the admin messages sent, a signing error leaves msg unsigned
+func (g *Guard) ToAdmin(msg *quickfix.Message, sessionID quickfix.SessionID) {
+	g.ToApp(msg, sessionID)
+}
+
+// FromAdmin verifies the admin messages received
+func (g *Guard) FromAdmin(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+	return g.FromApp(msg, sessionID)
+}
@@ -0,0 +1,101 @@
+package signature
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+)
+
+// transmitted returns an order as sent on the session, with its session header fields set
+func transmitted() *quickfix.Message {
+	msg := quickfix.NewMessage()
+	msg.Header.SetString(tag.MsgType, "D")
+	msg.Header.SetString(tag.SenderCompID, "FIRM")
+	msg.Header.SetString(tag.TargetCompID, "HNX")
+	msg.Header.SetInt(tag.MsgSeqNum, 3)
+	msg.Header.SetString(tag.SendingTime, "20260101-01:00:00")
+	msg.Body.SetString(tag.ClOrdID, "C1")
+	msg.Body.SetString(tag.Symbol, "VND")
+	msg.Body.SetString(tag.OrderQty, "100")
+	return msg
+}
+
+func TestSignVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+	keys := []struct {
+		name     string
+		signer   Signer
+		verifier Verifier
+		other    Verifier
+	}{
+		{"HMAC", HMAC{Key: []byte("secret")}, HMAC{Key: []byte("secret")}, HMAC{Key: []byte("other")}},
+		{"Ed25519", Ed25519Signer{PrivateKey: priv}, Ed25519Verifier{PublicKey: pub}, Ed25519Verifier{PublicKey: otherPub}},
+	}
+	tests := []struct {
+		name   string
+		change func(msg *quickfix.Message)
+		other  bool
+		want   error
+	}{
+		{name: "unchanged", change: func(*quickfix.Message) {}},
+		{name: "resent", change: func(msg *quickfix.Message) {
+			msg.Header.SetString(tag.OrigSendingTime, "20260101-01:00:00")
+			msg.Header.SetString(tag.SendingTime, "20260101-02:00:00")
+			msg.Header.SetBool(tag.PossDupFlag, true)
+		}},
+		{name: "body changed", change: func(msg *quickfix.Message) { msg.Body.SetString(tag.ClOrdID, "C2") }, want: ErrInvalidSignature},
+		{name: "header changed", change: func(msg *quickfix.Message) { msg.Header.SetInt(tag.MsgSeqNum, 4) }, want: ErrInvalidSignature},
+		{name: "sending time changed", change: func(msg *quickfix.Message) {
+			msg.Header.SetString(tag.SendingTime, "20260101-02:00:00")
+		}, want: ErrInvalidSignature},
+		{name: "signature removed", change: func(msg *quickfix.Message) { msg.Trailer.Remove(tag.Signature) }, want: ErrNoSignature},
+		{name: "other key", change: func(*quickfix.Message) {}, other: true, want: ErrInvalidSignature},
+	}
+	for _, k := range keys {
+		for _, tt := range tests {
+			t.Run(k.name+"/"+tt.name, func(t *testing.T) {
+				msg := transmitted()
+				if err := Sign(msg, k.signer); err != nil {
+					t.Fatal(err)
+				}
+				tt.change(msg)
+				v := k.verifier
+				if tt.other {
+					v = k.other
+				}
+				if err := Verify(msg, v); !errors.Is(err, tt.want) {
+					t.Errorf("got %v, want %v", err, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestGuardRejects(t *testing.T) {
+	key := HMAC{Key: []byte("secret")}
+	g := &Guard{Signer: key, Verifier: key}
+	var invalid error
+	g.OnInvalid = func(msg *quickfix.Message, sessionID quickfix.SessionID, err error) { invalid = err }
+	msg := transmitted()
+	if err := g.ToApp(msg, quickfix.SessionID{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.FromApp(msg, quickfix.SessionID{}); err != nil {
+		t.Fatalf("got %v for a signed message", err)
+	}
+	msg.Body.SetString(tag.ClOrdID, "C2")
+	err := g.FromApp(msg, quickfix.SessionID{})
+	if err == nil || err.RejectReason() != sessionRejectReasonSignature {
+		t.Errorf("got %v, want a reject with SessionRejectReason %d", err, sessionRejectReasonSignature)
+	}
+	if !errors.Is(invalid, ErrInvalidSignature) {
+		t.Errorf("got OnInvalid error %v", invalid)
+	}
+}
@@ -0,0 +1,69 @@
+package signature
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+)
+
+// ErrInvalidSignature is returned for a signature that does not match the message
+var ErrInvalidSignature = errors.New("signature: invalid signature")
+
+// Signer computes the signature of the canonical form of a message
+type Signer interface {
+	Sign(data []byte) ([]byte, error)
+}
+
+// Verifier checks the signature of the canonical form of a message
+type Verifier interface {
+	// Verify returns ErrInvalidSignature if sig is not the signature of data
+	Verify(data, sig []byte) error
+}
+
+// HMAC signs and verifies with HMAC-SHA256 and a pre-shared key
+type HMAC struct {
+	Key []byte
+}
+
+// Sign implements Signer
+func (h HMAC) Sign(data []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, h.Key)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+// Verify implements Verifier
+func (h HMAC) Verify(data, sig []byte) error {
+	expected, _ := h.Sign(data)
+	if !hmac.Equal(expected, sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Ed25519Signer signs with the private key of the sender
+type Ed25519Signer struct {
+	PrivateKey ed25519.PrivateKey
+}
+
+// Sign implements Signer
+func (s Ed25519Signer) Sign(data []byte) ([]byte, error) {
+	if len(s.PrivateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("signature: invalid Ed25519 private key")
+	}
+	return ed25519.Sign(s.PrivateKey, data), nil
+}
+
+// Ed25519Verifier verifies with the public key of the counterparty
+type Ed25519Verifier struct {
+	PublicKey ed25519.PublicKey
+}
+
+// Verify implements Verifier
+func (v Ed25519Verifier) Verify(data, sig []byte) error {
+	if len(v.PublicKey) != ed25519.PublicKeySize || !ed25519.Verify(v.PublicKey, data, sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package tradecapture
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/tradecapturereport"
+	"github.com/quickfixgo/fix44/tradecapturereportack"
+	"github.com/quickfixgo/fix44/tradecapturereportrequest"
+	"github.com/quickfixgo/fix44/tradecapturereportrequestack"
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/tag"
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoStart is returned for a Filter with an End but no Start: a single NoDates entry
+// would be read by the counterparty as the start of the range
+var ErrNoStart = errors.New("tradecapture: filter has an End but no Start")
+
+// Trade is a trade from a TradeCaptureReport
+type Trade struct {
+	TradeReportID      string
+	TradeRequestID     string
+	TransType          enum.TradeReportTransType
+	ExecID             string
+	TrdMatchID         string
+	Symbol             string
+	LastQty            decimal.Decimal
+	LastPx             decimal.Decimal
+	TradeDate          string
+	TransactTime       time.Time
+	PreviouslyReported bool
+	Sides              []Side
+}
+
+// Side is an entry of the NoSides group of a TradeCaptureReport
+type Side struct {
+	Side    enum.Side
+	OrderID string
+	ClOrdID string
+	Account string
+}
+
+// FromReport returns the Trade of a TradeCaptureReport
+func FromReport(r tradecapturereport.TradeCaptureReport) (Trade, error) {
+	var t Trade
+	var err quickfix.MessageRejectError
+	if t.TradeReportID, err = r.GetTradeReportID(); err != nil {
+		return t, err
+	}
+	t.TradeRequestID, _ = r.GetTradeRequestID()
+	t.TransType, _ = r.GetTradeReportTransType()
+	t.ExecID, _ = r.GetExecID()
+	t.TrdMatchID, _ = r.GetTrdMatchID()
+	t.Symbol, _ = r.GetSymbol()
+	t.LastQty, _ = r.GetLastQty()
+	t.LastPx, _ = r.GetLastPx()
+	t.TradeDate, _ = r.GetTradeDate()
+	t.TransactTime, _ = r.GetTransactTime()
+	t.PreviouslyReported, _ = r.GetPreviouslyReported()
+	if sides, err := r.GetNoSides(); err == nil {
+		for _, s := range sides.All() {
+			var side Side
+			side.Side, _ = s.GetSide()
+			side.OrderID, _ = s.GetOrderID()
+			side.ClOrdID, _ = s.GetClOrdID()
+			side.Account, _ = s.GetAccount()
+			t.Sides = append(t.Sides, side)
+		}
+	}
+	return t, nil
+}
+
+// Filter selects the trades of a TradeCaptureReportRequest,
+// zero fields are not sent
+type Filter struct {
+	Symbol string
+	// Start and End bound the TransactTime of the trades, a single NoDates entry being
+	// read as a start, End cannot be set without Start
+	Start, End time.Time
+	// TradeDate selects the trades of a day, as YYYYMMDD
+	TradeDate string
+}
+
+// Result is the answer to a TradeCaptureReportRequest
+type Result struct {
+	TradeRequestID string
+	Trades         []Trade
+	// Err is a *RequestError when the request was rejected
+	Err error
+}
+
+// RequestError is a TradeCaptureReportRequestAck rejecting a request
+type RequestError struct {
+	TradeRequestID string
+	Result         enum.TradeRequestResult
+	Text           string
+}
+
+func (e *RequestError) Error() string {
+	s := fmt.Sprintf("tradecapture: request %s rejected with TradeRequestResult %s", e.TradeRequestID, e.Result)
+	if e.Text != "" {
+		s += ": " + e.Text
+	}
+	return s
+}
+
+type request struct {
+	trades []Trade
+	// total is the TotNumTradeReports announced by the counterparty, -1 if unknown
+	total int
+	done  func(Result)
+}
+
+// Client sends TradeCaptureReportRequests, assembles the TradeCaptureReports answering
+// them and acknowledges the received reports.
+// A Client is safe for concurrent use.
+type Client struct {
+	SessionID quickfix.SessionID
+	// Send defaults to quickfix.SendToTarget
+	Send func(m quickfix.Messagable, sessionID quickfix.SessionID) error
+	// AutoAck sends an accepting TradeCaptureReportAck for each received report
+	AutoAck bool
+	// OnTrade, if set, is called for each received trade, including unsolicited ones
+	OnTrade func(Trade)
+
+	mu       sync.Mutex
+	nextID   int
+	idPrefix string
+	requests map[string]*request
+}
+
+// NewClient returns a Client for the session
+func NewClient(sessionID quickfix.SessionID) *Client {
+	return &Client{
+		SessionID: sessionID,
+		Send:      quickfix.SendToTarget,
+		idPrefix:  time.Now().Format("150405") + "-",
+		requests:  make(map[string]*request),
+	}
+}
+
+// Request asks for the trades matching f, done is called once all the reports are received
+// or the request is rejected. It returns the TradeRequestID.
+func (c *Client) Request(f Filter, done func(Result)) (string, error) {
+	if f.Start.IsZero() && !f.End.IsZero() {
+		return "", ErrNoStart
+	}
+	c.mu.Lock()
+	c.nextID++
+	id := fmt.Sprintf("%s%d", c.idPrefix, c.nextID)
+	c.requests[id] = &request{total: -1, done: done}
+	c.mu.Unlock()
+
+	req := tradecapturereportrequest.New(field.NewTradeRequestID(id), field.NewTradeRequestType(enum.TradeRequestType_ALL_TRADES))
+	req.SetSubscriptionRequestType(enum.SubscriptionRequestType_SNAPSHOT)
+	if f.Symbol != "" {
+		req.SetSymbol(f.Symbol)
+	}
+	dates := tradecapturereportrequest.NewNoDatesRepeatingGroup()
+	for _, t := range []time.Time{f.Start, f.End} {
+		if !t.IsZero() {
+			d := dates.Add()
+			if f.TradeDate != "" {
+				d.SetTradeDate(f.TradeDate)
+			}
+			d.SetTransactTime(t)
+		}
+	}
+	if dates.Len() == 0 && f.TradeDate != "" {
+		dates.Add().SetTradeDate(f.TradeDate)
+	}
+	if dates.Len() > 0 {
+		req.SetNoDates(dates)
+	}
+	if err := c.Send(req, c.SessionID); err != nil {
+		c.mu.Lock()
+		delete(c.requests, id)
+		c.mu.Unlock()
+		return "", err
+	}
+	return id, nil
+}
+
+// Pending returns the TradeRequestIDs still waiting for reports, sorted
+func (c *Client) Pending() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]string, 0, len(c.requests))
+	for id := range c.requests {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Process handles a TradeCaptureReportRequestAck or a TradeCaptureReport,
+// other messages are ignored. It is meant to be called from FromApp.
+func (c *Client) Process(msg *quickfix.Message) error {
+	msgType, err := msg.Header.GetString(tag.MsgType)
+	if err != nil {
+		return err
+	}
+	switch msgType {
+	case "AQ":
+		return c.OnRequestAck(tradecapturereportrequestack.FromMessage(msg))
+	case "AE":
+		return c.OnReport(tradecapturereport.FromMessage(msg))
+	}
+	return nil
+}
+
+// OnRequestAck records the number of reports announced for a request,
+// or ends it when it is rejected or answered with no report
+func (c *Client) OnRequestAck(a tradecapturereportrequestack.TradeCaptureReportRequestAck) error {
+	id, err := a.GetTradeRequestID()
+	if err != nil {
+		return err
+	}
+	result, _ := a.GetTradeRequestResult()
+	status, _ := a.GetTradeRequestStatus()
+	total, totalErr := a.GetTotNumTradeReports()
+
+	c.mu.Lock()
+	r, ok := c.requests[id]
+	if !ok {
+		c.mu.Unlock()
+		return nil
+	}
+	var res *Result
+	switch {
+	case result != enum.TradeRequestResult_SUCCESSFUL || status == enum.TradeRequestStatus_REJECTED:
+		rejErr := &RequestError{TradeRequestID: id, Result: result}
+		rejErr.Text, _ = a.GetText()
+		res = &Result{TradeRequestID: id, Trades: r.trades, Err: rejErr}
+	case totalErr == nil:
+		r.total = total
+		if len(r.trades) >= total {
+			res = &Result{TradeRequestID: id, Trades: r.trades}
+		}
+	}
+	if res != nil {
+		delete(c.requests, id)
+	}
+	c.mu.Unlock()
+	if res != nil && r.done != nil {
+		r.done(*res)
+	}
+	return nil
+}
+
+// OnReport adds the trade of a report to its request and acknowledges it if AutoAck is set.
+// A request is complete on its report with LastRptRequested or when TotNumTradeReports reports are received.
+func (c *Client) OnReport(r tradecapturereport.TradeCaptureReport) error {
+	t, err := FromReport(r)
+	if err != nil {
+		return err
+	}
+	if c.OnTrade != nil {
+		c.OnTrade(t)
+	}
+	if c.AutoAck {
+		if err := c.ack(r, t); err != nil {
+			return err
+		}
+	}
+	if t.TradeRequestID == "" {
+		return nil
+	}
+	last, _ := r.GetLastRptRequested()
+	total, totalErr := r.GetTotNumTradeReports()
+
+	c.mu.Lock()
+	req, ok := c.requests[t.TradeRequestID]
+	if !ok {
+		c.mu.Unlock()
+		return nil
+	}
+	req.trades = append(req.trades, t)
+	if totalErr == nil {
+		req.total = total
+	}
+	complete := last || (req.total >= 0 && len(req.trades) >= req.total)
+	if complete {
+		delete(c.requests, t.TradeRequestID)
+	}
+	c.mu.Unlock()
+	if complete && req.done != nil {
+		req.done(Result{TradeRequestID: t.TradeRequestID, Trades: req.trades})
+	}
+	return nil
+}
+
+func (c *Client) ack(r tradecapturereport.TradeCaptureReport, t Trade) error {
+	execType, err := r.GetExecType()
+	if err != nil {
+		execType = enum.ExecType_TRADE
+	}
+	a := tradecapturereportack.New(field.NewTradeReportID(t.TradeReportID), field.NewExecType(execType))
+	if t.TransType != "" {
+		a.SetTradeReportTransType(t.TransType)
+	}
+	a.SetTrdRptStatus(enum.TrdRptStatus_ACCEPTED)
+	if t.Symbol != "" {
+		a.SetSymbol(t.Symbol)
+	}
+	return c.Send(a, c.SessionID)
+}
@@ -0,0 +1,138 @@
+package tradecapture
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/field"
+	"github.com/quickfixgo/fix44/tradecapturereport"
+	"github.com/quickfixgo/fix44/tradecapturereportrequestack"
+	"github.com/quickfixgo/quickfix"
+	"github.com/shopspring/decimal"
+)
+
+// capture returns a TradeCaptureReport answering the request
+func capture(tradeRequestID, tradeReportID string, last bool) *quickfix.Message {
+	r := tradecapturereport.New(field.NewTradeReportID(tradeReportID), field.NewPreviouslyReported(true),
+		field.NewLastQty(decimal.NewFromInt(100), 0), field.NewLastPx(decimal.NewFromInt(10), 0),
+		field.NewTradeDate("20261019"), field.NewTransactTime(time.Now()))
+	r.SetTradeRequestID(tradeRequestID)
+	r.SetExecID("E" + tradeReportID)
+	if last {
+		r.SetLastRptRequested(true)
+	}
+	return r.ToMessage()
+}
+
+// requestAck returns a TradeCaptureReportRequestAck of the request announcing total reports, if not negative
+func requestAck(tradeRequestID string, result enum.TradeRequestResult, total int) *quickfix.Message {
+	status := enum.TradeRequestStatus_ACCEPTED
+	if result != enum.TradeRequestResult_SUCCESSFUL {
+		status = enum.TradeRequestStatus_REJECTED
+	}
+	a := tradecapturereportrequestack.New(field.NewTradeRequestID(tradeRequestID), field.NewTradeRequestType(enum.TradeRequestType_ALL_TRADES),
+		field.NewTradeRequestResult(result), field.NewTradeRequestStatus(status))
+	if total >= 0 {
+		a.SetTotNumTradeReports(total)
+	}
+	return a.ToMessage()
+}
+
+func TestRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		// answer returns the messages answering the request with the TradeRequestID
+		answer     func(id string) []*quickfix.Message
+		wantTrades int
+		wantDone   bool
+		wantErr    bool
+	}{
+		{"last report", func(id string) []*quickfix.Message {
+			return []*quickfix.Message{capture(id, "T1", false), capture(id, "T2", true)}
+		}, 2, true, false},
+		{"announced total", func(id string) []*quickfix.Message {
+			return []*quickfix.Message{requestAck(id, enum.TradeRequestResult_SUCCESSFUL, 2), capture(id, "T1", false), capture(id, "T2", false)}
+		}, 2, true, false},
+		{"total reached before the ack", func(id string) []*quickfix.Message {
+			return []*quickfix.Message{capture(id, "T1", false), requestAck(id, enum.TradeRequestResult_SUCCESSFUL, 1)}
+		}, 1, true, false},
+		{"no trade", func(id string) []*quickfix.Message {
+			return []*quickfix.Message{requestAck(id, enum.TradeRequestResult_SUCCESSFUL, 0)}
+		}, 0, true, false},
+		{"waiting", func(id string) []*quickfix.Message {
+			return []*quickfix.Message{requestAck(id, enum.TradeRequestResult_SUCCESSFUL, 3), capture(id, "T1", false)}
+		}, 0, false, false},
+		{"rejected", func(id string) []*quickfix.Message {
+			return []*quickfix.Message{requestAck(id, enum.TradeRequestResult_INVALID_OR_UNKNOWN_INSTRUMENT, -1)}
+		}, 0, true, true},
+		{"other request", func(id string) []*quickfix.Message {
+			return []*quickfix.Message{capture("other", "T1", true)}
+		}, 0, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(quickfix.SessionID{})
+			c.Send = func(quickfix.Messagable, quickfix.SessionID) error { return nil }
+			var results []Result
+			id, err := c.Request(Filter{Symbol: "VND"}, func(r Result) { results = append(results, r) })
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, msg := range tt.answer(id) {
+				if err := c.Process(msg); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !tt.wantDone {
+				if len(results) != 0 || len(c.Pending()) != 1 {
+					t.Errorf("got results %v and pending %v, want the request pending", results, c.Pending())
+				}
+				return
+			}
+			if len(results) != 1 || len(c.Pending()) != 0 {
+				t.Fatalf("got results %v and pending %v, want the request done once", results, c.Pending())
+			}
+			var reqErr *RequestError
+			if len(results[0].Trades) != tt.wantTrades || errors.As(results[0].Err, &reqErr) != tt.wantErr {
+				t.Errorf("got %+v", results[0])
+			}
+		})
+	}
+}
+
+func TestRequestFilter(t *testing.T) {
+	c := NewClient(quickfix.SessionID{})
+	sent := 0
+	c.Send = func(quickfix.Messagable, quickfix.SessionID) error {
+		sent++
+		return nil
+	}
+	if _, err := c.Request(Filter{End: time.Now()}, nil); !errors.Is(err, ErrNoStart) || sent != 0 {
+		t.Errorf("got %v and %d sent, want %v", err, sent, ErrNoStart)
+	}
+	if _, err := c.Request(Filter{Start: time.Now().Add(-time.Hour), End: time.Now()}, nil); err != nil || sent != 1 {
+		t.Errorf("got %v and %d sent", err, sent)
+	}
+}
+
+func TestAutoAck(t *testing.T) {
+	c := NewClient(quickfix.SessionID{})
+	c.AutoAck = true
+	var acks []string
+	c.Send = func(m quickfix.Messagable, sessionID quickfix.SessionID) error {
+		msgType, _ := m.ToMessage().MsgType()
+		acks = append(acks, msgType)
+		return nil
+	}
+	var trades []Trade
+	c.OnTrade = func(tr Trade) { trades = append(trades, tr) }
+	// an unsolicited report
+	if err := c.Process(capture("", "T1", false)); err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 || trades[0].ExecID != "ET1" || len(acks) != 1 || acks[0] != "AR" {
+		t.Errorf("got trades %+v and acks %v", trades, acks)
+	}
+}
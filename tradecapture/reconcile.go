@@ -0,0 +1,114 @@
+package tradecapture
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/ordertracker"
+)
+
+// BreakKind tells why a fill and a trade do not reconcile
+type BreakKind int
+
+const (
+	// MissingTrade is a fill without trade report
+	MissingTrade BreakKind = iota
+	// MissingFill is a trade report without fill
+	MissingFill
+	// QtyMismatch is a fill and a trade with the same ExecID and different quantities
+	QtyMismatch
+	// PxMismatch is a fill and a trade with the same ExecID and different prices
+	PxMismatch
+)
+
+func (k BreakKind) String() string {
+	switch k {
+	case MissingTrade:
+		return "missing trade"
+	case MissingFill:
+		return "missing fill"
+	case QtyMismatch:
+		return "quantity mismatch"
+	case PxMismatch:
+		return "price mismatch"
+	}
+	return fmt.Sprintf("BreakKind(%d)", int(k))
+}
+
+// Break is a difference between the fills and the captured trades
+type Break struct {
+	Kind   BreakKind
+	ExecID string
+	// Fill is nil for MissingFill, Trade is nil for MissingTrade
+	Fill  *ordertracker.Fill
+	Trade *Trade
+}
+
+// Report is the result of a reconciliation
+type Report struct {
+	Matched int
+	Breaks  []Break
+}
+
+// String renders the report one break per line, after a summary line
+func (r Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "matched %d, breaks %d\n", r.Matched, len(r.Breaks))
+	for _, br := range r.Breaks {
+		fmt.Fprintf(&b, "%s ExecID=%s", br.Kind, br.ExecID)
+		if br.Fill != nil {
+			fmt.Fprintf(&b, " fill=%s@%s", br.Fill.LastQty, br.Fill.LastPx)
+		}
+		if br.Trade != nil {
+			fmt.Fprintf(&b, " trade=%s@%s TradeReportID=%s", br.Trade.LastQty, br.Trade.LastPx, br.Trade.TradeReportID)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Reconcile matches fills, e.g. from ordertracker.Tracker.Fills, with captured trades by ExecID.
+// For an ExecID reported several times the last report wins, and canceled trades are left out.
+// Trades without ExecID cannot be matched with a fill and are left out too.
+// Breaks are sorted by ExecID.
+func Reconcile(fills []ordertracker.Fill, trades []Trade) Report {
+	byExecID := make(map[string]Trade, len(trades))
+	for _, t := range trades {
+		if t.ExecID == "" {
+			continue
+		}
+		if t.TransType == enum.TradeReportTransType_CANCEL {
+			delete(byExecID, t.ExecID)
+			continue
+		}
+		byExecID[t.ExecID] = t
+	}
+
+	var r Report
+	seen := make(map[string]bool, len(fills))
+	for i := range fills {
+		f := &fills[i]
+		seen[f.ExecID] = true
+		t, ok := byExecID[f.ExecID]
+		switch {
+		case !ok:
+			r.Breaks = append(r.Breaks, Break{Kind: MissingTrade, ExecID: f.ExecID, Fill: f})
+		case !t.LastQty.Equal(f.LastQty):
+			r.Breaks = append(r.Breaks, Break{Kind: QtyMismatch, ExecID: f.ExecID, Fill: f, Trade: &t})
+		case !t.LastPx.Equal(f.LastPx):
+			r.Breaks = append(r.Breaks, Break{Kind: PxMismatch, ExecID: f.ExecID, Fill: f, Trade: &t})
+		default:
+			r.Matched++
+		}
+	}
+	for execID, t := range byExecID {
+		if !seen[execID] {
+			t := t
+			r.Breaks = append(r.Breaks, Break{Kind: MissingFill, ExecID: execID, Trade: &t})
+		}
+	}
+	sort.SliceStable(r.Breaks, func(i, j int) bool { return r.Breaks[i].ExecID < r.Breaks[j].ExecID })
+	return r
+}
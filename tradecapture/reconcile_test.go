@@ -0,0 +1,61 @@
+package tradecapture
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/quickfixgo/enum"
+	"github.com/quickfixgo/fix44/ordertracker"
+	"github.com/shopspring/decimal"
+)
+
+func fill(execID string, qty, px int64) ordertracker.Fill {
+	return ordertracker.Fill{ExecID: execID, LastQty: decimal.NewFromInt(qty), LastPx: decimal.NewFromInt(px)}
+}
+
+func captured(execID string, transType enum.TradeReportTransType, qty, px int64) Trade {
+	return Trade{TradeReportID: "T" + execID, ExecID: execID, TransType: transType, LastQty: decimal.NewFromInt(qty), LastPx: decimal.NewFromInt(px)}
+}
+
+func TestReconcile(t *testing.T) {
+	type brk struct {
+		kind   BreakKind
+		execID string
+	}
+	tests := []struct {
+		name        string
+		fills       []ordertracker.Fill
+		trades      []Trade
+		wantMatched int
+		want        []brk
+	}{
+		{"matched", []ordertracker.Fill{fill("E1", 100, 10)}, []Trade{captured("E1", enum.TradeReportTransType_NEW, 100, 10)}, 1, nil},
+		{"missing trade", []ordertracker.Fill{fill("E1", 100, 10)}, nil, 0, []brk{{MissingTrade, "E1"}}},
+		{"missing fill", nil, []Trade{captured("E1", enum.TradeReportTransType_NEW, 100, 10)}, 0, []brk{{MissingFill, "E1"}}},
+		{"quantity", []ordertracker.Fill{fill("E1", 100, 10)}, []Trade{captured("E1", enum.TradeReportTransType_NEW, 200, 10)}, 0, []brk{{QtyMismatch, "E1"}}},
+		{"price", []ordertracker.Fill{fill("E1", 100, 10)}, []Trade{captured("E1", enum.TradeReportTransType_NEW, 100, 11)}, 0, []brk{{PxMismatch, "E1"}}},
+		{"last report wins", []ordertracker.Fill{fill("E1", 100, 10)}, []Trade{
+			captured("E1", enum.TradeReportTransType_NEW, 200, 10),
+			captured("E1", enum.TradeReportTransType_REPLACE, 100, 10),
+		}, 1, nil},
+		{"canceled trade", []ordertracker.Fill{fill("E1", 100, 10)}, []Trade{
+			captured("E1", enum.TradeReportTransType_NEW, 100, 10),
+			captured("E1", enum.TradeReportTransType_CANCEL, 100, 10),
+		}, 0, []brk{{MissingTrade, "E1"}}},
+		{"trade without ExecID", nil, []Trade{captured("", enum.TradeReportTransType_NEW, 100, 10)}, 0, nil},
+		{"sorted by ExecID", []ordertracker.Fill{fill("E3", 1, 1), fill("E1", 1, 1)}, []Trade{captured("E2", enum.TradeReportTransType_NEW, 1, 1)},
+			0, []brk{{MissingTrade, "E1"}, {MissingFill, "E2"}, {MissingTrade, "E3"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Reconcile(tt.fills, tt.trades)
+			var got []brk
+			for _, b := range r.Breaks {
+				got = append(got, brk{b.Kind, b.ExecID})
+			}
+			if r.Matched != tt.wantMatched || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %d matched and breaks %v, want %d and %v", r.Matched, got, tt.wantMatched, tt.want)
+			}
+		})
+	}
+}